@@ -0,0 +1,116 @@
+// sdk-go/internal/api/batching.go
+package api
+
+import (
+	"fmt"
+
+	"github.com/usercanal/sdk-go/internal/batch"
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// WithEventBatching tunes the event queue independently of other streams
+func WithEventBatching(cfg *types.BatchConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.eventBatch = *cfg
+		}
+	}
+}
+
+// WithLogBatching tunes the log queue independently of other streams
+func WithLogBatching(cfg *types.BatchConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.logBatch = *cfg
+		}
+	}
+}
+
+// validateBatchConfig checks one stream's settings
+func validateBatchConfig(stream string, bc types.BatchConfig) error {
+	if bc.Size < 0 || bc.FlushInterval < 0 || bc.MaxBatchBytes < 0 || bc.MaxQueueSize < 0 || bc.MaxQueueBytes < 0 {
+		return types.NewValidationError(stream, "batch settings cannot be negative")
+	}
+	if bc.Overflow < types.OverflowDropOldest || bc.Overflow > types.OverflowBlock {
+		return types.NewValidationError(stream+".Overflow", fmt.Sprintf("invalid overflow policy %d", bc.Overflow))
+	}
+	if bc.Jitter < 0 || bc.Jitter > 1 {
+		return types.NewValidationError(stream+".Jitter", "must be between 0 and 1")
+	}
+	if a := bc.Adaptive; a != nil {
+		if a.MinSize < 0 || a.MaxSize < 0 || a.MinInterval < 0 || a.MaxInterval < 0 || a.TargetLatency < 0 {
+			return types.NewValidationError(stream+".Adaptive", "bounds cannot be negative")
+		}
+		if a.MinSize > transport.MaxBatchItems {
+			return types.NewValidationError(stream+".Adaptive", fmt.Sprintf("MinSize cannot exceed the transport limit of %d items", transport.MaxBatchItems))
+		}
+		if a.MaxSize > 0 && a.MinSize > a.MaxSize {
+			return types.NewValidationError(stream+".Adaptive", "MinSize cannot exceed MaxSize")
+		}
+		if a.MaxInterval > 0 && a.MinInterval > a.MaxInterval {
+			return types.NewValidationError(stream+".Adaptive", "MinInterval cannot exceed MaxInterval")
+		}
+	}
+	return nil
+}
+
+// newStreamBatcher creates a stream's batcher, filling unset values from the client config
+func newStreamBatcher[T any](cfg *config, bc types.BatchConfig, size batch.SizeFunc[T], send batch.SendFunc[T]) *batch.Manager[T] {
+	if bc.Size <= 0 {
+		bc.Size = cfg.batchSize
+	}
+	if bc.FlushInterval <= 0 {
+		bc.FlushInterval = cfg.flushInterval
+	}
+	if bc.Size > transport.MaxBatchItems {
+		logger.Warn("Batch size %d exceeds the transport limit, using %d", bc.Size, transport.MaxBatchItems)
+		bc.Size = transport.MaxBatchItems
+	}
+	if bc.MaxBatchBytes <= 0 || bc.MaxBatchBytes > transport.MaxBatchBytes {
+		bc.MaxBatchBytes = transport.MaxBatchBytes
+	}
+	opts := []batch.Option[T]{
+		batch.WithSizeFunc(size),
+		batch.WithMaxBatchBytes[T](bc.MaxBatchBytes),
+		batch.WithQueueLimit[T](bc.MaxQueueSize, bc.MaxQueueBytes, bc.Overflow),
+		batch.WithJitter[T](bc.Jitter),
+	}
+	if bc.Adaptive != nil {
+		bounds := *bc.Adaptive
+		if bounds.MaxSize <= 0 || bounds.MaxSize > transport.MaxBatchItems {
+			bounds.MaxSize = transport.MaxBatchItems
+		}
+		opts = append(opts, batch.WithAdaptive[T](bounds))
+	}
+	return batch.NewManager(bc.Size, bc.FlushInterval, send, opts...)
+}
+
+func eventSize(event *transport.Event) int {
+	return event.EncodedSize()
+}
+
+func logSize(log *transport.Log) int {
+	return log.EncodedSize()
+}
+
+// streamStats summarises a stream's batchers, reporting the settings of the first;
+// sent comes from the transport
+func streamStats[T any](sent int64, batchers ...*batch.Manager[T]) types.StreamStats {
+	stats := types.StreamStats{
+		Sent:          sent,
+		BatchSize:     batchers[0].BatchSize(),
+		BatchBytes:    batchers[0].MaxBatchBytes(),
+		FlushInterval: batchers[0].FlushInterval(),
+		Adaptive:      batchers[0].Adaptive(),
+		SendLatency:   batchers[0].SendLatency(),
+	}
+	for _, b := range batchers {
+		stats.InQueue += b.QueueSize()
+		stats.QueueBytes += b.QueueBytes()
+		stats.Failed += b.FailedCount()
+		stats.Dropped += b.DroppedCount()
+	}
+	return stats
+}
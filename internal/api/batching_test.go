@@ -0,0 +1,113 @@
+// sdk-go/internal/api/batching_test.go
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/batch"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestValidateBatchConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       types.BatchConfig
+		wantField string // "" when valid
+	}{
+		{name: "zero values", cfg: types.BatchConfig{}},
+		{name: "bounded queue", cfg: types.BatchConfig{Size: 50, MaxQueueSize: 1000, MaxQueueBytes: 1 << 20, Overflow: types.OverflowBlock}},
+		{name: "negative size", cfg: types.BatchConfig{Size: -1}, wantField: "EventBatching"},
+		{name: "negative queue size", cfg: types.BatchConfig{MaxQueueSize: -1}, wantField: "EventBatching"},
+		{name: "negative queue bytes", cfg: types.BatchConfig{MaxQueueBytes: -1}, wantField: "EventBatching"},
+		{name: "negative interval", cfg: types.BatchConfig{FlushInterval: -time.Second}, wantField: "EventBatching"},
+		{name: "unknown overflow policy", cfg: types.BatchConfig{Overflow: types.OverflowBlock + 1}, wantField: "EventBatching.Overflow"},
+		{name: "negative overflow policy", cfg: types.BatchConfig{Overflow: -1}, wantField: "EventBatching.Overflow"},
+		{name: "jitter", cfg: types.BatchConfig{Jitter: 0.2}},
+		{name: "jitter above one", cfg: types.BatchConfig{Jitter: 1.5}, wantField: "EventBatching.Jitter"},
+		{name: "negative jitter", cfg: types.BatchConfig{Jitter: -0.1}, wantField: "EventBatching.Jitter"},
+		{name: "adaptive defaults", cfg: types.BatchConfig{Adaptive: &types.AdaptiveBatching{}}},
+		{
+			name:      "negative adaptive bound",
+			cfg:       types.BatchConfig{Adaptive: &types.AdaptiveBatching{MinInterval: -time.Second}},
+			wantField: "EventBatching.Adaptive",
+		},
+		{
+			name:      "adaptive MinSize above MaxSize",
+			cfg:       types.BatchConfig{Adaptive: &types.AdaptiveBatching{MinSize: 100, MaxSize: 50}},
+			wantField: "EventBatching.Adaptive",
+		},
+		{
+			name:      "adaptive MinSize above the transport limit",
+			cfg:       types.BatchConfig{Adaptive: &types.AdaptiveBatching{MinSize: transport.MaxBatchItems + 1}},
+			wantField: "EventBatching.Adaptive",
+		},
+		{
+			name:      "adaptive MinInterval above MaxInterval",
+			cfg:       types.BatchConfig{Adaptive: &types.AdaptiveBatching{MinInterval: time.Minute, MaxInterval: time.Second}},
+			wantField: "EventBatching.Adaptive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBatchConfig("EventBatching", tt.cfg)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("validateBatchConfig = %v, want nil", err)
+				}
+				return
+			}
+			var verr *types.ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.wantField {
+				t.Errorf("validateBatchConfig = %v, want a %s ValidationError", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidBatching(t *testing.T) {
+	_, err := New(testAPIKey, WithLogBatching(&types.BatchConfig{MaxQueueSize: -1}))
+	if err == nil {
+		t.Fatal("New accepted a negative log queue size")
+	}
+}
+
+func TestStatsSeparateFailures(t *testing.T) {
+	c := newTestClient(t)
+
+	// Swap in a log queue whose sends fail
+	c.logBatcher.Close()
+	c.logBatcher = batch.NewManager(10, time.Hour, func(context.Context, []*transport.Log) error {
+		return errors.New("connection reset")
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		c.Log(ctx, types.LogEntry{Level: types.LogInfo, Service: "api", Source: "host-1", Message: "m"})
+	}
+	if err := c.logBatcher.Flush(ctx); err == nil {
+		t.Fatal("expected the log flush to fail")
+	}
+	c.Track(ctx, types.Event{UserId: "user_1", Name: types.FeatureUsed})
+	if err := c.eventBatcher.Flush(ctx); err != nil {
+		t.Fatalf("event flush: %v", err)
+	}
+
+	stats := c.GetStats()
+	if stats.LogsFailed != 3 || stats.Logs.Failed != 3 {
+		t.Errorf("LogsFailed = %d, Logs.Failed = %d, want 3", stats.LogsFailed, stats.Logs.Failed)
+	}
+	if stats.EventsFailed != 0 || stats.Events.Failed != 0 {
+		t.Errorf("EventsFailed = %d, Events.Failed = %d, want log failures left out", stats.EventsFailed, stats.Events.Failed)
+	}
+	if stats.LogsInQueue != 3 {
+		t.Errorf("LogsInQueue = %d, want the failed logs re-queued", stats.LogsInQueue)
+	}
+
+	// The failing queue would otherwise fail the client's Close
+	c.logBatcher.Remove(func(*transport.Log) bool { return true })
+}
@@ -0,0 +1,235 @@
+// sdk-go/internal/api/builder.go
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// eventKind selects which client path an EventBuilder sends through
+type eventKind uint8
+
+const (
+	kindTrack eventKind = iota
+	kindIdentify
+	kindGroup
+	kindRevenue
+	kindAlias
+)
+
+// EventBuilder assembles an event step by step and validates it when sent. It is
+// not safe for concurrent use; build one per event.
+type EventBuilder struct {
+	client *Client
+	kind   eventKind
+
+	id         string
+	userID     string
+	name       types.EventName
+	groupID    string
+	previousID string
+	deviceID   []byte
+	sessionID  []byte
+	timestamp  time.Time
+	properties types.Properties
+
+	orderID     string
+	amount      float64
+	currency    types.Currency
+	revenueType types.RevenueType
+	products    []types.Product
+}
+
+// NewTrack starts a track event with the given name
+func (c *Client) NewTrack(name types.EventName) *EventBuilder {
+	return &EventBuilder{client: c, kind: kindTrack, name: name}
+}
+
+// NewIdentify starts an identify event whose properties are the user's traits
+func (c *Client) NewIdentify(userID string) *EventBuilder {
+	return &EventBuilder{client: c, kind: kindIdentify, userID: userID}
+}
+
+// NewGroup starts a group event associating a user with groupID
+func (c *Client) NewGroup(groupID string) *EventBuilder {
+	return &EventBuilder{client: c, kind: kindGroup, groupID: groupID}
+}
+
+// NewRevenue starts a revenue event, a one-time payment unless WithRevenueType says otherwise
+func (c *Client) NewRevenue(orderID string, amount float64, currency types.Currency) *EventBuilder {
+	return &EventBuilder{
+		client:      c,
+		kind:        kindRevenue,
+		orderID:     orderID,
+		amount:      amount,
+		currency:    currency,
+		revenueType: types.RevenueTypeOneTime,
+	}
+}
+
+// NewAlias starts an alias event linking previousID to userID
+func (c *Client) NewAlias(previousID, userID string) *EventBuilder {
+	return &EventBuilder{client: c, kind: kindAlias, previousID: previousID, userID: userID}
+}
+
+// WithUserID sets the user the event belongs to
+func (b *EventBuilder) WithUserID(userID string) *EventBuilder {
+	b.userID = userID
+	return b
+}
+
+// WithEventID sets an ID the collector can use to deduplicate the event
+func (b *EventBuilder) WithEventID(id string) *EventBuilder {
+	b.id = id
+	return b
+}
+
+// WithDeviceID overrides the device ID (16-byte UUID)
+func (b *EventBuilder) WithDeviceID(deviceID []byte) *EventBuilder {
+	b.deviceID = deviceID
+	return b
+}
+
+// WithSessionID links the event to a session (16-byte UUID)
+func (b *EventBuilder) WithSessionID(sessionID []byte) *EventBuilder {
+	b.sessionID = sessionID
+	return b
+}
+
+// WithoutSession clears any session ID so the event is not linked to a session
+func (b *EventBuilder) WithoutSession() *EventBuilder {
+	b.sessionID = nil
+	return b
+}
+
+// WithTimestamp overrides the time the event happened, which defaults to when it is sent
+func (b *EventBuilder) WithTimestamp(t time.Time) *EventBuilder {
+	b.timestamp = t
+	return b
+}
+
+// WithProperties adds properties, replacing any already set under the same keys.
+// The map is copied, so it may be reused after the call.
+func (b *EventBuilder) WithProperties(props types.Properties) *EventBuilder {
+	for k, v := range props {
+		b.set(k, v)
+	}
+	return b
+}
+
+// WithProperty sets one property of any supported type
+func (b *EventBuilder) WithProperty(key string, value interface{}) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithString sets a string property
+func (b *EventBuilder) WithString(key, value string) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithInt sets an integer property
+func (b *EventBuilder) WithInt(key string, value int64) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithFloat sets a floating-point property
+func (b *EventBuilder) WithFloat(key string, value float64) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithBool sets a boolean property
+func (b *EventBuilder) WithBool(key string, value bool) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithTime sets a time property
+func (b *EventBuilder) WithTime(key string, value time.Time) *EventBuilder {
+	return b.set(key, value)
+}
+
+// WithRevenueType sets the kind of revenue; only revenue events use it
+func (b *EventBuilder) WithRevenueType(revenueType types.RevenueType) *EventBuilder {
+	b.revenueType = revenueType
+	return b
+}
+
+// WithProducts adds products to the order; only revenue events use them
+func (b *EventBuilder) WithProducts(products ...types.Product) *EventBuilder {
+	b.products = append(b.products, products...)
+	return b
+}
+
+// set stores a property in the builder's own map
+func (b *EventBuilder) set(key string, value interface{}) *EventBuilder {
+	if b.properties == nil {
+		b.properties = make(types.Properties)
+	}
+	b.properties[key] = value
+	return b
+}
+
+// Send validates the event and queues it through the same path as the
+// corresponding client method
+func (b *EventBuilder) Send(ctx context.Context) error {
+	switch b.kind {
+	case kindIdentify:
+		return b.client.Identify(ctx, types.Identity{
+			ID:         b.id,
+			UserId:     b.userID,
+			DeviceID:   b.deviceID,
+			SessionID:  b.sessionID,
+			Properties: b.properties,
+			Timestamp:  b.timestamp,
+		})
+
+	case kindGroup:
+		return b.client.Group(ctx, types.GroupInfo{
+			ID:         b.id,
+			UserId:     b.userID,
+			GroupId:    b.groupID,
+			DeviceID:   b.deviceID,
+			SessionID:  b.sessionID,
+			Properties: b.properties,
+			Timestamp:  b.timestamp,
+		})
+
+	case kindRevenue:
+		return b.client.Revenue(ctx, types.Revenue{
+			ID:         b.id,
+			UserID:     b.userID,
+			OrderID:    b.orderID,
+			DeviceID:   b.deviceID,
+			SessionID:  b.sessionID,
+			Amount:     b.amount,
+			Currency:   b.currency,
+			Type:       b.revenueType,
+			Products:   b.products,
+			Properties: b.properties,
+			Timestamp:  b.timestamp,
+		})
+
+	case kindAlias:
+		return b.client.Alias(ctx, types.Alias{
+			ID:         b.id,
+			PreviousId: b.previousID,
+			UserId:     b.userID,
+			DeviceID:   b.deviceID,
+			SessionID:  b.sessionID,
+			Properties: b.properties,
+			Timestamp:  b.timestamp,
+		})
+
+	default:
+		return b.client.Track(ctx, types.Event{
+			ID:         b.id,
+			UserId:     b.userID,
+			DeviceID:   b.deviceID,
+			SessionID:  b.sessionID,
+			Name:       b.name,
+			Properties: b.properties,
+			Timestamp:  b.timestamp,
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// sdk-go/internal/api/builder_test.go
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	event_schema "github.com/usercanal/sdk-go/internal/schema/event"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestEventBuilder(t *testing.T) {
+	at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		build       func(*Client) *EventBuilder
+		wantType    event_schema.EventType
+		wantName    string
+		wantUser    string
+		wantPayload map[string]interface{}
+	}{
+		{
+			name: "track with typed properties",
+			build: func(c *Client) *EventBuilder {
+				return c.NewTrack(types.FeatureUsed).
+					WithUserID("user_1").
+					WithString("feature", "export").
+					WithInt("rows", 20).
+					WithFloat("ratio", 0.5).
+					WithBool("beta", true).
+					WithTime("at", at).
+					WithProperty("tags", []interface{}{"a"})
+			},
+			wantType: event_schema.EventTypeTRACK,
+			wantName: "Feature Used",
+			wantUser: "user_1",
+			wantPayload: map[string]interface{}{
+				"feature": "export",
+				"rows":    float64(20),
+				"ratio":   0.5,
+				"beta":    true,
+				"at":      "2024-05-06T07:08:09Z",
+				"tags":    []interface{}{"a"},
+			},
+		},
+		{
+			name: "later properties replace earlier ones",
+			build: func(c *Client) *EventBuilder {
+				return c.NewTrack(types.FeatureUsed).
+					WithUserID("user_1").
+					WithProperties(types.Properties{"a": 1, "b": 1}).
+					WithInt("b", 2)
+			},
+			wantType:    event_schema.EventTypeTRACK,
+			wantName:    "Feature Used",
+			wantUser:    "user_1",
+			wantPayload: map[string]interface{}{"a": float64(1), "b": float64(2)},
+		},
+		{
+			name: "event ID",
+			build: func(c *Client) *EventBuilder {
+				return c.NewTrack(types.FeatureUsed).WithUserID("user_1").WithEventID("evt_1")
+			},
+			wantType:    event_schema.EventTypeTRACK,
+			wantName:    "Feature Used",
+			wantUser:    "user_1",
+			wantPayload: map[string]interface{}{types.EventIDKey: "evt_1"},
+		},
+		{
+			name: "identify",
+			build: func(c *Client) *EventBuilder {
+				return c.NewIdentify("user_1").WithString("plan", "pro")
+			},
+			wantType:    event_schema.EventTypeIDENTIFY,
+			wantName:    "identify",
+			wantUser:    "user_1",
+			wantPayload: map[string]interface{}{"traits": map[string]interface{}{"plan": "pro"}},
+		},
+		{
+			name: "group",
+			build: func(c *Client) *EventBuilder {
+				return c.NewGroup("org_1").WithUserID("user_1").WithInt("seats", 5)
+			},
+			wantType: event_schema.EventTypeGROUP,
+			wantName: "group",
+			wantUser: "user_1",
+			wantPayload: map[string]interface{}{
+				"group_id":   "org_1",
+				"properties": map[string]interface{}{"seats": float64(5)},
+			},
+		},
+		{
+			name: "revenue",
+			build: func(c *Client) *EventBuilder {
+				return c.NewRevenue("order_1", 9.99, types.CurrencyUSD).
+					WithUserID("user_1").
+					WithRevenueType(types.RevenueTypeSubscription).
+					WithProducts(types.Product{ID: "p1", Name: "Pro", Price: 9.99, Quantity: 1}).
+					WithString("coupon", "SPRING")
+			},
+			wantType: event_schema.EventTypeTRACK,
+			wantName: "Order Completed",
+			wantUser: "user_1",
+			wantPayload: map[string]interface{}{
+				"order_id": "order_1",
+				"revenue":  9.99,
+				"currency": "USD",
+				"type":     "subscription",
+				"products": []interface{}{map[string]interface{}{"id": "p1", "name": "Pro", "price": 9.99, "quantity": float64(1)}},
+				"coupon":   "SPRING",
+			},
+		},
+		{
+			name: "revenue defaults to a one-time payment",
+			build: func(c *Client) *EventBuilder {
+				return c.NewRevenue("order_1", 5, types.CurrencyEUR).WithUserID("user_1")
+			},
+			wantType: event_schema.EventTypeTRACK,
+			wantName: "Order Completed",
+			wantUser: "user_1",
+			wantPayload: map[string]interface{}{
+				"order_id": "order_1",
+				"revenue":  float64(5),
+				"currency": "EUR",
+				"type":     "one_time",
+			},
+		},
+		{
+			name: "alias",
+			build: func(c *Client) *EventBuilder {
+				return c.NewAlias("anon_1", "user_1")
+			},
+			wantType: event_schema.EventTypeALIAS,
+			wantName: "alias",
+			wantUser: "user_1",
+			wantPayload: map[string]interface{}{
+				"previous_id": "anon_1",
+				"user_id":     "user_1",
+				"properties":  nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			if err := tt.build(c).WithTimestamp(at).Send(context.Background()); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			queued := queuedEvents(c)
+			if len(queued) != 1 {
+				t.Fatalf("got %d queued events, want 1", len(queued))
+			}
+			got := queued[0]
+			if got.EventType != tt.wantType || got.EventName != tt.wantName || got.UserID != tt.wantUser {
+				t.Errorf("got %v %q for %q, want %v %q for %q", got.EventType, got.EventName, got.UserID, tt.wantType, tt.wantName, tt.wantUser)
+			}
+			if got.Timestamp != uint64(at.UnixMilli()) {
+				t.Errorf("Timestamp = %d, want %d", got.Timestamp, at.UnixMilli())
+			}
+			if p := payload(t, got.Payload); !reflect.DeepEqual(p, tt.wantPayload) {
+				t.Errorf("payload = %v, want %v", p, tt.wantPayload)
+			}
+		})
+	}
+}
+
+func TestEventBuilderIDs(t *testing.T) {
+	device := bytes.Repeat([]byte{1}, 16)
+	session := bytes.Repeat([]byte{2}, 16)
+
+	tests := []struct {
+		name        string
+		build       func(*EventBuilder) *EventBuilder
+		wantSession []byte
+	}{
+		{name: "session", build: func(b *EventBuilder) *EventBuilder { return b.WithSessionID(session) }, wantSession: session},
+		{name: "without session", build: func(b *EventBuilder) *EventBuilder { return b.WithSessionID(session).WithoutSession() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			b := c.NewTrack(types.FeatureUsed).WithUserID("user_1").WithDeviceID(device)
+			if err := tt.build(b).Send(context.Background()); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			got := queuedEvents(c)[0]
+			if !bytes.Equal(got.DeviceID, device) {
+				t.Errorf("DeviceID = %x, want %x", got.DeviceID, device)
+			}
+			if !bytes.Equal(got.SessionID, tt.wantSession) {
+				t.Errorf("SessionID = %x, want %x", got.SessionID, tt.wantSession)
+			}
+		})
+	}
+}
+
+// The builder keeps its own copy of properties passed to it
+func TestEventBuilderCopiesProperties(t *testing.T) {
+	c := newTestClient(t)
+	props := types.Properties{"a": 1}
+	b := c.NewTrack(types.FeatureUsed).WithUserID("user_1").WithProperties(props)
+	props["a"] = 2
+	props["b"] = 3
+
+	if err := b.Send(context.Background()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got := payload(t, queuedEvents(c)[0].Payload); !reflect.DeepEqual(got, map[string]interface{}{"a": float64(1)}) {
+		t.Errorf("payload = %v, want only a=1", got)
+	}
+}
+
+func TestEventBuilderValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*Client) *EventBuilder
+	}{
+		{name: "track without user", build: func(c *Client) *EventBuilder { return c.NewTrack(types.FeatureUsed) }},
+		{name: "group without user", build: func(c *Client) *EventBuilder { return c.NewGroup("org_1") }},
+		{name: "revenue without amount", build: func(c *Client) *EventBuilder {
+			return c.NewRevenue("order_1", 0, types.CurrencyUSD).WithUserID("user_1")
+		}},
+		{name: "short device ID", build: func(c *Client) *EventBuilder {
+			return c.NewTrack(types.FeatureUsed).WithUserID("user_1").WithDeviceID([]byte{1})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			err := tt.build(c).Send(context.Background())
+			if !errors.Is(err, types.ErrInvalidInput) {
+				t.Errorf("Send = %v, want ErrInvalidInput", err)
+			}
+			if n := len(queuedEvents(c)); n != 0 {
+				t.Errorf("%d events queued", n)
+			}
+		})
+	}
+}
@@ -4,36 +4,82 @@ package api
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/usercanal/sdk-go/internal/batch"
 	configDefaults "github.com/usercanal/sdk-go/internal/config"
+	"github.com/usercanal/sdk-go/internal/convert"
+	"github.com/usercanal/sdk-go/internal/credentials"
+	"github.com/usercanal/sdk-go/internal/encrypt"
+	"github.com/usercanal/sdk-go/internal/errtrack"
 	"github.com/usercanal/sdk-go/internal/identity"
+	"github.com/usercanal/sdk-go/internal/inventory"
+	"github.com/usercanal/sdk-go/internal/logbridge"
+	"github.com/usercanal/sdk-go/internal/logdedupe"
+	"github.com/usercanal/sdk-go/internal/logfilter"
 	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/internal/metrics"
+	"github.com/usercanal/sdk-go/internal/redact"
+	"github.com/usercanal/sdk-go/internal/runtimestats"
+	"github.com/usercanal/sdk-go/internal/superprops"
+	"github.com/usercanal/sdk-go/internal/suppress"
+	"github.com/usercanal/sdk-go/internal/trace"
 	"github.com/usercanal/sdk-go/internal/transport"
 	"github.com/usercanal/sdk-go/types"
 )
 
 // Use centralized defaults from config package
 const (
-	defaultEndpoint      = configDefaults.DefaultEndpoint
-	defaultBatchSize     = configDefaults.DefaultBatchSize
-	defaultFlushInterval = configDefaults.DefaultFlushInterval
-	defaultMaxRetries    = configDefaults.DefaultMaxRetries
-	defaultCloseTimeout  = configDefaults.DefaultCloseTimeout
+	defaultEndpoint        = configDefaults.DefaultEndpoint
+	defaultBatchSize       = configDefaults.DefaultBatchSize
+	defaultFlushInterval   = configDefaults.DefaultFlushInterval
+	defaultMaxRetries      = configDefaults.DefaultMaxRetries
+	defaultCloseTimeout    = configDefaults.DefaultCloseTimeout
+	defaultMetricsInterval = configDefaults.DefaultMetricsInterval
+	defaultPriorityWindow  = configDefaults.DefaultPriorityWindow
 )
 
 // Client represents an analytics client
 type Client struct {
-	cfg          *config
-	sender       *transport.Sender
-	eventBatcher *batch.Manager
-	logBatcher   *batch.Manager
-	identityMgr  *identity.Manager
-	mu           sync.RWMutex
-	closed       bool
-	closing      bool
+	cfg              *config
+	sender           *transport.Sender
+	eventBatcher     *batch.Manager[*transport.Event]
+	logBatcher       *batch.Manager[*transport.Log]
+	metricBatcher    *batch.Manager[*transport.Metric]
+	metrics          *metrics.Registry
+	inventoryBatcher *batch.Manager[*transport.Inventory]
+	inventory        *inventory.Reporter
+	instanceID       []byte
+	runtimeStats     *runtimestats.Collector
+	spanBatcher      *batch.Manager[*transport.Span]
+	tracer           *trace.Tracer
+	errLimiter       *errtrack.Limiter
+	logFilter        *logfilter.Filter
+	logDedupe        *logdedupe.Deduper
+	priority         *priorityLane
+	identityMgr      *identity.Manager
+	converter        *convert.Converter
+	redactor         *redact.Redactor
+	superProps       *superprops.Registry
+	logWriters       []*logbridge.Writer
+	writersMu        sync.Mutex
+	mu               sync.RWMutex
+	closed           bool
+	closing          bool
+
+	// Consent enforcement counters (atomic)
+	suppressedCount int64
+	anonymizedCount int64
+
+	// Error captures dropped by per-fingerprint rate limiting (atomic)
+	errorsRateLimited int64
+
+	// Users suppressed by deletion and suppression requests, in memory only
+	suppressed *suppress.List
 }
 
 // Config represents the external client configuration
@@ -47,20 +93,38 @@ type Config struct {
 
 // internal config struct
 type config struct {
-	endpoint      string
-	batchSize     int
-	flushInterval time.Duration
-	maxRetries    int
-	debug         bool
+	endpoint        string
+	batchSize       int
+	flushInterval   time.Duration
+	maxRetries      int
+	debug           bool
+	serviceName     string
+	metricsInterval time.Duration
+	credentials     types.CredentialProvider
+	encryption      *types.EncryptionConfig
+	redaction       *types.RedactionConfig
+	consent         *types.ConsentConfig
+	inventory       *types.InventoryConfig
+	runtimeMetrics  *types.RuntimeMetricsConfig
+	errorCapture    *types.ErrorCaptureConfig
+	logFilter       types.LogFilterConfig
+	logDedupe       *types.LogDedupeConfig
+	priority        types.PriorityConfig
+	eventBatch      types.BatchConfig
+	logBatch        types.BatchConfig
+	superProps      types.Properties
 }
 
 func defaultConfig() *config {
 	return &config{
-		endpoint:      defaultEndpoint,
-		batchSize:     defaultBatchSize,
-		flushInterval: defaultFlushInterval,
-		maxRetries:    defaultMaxRetries,
-		debug:         configDefaults.DefaultDebug,
+		endpoint:        defaultEndpoint,
+		batchSize:       defaultBatchSize,
+		flushInterval:   defaultFlushInterval,
+		maxRetries:      defaultMaxRetries,
+		debug:           configDefaults.DefaultDebug,
+		metricsInterval: defaultMetricsInterval,
+		priority:        types.PriorityConfig{Window: defaultPriorityWindow},
+		serviceName:     filepath.Base(os.Args[0]),
 	}
 }
 
@@ -127,49 +191,240 @@ func WithDebug(debug bool) Option {
 	}
 }
 
-// New creates a new client with the provided API key and options
-func New(apiKey string, opts ...Option) (*Client, error) {
-	if apiKey == "" {
-		return nil, types.NewValidationError("apiKey", "is required")
+// WithServiceName sets the service reported with spans; defaults to the executable name
+func WithServiceName(name string) Option {
+	return func(c *config) {
+		if name != "" {
+			c.serviceName = name
+		}
+	}
+}
+
+// WithMetricsInterval sets the window over which metric instruments are aggregated
+func WithMetricsInterval(interval time.Duration) Option {
+	return func(c *config) {
+		if interval > 0 {
+			c.metricsInterval = interval
+		}
+	}
+}
+
+// WithCredentials sets a provider that supplies the API key for every batch,
+// allowing the key to be rotated without recreating the client
+func WithCredentials(provider types.CredentialProvider) Option {
+	return func(c *config) {
+		if provider != nil {
+			c.credentials = provider
+		}
+	}
+}
+
+// WithEncryption enables field-level encryption of sensitive properties
+func WithEncryption(cfg *types.EncryptionConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.encryption = cfg
+		}
+	}
+}
+
+// WithRedaction enables PII redaction of properties and log payloads before conversion
+func WithRedaction(cfg *types.RedactionConfig) Option {
+	return func(c *config) {
+		if cfg != nil && len(cfg.Rules) > 0 {
+			c.redaction = cfg
+		}
+	}
+}
+
+// WithConsent enables consent enforcement on event tracking
+func WithConsent(cfg *types.ConsentConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			consent := *cfg
+			if consent.Mode == 0 {
+				consent.Mode = types.ConsentDrop
+			}
+			if consent.DefaultCategory == "" {
+				consent.DefaultCategory = types.ConsentAnalytics
+			}
+			c.consent = &consent
+		}
+	}
+}
+
+// WithInventory enables automatic inventory reporting at startup and on an interval
+func WithInventory(cfg *types.InventoryConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.inventory = cfg
+		}
+	}
+}
+
+// WithRuntimeMetrics enables periodic sampling of Go runtime metrics
+func WithRuntimeMetrics(cfg *types.RuntimeMetricsConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			rm := *cfg
+			if len(rm.Metrics) == 0 {
+				rm.Metrics = types.DefaultRuntimeMetrics
+			}
+			if rm.Output == 0 {
+				rm.Output = types.RuntimeMetricsAsMetrics
+			}
+			if rm.Service == "" {
+				rm.Service = defaultRuntimeService
+			}
+			if rm.Source == "" {
+				rm.Source = hostname
+			}
+			c.runtimeMetrics = &rm
+		}
+	}
+}
+
+// WithErrorCapture tunes error and panic capture; sensible defaults apply without it
+func WithErrorCapture(cfg *types.ErrorCaptureConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			ec := *cfg
+			c.errorCapture = &ec
+		}
+	}
+}
+
+// WithLogFilter sets log levels, sampling and rate limits applied before logs are queued
+func WithLogFilter(cfg *types.LogFilterConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.logFilter = *cfg
+		}
+	}
+}
+
+// WithLogDedupe collapses identical log entries repeated within a window
+func WithLogDedupe(cfg *types.LogDedupeConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			ld := *cfg
+			c.logDedupe = &ld
+		}
+	}
+}
+
+// WithDefaultProperties registers properties added to every event and log entry
+func WithDefaultProperties(props types.Properties) Option {
+	return func(c *config) {
+		if len(props) > 0 {
+			c.superProps = props
+		}
+	}
+}
+
+// WithPriority configures the priority lane for critical logs and chosen events
+func WithPriority(cfg *types.PriorityConfig) Option {
+	return func(c *config) {
+		if cfg != nil {
+			c.priority = *cfg
+			if c.priority.Window <= 0 {
+				c.priority.Window = defaultPriorityWindow
+			}
+		}
 	}
+}
 
+// New creates a new client with the provided API key and options
+// The API key may be empty when a credential provider is supplied via WithCredentials.
+func New(apiKey string, opts ...Option) (*Client, error) {
 	cfg := defaultConfig()
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	sender, err := transport.NewSender(apiKey, cfg.endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create sender: %w", err)
+	if cfg.credentials == nil {
+		if err := types.ValidateAPIKey(apiKey); err != nil {
+			return nil, err
+		}
+		cfg.credentials = credentials.NewStatic(apiKey)
 	}
 
-	// Create wrapper functions to match batch.SendFunc signature
-	eventSendFunc := func(ctx context.Context, items []interface{}) error {
-		events := make([]*transport.Event, len(items))
-		for i, item := range items {
-			if event, ok := item.(*transport.Event); ok {
-				events[i] = event
-			} else {
-				return fmt.Errorf("invalid event type: %T", item)
-			}
+	var converterOpts []convert.Option
+	if cfg.encryption != nil {
+		encryptor, err := encrypt.New(*cfg.encryption)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption config: %w", err)
 		}
-		return sender.SendEvents(ctx, events)
+		converterOpts = append(converterOpts, convert.WithEncryptor(encryptor))
 	}
 
-	logSendFunc := func(ctx context.Context, items []interface{}) error {
-		logs := make([]*transport.Log, len(items))
-		for i, item := range items {
-			if log, ok := item.(*transport.Log); ok {
-				logs[i] = log
-			} else {
-				return fmt.Errorf("invalid log type: %T", item)
-			}
+	if cfg.consent != nil && cfg.consent.Store == nil {
+		return nil, types.NewValidationError("Consent.Store", "is required")
+	}
+
+	if cfg.inventory != nil && cfg.inventory.Service == "" {
+		return nil, types.NewValidationError("Inventory.Service", "is required")
+	}
+
+	if cfg.runtimeMetrics != nil {
+		if cfg.runtimeMetrics.Output > types.RuntimeMetricsAsLogs {
+			return nil, types.NewValidationError("RuntimeMetrics.Output", "is invalid")
+		}
+		if err := runtimestats.Validate(cfg.runtimeMetrics.Metrics); err != nil {
+			return nil, fmt.Errorf("invalid runtime metrics config: %w", err)
+		}
+	}
+
+	if err := validateBatchConfig("EventBatching", cfg.eventBatch); err != nil {
+		return nil, err
+	}
+	if err := validateBatchConfig("LogBatching", cfg.logBatch); err != nil {
+		return nil, err
+	}
+
+	if err := logfilter.Validate(cfg.logFilter); err != nil {
+		return nil, fmt.Errorf("invalid log filter config: %w", err)
+	}
+
+	// Error capture is always available; fill in whatever was not configured
+	if cfg.errorCapture == nil {
+		cfg.errorCapture = &types.ErrorCaptureConfig{}
+	}
+	if cfg.errorCapture.Service == "" {
+		cfg.errorCapture.Service = cfg.serviceName
+	}
+	if cfg.errorCapture.Source == "" {
+		cfg.errorCapture.Source = hostname
+	}
+	if cfg.errorCapture.MaxFrames <= 0 {
+		cfg.errorCapture.MaxFrames = errtrack.DefaultMaxFrames
+	}
+
+	superProps, err := normalizeSuperProperties(cfg.superProps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default properties: %w", err)
+	}
+
+	var redactor *redact.Redactor
+	if cfg.redaction != nil {
+		var err error
+		redactor, err = redact.New(*cfg.redaction)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction config: %w", err)
 		}
-		return sender.SendLogs(ctx, logs)
 	}
 
-	eventBatchMgr := batch.NewManager(cfg.batchSize, cfg.flushInterval, eventSendFunc)
-	logBatchMgr := batch.NewManager(cfg.batchSize, cfg.flushInterval, logSendFunc)
+	sender, err := transport.NewSender(cfg.credentials, cfg.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sender: %w", err)
+	}
+
+	eventBatchMgr := newStreamBatcher(cfg, cfg.eventBatch, eventSize, sender.SendEvents)
+	logBatchMgr := newStreamBatcher(cfg, cfg.logBatch, logSize, sender.SendLogs)
+	metricBatchMgr := batch.NewManager(cfg.batchSize, cfg.flushInterval, sender.SendMetrics)
+	inventoryBatchMgr := batch.NewManager(cfg.batchSize, cfg.flushInterval, sender.SendInventory)
+	spanBatchMgr := batch.NewManager(cfg.batchSize, cfg.flushInterval, sender.SendSpans)
+	priority := newPriorityLane(&cfg.priority, sender.SendLogs, sender.SendEvents)
 
 	// Create identity manager for session and device ID management
 	identityMgr, err := identity.NewManager()
@@ -178,22 +433,58 @@ func New(apiKey string, opts ...Option) (*Client, error) {
 	}
 
 	client := &Client{
-		cfg:          cfg,
-		sender:       sender,
-		eventBatcher: eventBatchMgr,
-		logBatcher:   logBatchMgr,
-		identityMgr:  identityMgr,
+		cfg:              cfg,
+		sender:           sender,
+		eventBatcher:     eventBatchMgr,
+		logBatcher:       logBatchMgr,
+		metricBatcher:    metricBatchMgr,
+		inventoryBatcher: inventoryBatchMgr,
+		spanBatcher:      spanBatchMgr,
+		priority:         priority,
+		identityMgr:      identityMgr,
+		converter:        convert.NewConverter(converterOpts...),
+		redactor:         redactor,
+		superProps:       superprops.New(superProps),
+		errLimiter:       errtrack.NewLimiter(cfg.errorCapture.RateLimit, cfg.errorCapture.RateWindow),
+
+		suppressed: suppress.New(suppress.DefaultMaxUsers, suppress.DefaultTTL),
+	}
+	// The filter always exists so levels can be set at runtime
+	client.logFilter = logfilter.New(cfg.logFilter, client.reportFilteredLogs)
+	if cfg.logDedupe != nil {
+		client.logDedupe = logdedupe.New(cfg.logDedupe.Window, cfg.logDedupe.MaxKeys, client.emitFoldedLog)
+	}
+	instanceID := uuid.New()
+	client.instanceID = instanceID[:]
+	client.tracer = trace.NewTracer(cfg.serviceName, client.exportSpan)
+	client.metrics = metrics.NewRegistry(cfg.metricsInterval, client.emitMetrics)
+	if cfg.inventory != nil {
+		client.inventory = inventory.NewReporter(cfg.inventory.Interval, client.reportServiceInventory)
+	}
+	if cfg.runtimeMetrics != nil {
+		client.runtimeStats = runtimestats.NewCollector(cfg.runtimeMetrics.Interval, cfg.runtimeMetrics.Metrics, client.recordRuntimeMetrics)
 	}
 
 	return client, nil
 }
 
-// Flush forces a flush of both event and log batchers
+// Flush forces a flush of the event, log, span, metric and inventory batchers
 func (c *Client) Flush(ctx context.Context) error {
 	if err := c.checkClosed(); err != nil {
 		return err
 	}
 
+	// Release logs held by writers and deduplication into the batchers
+	c.flushLogWriters()
+	if c.logDedupe != nil {
+		c.logDedupe.Flush()
+	}
+
+	// Priority lane first so critical data is not held behind bulk batches
+	if err := c.flushPriority(ctx); err != nil {
+		return fmt.Errorf("failed to flush priority lane: %w", err)
+	}
+
 	// Flush both event and log batchers
 	if err := c.eventBatcher.Flush(ctx); err != nil {
 		return fmt.Errorf("failed to flush events: %w", err)
@@ -203,6 +494,22 @@ func (c *Client) Flush(ctx context.Context) error {
 		return fmt.Errorf("failed to flush logs: %w", err)
 	}
 
+	if err := c.spanBatcher.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush spans: %w", err)
+	}
+
+	// Close the current aggregation window before flushing the metric batcher
+	if err := c.metrics.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to aggregate metrics: %w", err)
+	}
+	if err := c.metricBatcher.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush metrics: %w", err)
+	}
+
+	if err := c.inventoryBatcher.Flush(ctx); err != nil {
+		return fmt.Errorf("failed to flush inventory: %w", err)
+	}
+
 	return nil
 }
 
@@ -222,6 +529,9 @@ func (c *Client) checkClosed() error {
 
 // Close flushes pending data and closes the client
 func (c *Client) Close(ctx context.Context) error {
+	// Send lines held by log writers while logs are still accepted
+	c.flushLogWriters()
+
 	c.mu.Lock()
 	if c.closed {
 		c.mu.Unlock()
@@ -243,12 +553,35 @@ func (c *Client) Close(ctx context.Context) error {
 		defer cancel()
 	}
 
+	// Stop background producers first so nothing is queued after the final flush
+	if c.inventory != nil {
+		c.inventory.Close()
+	}
+	if c.runtimeStats != nil {
+		c.runtimeStats.Close()
+	}
+	c.logFilter.Close() // Queues the final summary of dropped logs
+	if c.logDedupe != nil {
+		c.logDedupe.Close()
+	}
+
+	// Stop metric aggregation first so its final window is included in the flush
 	var flushErr error
-	if err := c.Flush(ctx); err != nil {
+	if err := c.metrics.Close(ctx); err != nil {
+		flushErr = fmt.Errorf("failed to close metrics: %w", err)
+	}
+
+	if err := c.Flush(ctx); err != nil && flushErr == nil {
 		flushErr = fmt.Errorf("failed to flush data during shutdown: %w", err)
 	}
 
-	// Close batchers
+	// Close batchers, priority lane first
+	if err := c.closePriority(); err != nil {
+		if flushErr == nil {
+			flushErr = fmt.Errorf("failed to close priority lane: %w", err)
+		}
+	}
+
 	if err := c.eventBatcher.Close(); err != nil {
 		if flushErr == nil {
 			flushErr = fmt.Errorf("failed to close event batcher: %w", err)
@@ -261,6 +594,24 @@ func (c *Client) Close(ctx context.Context) error {
 		}
 	}
 
+	if err := c.spanBatcher.Close(); err != nil {
+		if flushErr == nil {
+			flushErr = fmt.Errorf("failed to close span batcher: %w", err)
+		}
+	}
+
+	if err := c.metricBatcher.Close(); err != nil {
+		if flushErr == nil {
+			flushErr = fmt.Errorf("failed to close metric batcher: %w", err)
+		}
+	}
+
+	if err := c.inventoryBatcher.Close(); err != nil {
+		if flushErr == nil {
+			flushErr = fmt.Errorf("failed to close inventory batcher: %w", err)
+		}
+	}
+
 	// Close the sender
 	if err := c.sender.Close(); err != nil {
 		if flushErr == nil {
@@ -0,0 +1,85 @@
+// sdk-go/internal/api/client_test.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/transport"
+)
+
+const testAPIKey = "000102030405060708090a0b0c0d0e0f"
+
+// newTestClient returns a client connected to a server that discards everything.
+// Batches are only sent on an explicit flush, so tests can inspect the queues.
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(io.Discard, conn)
+		}
+	}()
+
+	opts = append([]Option{
+		WithEndpoint(ln.Addr().String()),
+		WithFlushInterval(time.Hour),
+		WithBatchSize(1000),
+	}, opts...)
+	c, err := New(testAPIKey, opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { c.Close(context.Background()) })
+	return c
+}
+
+// queuedEvents removes and returns the events waiting in the event queues
+func queuedEvents(c *Client) []*transport.Event {
+	var events []*transport.Event
+	collect := func(e *transport.Event) bool {
+		events = append(events, e)
+		return true
+	}
+	if c.priority != nil {
+		c.priority.eventBatcher.Remove(collect)
+	}
+	c.eventBatcher.Remove(collect)
+	return events
+}
+
+// queuedLogs removes and returns the logs waiting in the log queues
+func queuedLogs(c *Client) []*transport.Log {
+	var logs []*transport.Log
+	collect := func(l *transport.Log) bool {
+		logs = append(logs, l)
+		return true
+	}
+	if c.priority != nil {
+		c.priority.logBatcher.Remove(collect)
+	}
+	c.logBatcher.Remove(collect)
+	return logs
+}
+
+// payload decodes an encoded event or log payload
+func payload(t *testing.T, raw []byte) map[string]interface{} {
+	t.Helper()
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("decode payload %s: %v", raw, err)
+	}
+	return out
+}
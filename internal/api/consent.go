@@ -0,0 +1,103 @@
+// sdk-go/internal/api/consent.go
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// consentDecision is the outcome of a consent check for a single event
+type consentDecision uint8
+
+const (
+	consentAllow consentDecision = iota
+	consentAnonymize
+	consentSuppress
+)
+
+// checkConsent decides whether an event for userID may be sent as-is.
+// Events that identify a person (identify, group) cannot be anonymized and are suppressed instead.
+func (c *Client) checkConsent(ctx context.Context, userID string, name types.EventName, anonymizable bool) consentDecision {
+	if c.isSuppressed(userID) {
+		atomic.AddInt64(&c.suppressedCount, 1)
+		return consentSuppress
+	}
+
+	cc := c.cfg.consent
+	if cc == nil {
+		return consentAllow
+	}
+
+	category := cc.DefaultCategory
+	if override, ok := cc.EventCategories[name]; ok {
+		category = override
+	}
+
+	granted, err := cc.Store.HasConsent(ctx, userID, category)
+	if err != nil {
+		logger.Warn("Consent lookup failed for category %s: %v", category, err)
+		granted = cc.FailOpen
+	}
+	if granted {
+		return consentAllow
+	}
+
+	if cc.Mode == types.ConsentAnonymize && anonymizable {
+		atomic.AddInt64(&c.anonymizedCount, 1)
+		return consentAnonymize
+	}
+
+	atomic.AddInt64(&c.suppressedCount, 1)
+	logger.Debug("Event %q suppressed: no %s consent", name, category)
+	return consentSuppress
+}
+
+// SetConsent records a consent change in the configured store and tracks it as an event
+func (c *Client) SetConsent(ctx context.Context, userID string, category types.ConsentCategory, granted bool) error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
+
+	if userID == "" {
+		return types.NewValidationError("userID", "is required")
+	}
+	if category == "" {
+		return types.NewValidationError("category", "is required")
+	}
+
+	if c.cfg.consent == nil {
+		return types.NewValidationError("consent", "is not configured")
+	}
+	writer, ok := c.cfg.consent.Store.(types.ConsentWriter)
+	if !ok {
+		return types.NewValidationError("consent", "store does not support updates")
+	}
+	if err := writer.SetConsent(ctx, userID, category, granted); err != nil {
+		return fmt.Errorf("failed to update consent: %w", err)
+	}
+
+	// The consent record itself is always sent, whatever the new state is
+	event := types.Event{
+		UserId: userID,
+		Name:   types.ConsentUpdated,
+		Properties: types.Properties{
+			"category": string(category),
+			"granted":  granted,
+		},
+	}
+	return c.track(ctx, event)
+}
+
+// suppressedEvents returns how many events were dropped for lack of consent
+func (c *Client) suppressedEvents() int64 {
+	return atomic.LoadInt64(&c.suppressedCount)
+}
+
+// anonymizedEvents returns how many events were anonymized for lack of consent
+func (c *Client) anonymizedEvents() int64 {
+	return atomic.LoadInt64(&c.anonymizedCount)
+}
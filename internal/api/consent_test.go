@@ -0,0 +1,170 @@
+// sdk-go/internal/api/consent_test.go
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/consent"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestCheckConsent(t *testing.T) {
+	granted := func(categories ...types.ConsentCategory) types.ConsentStore {
+		store := consent.NewMemory(false)
+		for _, category := range categories {
+			store.SetConsent(context.Background(), "user_1", category, true)
+		}
+		return store
+	}
+	failing := consent.Func(func(context.Context, string, types.ConsentCategory) (bool, error) {
+		return false, errors.New("consent service unavailable")
+	})
+
+	tests := []struct {
+		name         string
+		cfg          *types.ConsentConfig
+		event        types.EventName
+		anonymizable bool
+		want         consentDecision
+	}{
+		{name: "consent not configured", event: types.FeatureUsed, anonymizable: true, want: consentAllow},
+		{
+			name:  "granted default category",
+			cfg:   &types.ConsentConfig{Store: granted(types.ConsentAnalytics)},
+			event: types.FeatureUsed,
+			want:  consentAllow,
+		},
+		{
+			name:         "not granted is dropped",
+			cfg:          &types.ConsentConfig{Store: granted()},
+			event:        types.FeatureUsed,
+			anonymizable: true,
+			want:         consentSuppress,
+		},
+		{
+			name:         "not granted is anonymized",
+			cfg:          &types.ConsentConfig{Store: granted(), Mode: types.ConsentAnonymize},
+			event:        types.FeatureUsed,
+			anonymizable: true,
+			want:         consentAnonymize,
+		},
+		{
+			name:  "identifying events cannot be anonymized",
+			cfg:   &types.ConsentConfig{Store: granted(), Mode: types.ConsentAnonymize},
+			event: "identify",
+			want:  consentSuppress,
+		},
+		{
+			name: "per-event category override",
+			cfg: &types.ConsentConfig{
+				Store:           granted(types.ConsentAnalytics),
+				EventCategories: map[types.EventName]types.ConsentCategory{types.SubscriptionStarted: types.ConsentMarketing},
+			},
+			event:        types.SubscriptionStarted,
+			anonymizable: true,
+			want:         consentSuppress,
+		},
+		{
+			name:         "store error fails closed",
+			cfg:          &types.ConsentConfig{Store: failing},
+			event:        types.FeatureUsed,
+			anonymizable: true,
+			want:         consentSuppress,
+		},
+		{
+			name:         "store error fails open",
+			cfg:          &types.ConsentConfig{Store: failing, FailOpen: true},
+			event:        types.FeatureUsed,
+			anonymizable: true,
+			want:         consentAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.cfg != nil {
+				opts = append(opts, WithConsent(tt.cfg))
+			}
+			c := newTestClient(t, opts...)
+
+			if got := c.checkConsent(context.Background(), "user_1", tt.event, tt.anonymizable); got != tt.want {
+				t.Errorf("checkConsent = %v, want %v", got, tt.want)
+			}
+
+			var wantSuppressed, wantAnonymized int64
+			switch tt.want {
+			case consentSuppress:
+				wantSuppressed = 1
+			case consentAnonymize:
+				wantAnonymized = 1
+			}
+			if c.suppressedEvents() != wantSuppressed || c.anonymizedEvents() != wantAnonymized {
+				t.Errorf("counts = (%d suppressed, %d anonymized), want (%d, %d)",
+					c.suppressedEvents(), c.anonymizedEvents(), wantSuppressed, wantAnonymized)
+			}
+		})
+	}
+}
+
+func TestTrackWithoutConsent(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     types.ConsentMode
+		wantUser string // "" when the event is dropped
+	}{
+		{name: "drop", mode: types.ConsentDrop},
+		{name: "anonymize", mode: types.ConsentAnonymize, wantUser: types.AnonymousUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, WithConsent(&types.ConsentConfig{Store: consent.NewMemory(false), Mode: tt.mode}))
+			device := []byte("device-id-000000")
+			err := c.Track(context.Background(), types.Event{UserId: "user_1", Name: types.FeatureUsed, DeviceID: device})
+			if err != nil {
+				t.Fatalf("Track: %v", err)
+			}
+
+			queued := queuedEvents(c)
+			if tt.wantUser == "" {
+				if len(queued) != 0 {
+					t.Fatalf("queued %d events, want none", len(queued))
+				}
+				return
+			}
+			if len(queued) != 1 {
+				t.Fatalf("queued %d events, want 1", len(queued))
+			}
+			if queued[0].UserID != tt.wantUser {
+				t.Errorf("UserID = %q, want %q", queued[0].UserID, tt.wantUser)
+			}
+			if string(queued[0].DeviceID) == string(device) {
+				t.Error("anonymized event kept the caller's device ID")
+			}
+		})
+	}
+}
+
+func TestSetConsentIsAlwaysTracked(t *testing.T) {
+	store := consent.NewMemory(true)
+	c := newTestClient(t, WithConsent(&types.ConsentConfig{Store: store}))
+	ctx := context.Background()
+
+	if err := c.SetConsent(ctx, "user_1", types.ConsentAnalytics, false); err != nil {
+		t.Fatalf("SetConsent: %v", err)
+	}
+	if granted, _ := store.HasConsent(ctx, "user_1", types.ConsentAnalytics); granted {
+		t.Error("store still reports consent after opt-out")
+	}
+
+	queued := queuedEvents(c)
+	if len(queued) != 1 || queued[0].EventName != string(types.ConsentUpdated) {
+		t.Fatalf("queued %d events, want one %s event", len(queued), types.ConsentUpdated)
+	}
+	if got := payload(t, queued[0].Payload); got["category"] != "analytics" || got["granted"] != false {
+		t.Errorf("payload = %v, want category analytics, granted false", got)
+	}
+}
@@ -0,0 +1,81 @@
+// sdk-go/internal/api/errors.go
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/errtrack"
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// repanicFlushTimeout bounds the flush before a recovered panic is re-raised
+const repanicFlushTimeout = 2 * time.Second
+
+// CaptureError records err with its unwrapped chain, the calling stack and a grouping
+// fingerprint, and sends it as an error log entry. props are added to the entry's Data.
+func (c *Client) CaptureError(ctx context.Context, err error, props types.Properties) error {
+	if err == nil {
+		return nil
+	}
+	report := errtrack.Capture(err, 1, c.cfg.errorCapture.MaxFrames)
+	return c.sendCapture(ctx, report, types.LogError, props)
+}
+
+// HandlePanic captures a value returned by recover as a critical log entry.
+// With Repanic configured, pending logs are flushed and the panic is re-raised.
+func (c *Client) HandlePanic(ctx context.Context, recovered interface{}, props types.Properties) {
+	if recovered == nil {
+		return
+	}
+
+	report := errtrack.CapturePanic(recovered, 1, c.cfg.errorCapture.MaxFrames)
+	if err := c.sendCapture(ctx, report, types.LogCritical, props); err != nil {
+		logger.Warn("Failed to capture panic: %v", err)
+	}
+
+	if c.cfg.errorCapture.Repanic {
+		flushCtx, cancel := context.WithTimeout(context.Background(), repanicFlushTimeout)
+		if err := c.flushPriority(flushCtx); err != nil {
+			logger.Warn("Failed to flush priority lane before re-panicking: %v", err)
+		}
+		if err := c.logBatcher.Flush(flushCtx); err != nil {
+			logger.Warn("Failed to flush logs before re-panicking: %v", err)
+		}
+		cancel()
+		panic(recovered)
+	}
+}
+
+// sendCapture applies per-fingerprint rate limiting and queues the capture on the log batcher
+func (c *Client) sendCapture(ctx context.Context, report errtrack.Report, level types.LogLevel, props types.Properties) error {
+	allowed, suppressed := c.errLimiter.Allow(report.Fingerprint)
+	if !allowed {
+		atomic.AddInt64(&c.errorsRateLimited, 1)
+		return nil
+	}
+	report.Suppressed = suppressed
+
+	data := make(map[string]interface{}, len(props)+1)
+	for k, v := range props {
+		data[k] = v
+	}
+	data[types.ErrorDataKey] = report.Map()
+
+	cfg := c.cfg.errorCapture
+	return c.Log(ctx, types.LogEntry{
+		EventType: types.LogCollect,
+		Level:     level,
+		Service:   cfg.Service,
+		Source:    cfg.Source,
+		Message:   report.Message,
+		Data:      data,
+	})
+}
+
+// rateLimitedErrors returns how many captures were dropped by rate limiting
+func (c *Client) rateLimitedErrors() int64 {
+	return atomic.LoadInt64(&c.errorsRateLimited)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/usercanal/sdk-go/internal/convert"
 	"github.com/usercanal/sdk-go/types"
 )
 
@@ -20,12 +19,32 @@ func (c *Client) Track(ctx context.Context, event types.Event) error {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
+	switch c.checkConsent(ctx, event.UserId, event.Name, true) {
+	case consentSuppress:
+		return nil
+	case consentAnonymize:
+		event.UserId = types.AnonymousUserID
+		event.DeviceID = nil
+		event.SessionID = nil
+	}
+
+	return c.track(ctx, event)
+}
+
+// track converts and queues a validated event, bypassing consent checks
+func (c *Client) track(ctx context.Context, event types.Event) error {
 	// Set timestamp if not set
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now()
 	}
 
-	transportEvent, err := convert.EventToInternal(&event)
+	props, err := c.prepareProperties(ctx, event.Properties)
+	if err != nil {
+		return err
+	}
+	event.Properties = props
+
+	transportEvent, err := c.converter.EventToInternal(&event)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
@@ -33,7 +52,7 @@ func (c *Client) Track(ctx context.Context, event types.Event) error {
 	// Use minimal enrichment for server-side (device_id only, no auto session generation)
 	transportEvent = c.identityMgr.EnrichEventMinimal(transportEvent)
 
-	if err := c.eventBatcher.Add(ctx, transportEvent); err != nil {
+	if err := c.eventQueue(event.Name).Add(ctx, transportEvent); err != nil {
 		return fmt.Errorf("failed to add event: %w", err)
 	}
 
@@ -50,7 +69,17 @@ func (c *Client) Identify(ctx context.Context, identity types.Identity) error {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
-	transportEvent, err := convert.IdentityToInternal(&identity)
+	if c.checkConsent(ctx, identity.UserId, "identify", false) != consentAllow {
+		return nil
+	}
+
+	props, err := c.prepareProperties(ctx, identity.Properties)
+	if err != nil {
+		return err
+	}
+	identity.Properties = props
+
+	transportEvent, err := c.converter.IdentityToInternal(&identity)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
@@ -75,7 +104,17 @@ func (c *Client) Group(ctx context.Context, groupInfo types.GroupInfo) error {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
-	transportEvent, err := convert.GroupToInternal(&groupInfo)
+	if c.checkConsent(ctx, groupInfo.UserId, "group", false) != consentAllow {
+		return nil
+	}
+
+	props, err := c.prepareProperties(ctx, groupInfo.Properties)
+	if err != nil {
+		return err
+	}
+	groupInfo.Properties = props
+
+	transportEvent, err := c.converter.GroupToInternal(&groupInfo)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
@@ -90,6 +129,41 @@ func (c *Client) Group(ctx context.Context, groupInfo types.GroupInfo) error {
 	return nil
 }
 
+// Alias links a previous identifier to a user
+func (c *Client) Alias(ctx context.Context, alias types.Alias) error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
+
+	if err := alias.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+
+	if c.checkConsent(ctx, alias.UserId, "alias", false) != consentAllow {
+		return nil
+	}
+
+	props, err := c.prepareProperties(ctx, alias.Properties)
+	if err != nil {
+		return err
+	}
+	alias.Properties = props
+
+	transportEvent, err := c.converter.AliasToInternal(&alias)
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+
+	// Use minimal enrichment for server-side (device_id only, no auto session generation)
+	transportEvent = c.identityMgr.EnrichEventMinimal(transportEvent)
+
+	if err := c.eventBatcher.Add(ctx, transportEvent); err != nil {
+		return fmt.Errorf("failed to add alias event: %w", err)
+	}
+
+	return nil
+}
+
 // Revenue tracks a revenue event
 func (c *Client) Revenue(ctx context.Context, rev types.Revenue) error {
 	if err := c.checkClosed(); err != nil {
@@ -100,7 +174,22 @@ func (c *Client) Revenue(ctx context.Context, rev types.Revenue) error {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
-	transportEvent, err := convert.RevenueToInternal(&rev)
+	switch c.checkConsent(ctx, rev.UserID, types.OrderCompleted, true) {
+	case consentSuppress:
+		return nil
+	case consentAnonymize:
+		rev.UserID = types.AnonymousUserID
+		rev.DeviceID = nil
+		rev.SessionID = nil
+	}
+
+	props, err := c.prepareProperties(ctx, rev.Properties)
+	if err != nil {
+		return err
+	}
+	rev.Properties = props
+
+	transportEvent, err := c.converter.RevenueToInternal(&rev)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
@@ -108,7 +197,7 @@ func (c *Client) Revenue(ctx context.Context, rev types.Revenue) error {
 	// Use minimal enrichment for server-side (device_id only, no auto session generation)
 	transportEvent = c.identityMgr.EnrichEventMinimal(transportEvent)
 
-	if err := c.eventBatcher.Add(ctx, transportEvent); err != nil {
+	if err := c.eventQueue(types.OrderCompleted).Add(ctx, transportEvent); err != nil {
 		return fmt.Errorf("failed to add revenue event: %w", err)
 	}
 
@@ -125,21 +214,35 @@ func (c *Client) TrackAdvanced(ctx context.Context, event types.EventAdvanced) e
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
+	switch c.checkConsent(ctx, event.UserId, event.Name, true) {
+	case consentSuppress:
+		return nil
+	case consentAnonymize:
+		event.UserId = types.AnonymousUserID
+		event.DeviceID = nil
+		event.SessionID = nil
+	}
+
 	// Set timestamp if not provided
 	timestamp := time.Now()
 	if event.Timestamp != nil {
 		timestamp = *event.Timestamp
 	}
 
+	props, err := c.prepareProperties(ctx, event.Properties)
+	if err != nil {
+		return err
+	}
+
 	// Convert to regular Event for transport conversion
 	regularEvent := types.Event{
 		UserId:     event.UserId,
 		Name:       event.Name,
-		Properties: event.Properties,
+		Properties: props,
 		Timestamp:  timestamp,
 	}
 
-	transportEvent, err := convert.EventToInternal(&regularEvent)
+	transportEvent, err := c.converter.EventToInternal(&regularEvent)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
@@ -160,7 +263,7 @@ func (c *Client) TrackAdvanced(ctx context.Context, event types.EventAdvanced) e
 		transportEvent = c.identityMgr.EnrichEventMinimal(transportEvent)
 	}
 
-	if err := c.eventBatcher.Add(ctx, transportEvent); err != nil {
+	if err := c.eventQueue(event.Name).Add(ctx, transportEvent); err != nil {
 		return fmt.Errorf("failed to add advanced event: %w", err)
 	}
 
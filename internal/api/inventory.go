@@ -0,0 +1,59 @@
+// sdk-go/internal/api/inventory.go
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/inventory"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// ReportInventory sends a service or asset snapshot to the live catalogue
+func (c *Client) ReportInventory(ctx context.Context, inv types.Inventory) error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
+
+	if inv.Kind == 0 {
+		inv.Kind = types.InventoryService
+	}
+	if inv.Hostname == "" {
+		inv.Hostname = hostname
+	}
+	if inv.Timestamp.IsZero() {
+		inv.Timestamp = time.Now()
+	}
+
+	if err := inv.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+
+	// Attributes go through the same normalization and redaction as event
+	// properties, so tags and rules apply before encryption
+	attributes, err := types.NormalizeProperties(inv.Attributes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+	inv.Attributes = c.redactProperties(attributes)
+
+	transportInventory, err := c.converter.InventoryToInternal(&inv, c.instanceID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+
+	if err := c.inventoryBatcher.Add(ctx, transportInventory); err != nil {
+		return fmt.Errorf("failed to add inventory: %w", err)
+	}
+
+	return nil
+}
+
+// reportServiceInventory sends the automatic snapshot of this process
+func (c *Client) reportServiceInventory(ctx context.Context) error {
+	cfg := c.cfg.inventory
+	inv := inventory.Collect(cfg.Service, cfg.IncludeDependencies)
+	inv.Attributes = cfg.Attributes
+	return c.ReportInventory(ctx, inv)
+}
@@ -0,0 +1,74 @@
+// sdk-go/internal/api/inventory_test.go
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/encrypt"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+type deployment struct {
+	Region string `usercanal:"region"`
+	Token  string `usercanal:"token"`
+	Owner  string `usercanal:"owner"`
+	Notes  string `usercanal:"-"`
+}
+
+func TestReportInventoryPreparesAttributes(t *testing.T) {
+	key := make([]byte, 32)
+	c := newTestClient(t,
+		WithEncryption(&types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": key}, Fields: []string{"deploy.token"}}),
+		WithRedaction(&types.RedactionConfig{Rules: []types.RedactionRule{{Keys: []string{"owner"}, Action: types.RedactMask}}}),
+	)
+
+	attrs := types.Properties{
+		"deploy": deployment{Region: "eu", Token: "secret", Owner: "ops@example.com", Notes: "internal"},
+	}
+	if err := c.ReportInventory(context.Background(), types.Inventory{Service: "api", Attributes: attrs}); err != nil {
+		t.Fatalf("ReportInventory: %v", err)
+	}
+	if _, ok := attrs["deploy"].(deployment); !ok {
+		t.Error("ReportInventory modified the caller's attributes")
+	}
+
+	var queued []*transport.Inventory
+	c.inventoryBatcher.Remove(func(inv *transport.Inventory) bool {
+		queued = append(queued, inv)
+		return true
+	})
+	if len(queued) != 1 {
+		t.Fatalf("queued %d inventories, want 1", len(queued))
+	}
+
+	got, _ := payload(t, queued[0].Payload)["attributes"].(map[string]interface{})
+	deploy, _ := got["deploy"].(map[string]interface{})
+	if deploy == nil {
+		t.Fatalf("attributes = %v, want the struct as a map", got)
+	}
+	if deploy["region"] != "eu" {
+		t.Errorf("region = %v, want eu under its tag name", deploy["region"])
+	}
+	if _, ok := deploy["Notes"]; ok {
+		t.Error("field tagged \"-\" was sent")
+	}
+	if deploy["owner"] != types.RedactedPlaceholder {
+		t.Errorf("owner = %v, want it redacted", deploy["owner"])
+	}
+	token, err := encrypt.Decrypt(deploy["token"], map[string][]byte{"k1": key})
+	if err != nil || token != "secret" {
+		t.Errorf("decrypted token = %v, %v; want secret", token, err)
+	}
+}
+
+func TestReportInventoryRejectsInvalidAttributes(t *testing.T) {
+	c := newTestClient(t)
+	cyclic := types.Properties{}
+	cyclic["self"] = cyclic
+
+	if err := c.ReportInventory(context.Background(), types.Inventory{Service: "api", Attributes: cyclic}); err == nil {
+		t.Error("cyclic attributes were accepted")
+	}
+}
@@ -0,0 +1,68 @@
+// sdk-go/internal/api/logbridge.go
+package api
+
+import "github.com/usercanal/sdk-go/internal/logbridge"
+
+// Logger returns a logger bound to service. An empty service uses the client's
+// service name; the source defaults to the hostname.
+func (c *Client) Logger(service string, opts ...logbridge.LoggerOption) *logbridge.Logger {
+	if service == "" {
+		service = c.cfg.serviceName
+	}
+	return logbridge.NewLogger(c.Log, service, hostname, opts...)
+}
+
+// SlogHandler returns a log/slog handler that sends records through Log.
+// Service defaults to the client's service name and Source to the hostname.
+func (c *Client) SlogHandler(opts logbridge.HandlerOptions) *logbridge.Handler {
+	if opts.Service == "" {
+		opts.Service = c.cfg.serviceName
+	}
+	if opts.Source == "" {
+		opts.Source = hostname
+	}
+	return logbridge.NewHandler(c.Log, opts)
+}
+
+// LogWriter returns an io.Writer that sends each written line through Log.
+// The writer is flushed by Flush and Close, until it is closed itself.
+func (c *Client) LogWriter(opts logbridge.WriterOptions) *logbridge.Writer {
+	if opts.Service == "" {
+		opts.Service = c.cfg.serviceName
+	}
+	if opts.Source == "" {
+		opts.Source = hostname
+	}
+	w := logbridge.NewWriter(c.Log, opts)
+
+	c.writersMu.Lock()
+	c.logWriters = append(c.logWriters, w)
+	c.writersMu.Unlock()
+
+	w.OnClose(func() { c.removeLogWriter(w) })
+	return w
+}
+
+// removeLogWriter stops flushing a closed writer
+func (c *Client) removeLogWriter(w *logbridge.Writer) {
+	c.writersMu.Lock()
+	defer c.writersMu.Unlock()
+
+	for i, lw := range c.logWriters {
+		if lw == w {
+			c.logWriters = append(c.logWriters[:i:i], c.logWriters[i+1:]...)
+			return
+		}
+	}
+}
+
+// flushLogWriters sends lines still held by log writers
+func (c *Client) flushLogWriters() {
+	c.writersMu.Lock()
+	writers := c.logWriters
+	c.writersMu.Unlock()
+
+	for _, w := range writers {
+		w.Flush()
+	}
+}
@@ -0,0 +1,59 @@
+// sdk-go/internal/api/logbridge_test.go
+package api
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/identity"
+	"github.com/usercanal/sdk-go/internal/logbridge"
+)
+
+func TestLoggerDefaults(t *testing.T) {
+	c := newTestClient(t, WithServiceName("checkout"))
+	ctxSession := bytes.Repeat([]byte{1}, 16)
+	ownSession := bytes.Repeat([]byte{2}, 16)
+	ctx := identity.ContextWithSessionID(context.Background(), ctxSession)
+
+	c.Logger("").Info(ctx, "from context")
+	c.Logger("billing", logbridge.WithSource("worker-1"), logbridge.WithSessionID(ownSession)).Info(ctx, "own session")
+
+	logs := queuedLogs(c)
+	if len(logs) != 2 {
+		t.Fatalf("got %d queued logs, want 2", len(logs))
+	}
+	if logs[0].Service != "checkout" || logs[0].Source != hostname || !bytes.Equal(logs[0].SessionID, ctxSession) {
+		t.Errorf("default logger sent service %q, source %q, session %x", logs[0].Service, logs[0].Source, logs[0].SessionID)
+	}
+	if logs[1].Service != "billing" || logs[1].Source != "worker-1" || !bytes.Equal(logs[1].SessionID, ownSession) {
+		t.Errorf("configured logger sent service %q, source %q, session %x", logs[1].Service, logs[1].Source, logs[1].SessionID)
+	}
+}
+
+func TestLogWriterClose(t *testing.T) {
+	c := newTestClient(t)
+	first := c.LogWriter(logbridge.WriterOptions{})
+	second := c.LogWriter(logbridge.WriterOptions{})
+	if len(c.logWriters) != 2 {
+		t.Fatalf("got %d writers, want 2", len(c.logWriters))
+	}
+
+	first.Close()
+	first.Close()
+	if len(c.logWriters) != 1 || c.logWriters[0] != second {
+		t.Fatalf("writers after close = %v, want only the second", c.logWriters)
+	}
+
+	second.Write([]byte("held"))
+	c.flushLogWriters()
+	logs := queuedLogs(c)
+	if len(logs) != 1 {
+		t.Fatalf("got %d queued logs, want 1", len(logs))
+	}
+
+	second.Close()
+	if len(c.logWriters) != 0 {
+		t.Errorf("got %d writers, want 0", len(c.logWriters))
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"time"
 
-	"github.com/usercanal/sdk-go/internal/convert"
+	"github.com/usercanal/sdk-go/internal/identity"
+	"github.com/usercanal/sdk-go/internal/logfilter"
+	"github.com/usercanal/sdk-go/internal/logger"
 	"github.com/usercanal/sdk-go/types"
 )
 
@@ -31,17 +33,42 @@ func (c *Client) Log(ctx context.Context, entry types.LogEntry) error {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
+	// Drop filtered entries before any encoding work
+	if c.logFilter.Allow(entry.Service, entry.Level, entry.Message) != logfilter.Allowed {
+		return nil
+	}
+
+	return c.enqueueLog(ctx, entry)
+}
+
+// enqueueLog fills in a validated entry and passes it through deduplication
+func (c *Client) enqueueLog(ctx context.Context, entry types.LogEntry) error {
 	// Set timestamp if not set
 	if entry.Timestamp.IsZero() {
 		entry.Timestamp = time.Now()
 	}
 
-	transportLog, err := convert.LogToInternal(&entry)
+	if len(entry.SessionID) == 0 {
+		entry.SessionID = identity.SessionIDFromContext(ctx)
+	}
+	entry.Data = withTraceContext(ctx, c.superProps.Merge(entry.Data))
+
+	if c.logDedupe != nil && !c.logDedupe.Add(entry) {
+		return nil
+	}
+	return c.queueLog(ctx, entry)
+}
+
+// queueLog redacts and converts an entry and adds it to the log batcher
+func (c *Client) queueLog(ctx context.Context, entry types.LogEntry) error {
+	c.redactLog(&entry)
+
+	transportLog, err := c.converter.LogToInternal(&entry)
 	if err != nil {
 		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
 	}
 
-	if err := c.logBatcher.Add(ctx, transportLog); err != nil {
+	if err := c.logQueue(entry.Level).Add(ctx, transportLog); err != nil {
 		return fmt.Errorf("failed to add log entry: %w", err)
 	}
 
@@ -194,3 +221,54 @@ func (c *Client) LogTrace(ctx context.Context, service, source, message string,
 		Data:      data,
 	})
 }
+
+// SetLogLevel changes the least severe level sent for service at runtime.
+// An empty service sets the default for services without their own level.
+func (c *Client) SetLogLevel(service string, level types.LogLevel) error {
+	if level > types.LogTrace {
+		return types.NewValidationError("level", "invalid log level")
+	}
+	c.logFilter.SetLevel(service, level)
+	return nil
+}
+
+// reportFilteredLogs sends a NOTICE entry for each service that dropped logs.
+// The entries bypass the filter so they are never dropped themselves.
+func (c *Client) reportFilteredLogs(summaries []logfilter.Summary) {
+	window := c.logFilter.Interval().String()
+	for _, s := range summaries {
+		entry := types.LogEntry{
+			EventType: types.LogCollect,
+			Level:     types.LogNotice,
+			Service:   s.Service,
+			Source:    hostname,
+			Message:   fmt.Sprintf("usercanal: %d log entries dropped by client-side filtering", s.Total()),
+			Data: map[string]interface{}{
+				"dropped": map[string]interface{}{
+					"below_level":  s.BelowLevel,
+					"sampled":      s.Sampled,
+					"rate_limited": s.RateLimited,
+				},
+				"window": window,
+			},
+		}
+		if err := c.enqueueLog(context.Background(), entry); err != nil {
+			logger.Warn("Failed to report filtered logs: %v", err)
+		}
+	}
+}
+
+// emitFoldedLog queues an entry reporting collapsed duplicates
+func (c *Client) emitFoldedLog(entry types.LogEntry) {
+	if err := c.queueLog(context.Background(), entry); err != nil {
+		logger.Warn("Failed to send folded log entry: %v", err)
+	}
+}
+
+// dedupedLogs returns how many log entries were folded into others
+func (c *Client) dedupedLogs() int64 {
+	if c.logDedupe == nil {
+		return 0
+	}
+	return c.logDedupe.Folded()
+}
@@ -0,0 +1,31 @@
+// sdk-go/internal/api/metrics.go
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/internal/metrics"
+)
+
+// Metrics returns the registry used to create counters, gauges, histograms and timers.
+// Instruments are aggregated in-process and sent once per metrics interval.
+func (c *Client) Metrics() *metrics.Registry {
+	return c.metrics
+}
+
+// emitMetrics converts the aggregates of a flush window and queues them for sending
+func (c *Client) emitMetrics(ctx context.Context, snapshots []metrics.Snapshot) error {
+	for i := range snapshots {
+		transportMetric, err := c.converter.MetricToInternal(&snapshots[i])
+		if err != nil {
+			logger.Warn("Dropping metric %q: %v", snapshots[i].Name, err)
+			continue
+		}
+		if err := c.metricBatcher.Add(ctx, transportMetric); err != nil {
+			return fmt.Errorf("failed to add metric: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// sdk-go/internal/api/priority.go
+package api
+
+import (
+	"context"
+
+	"github.com/usercanal/sdk-go/internal/batch"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// defaultPriorityLevels are the log levels sent through the priority lane by default
+var defaultPriorityLevels = []types.LogLevel{types.LogEmergency, types.LogAlert, types.LogCritical}
+
+// priorityLane holds the queues for critical logs and chosen events
+type priorityLane struct {
+	levels       map[types.LogLevel]struct{}
+	events       map[types.EventName]struct{}
+	logBatcher   *batch.Manager[*transport.Log]
+	eventBatcher *batch.Manager[*transport.Event]
+}
+
+// newPriorityLane creates the priority queues, or returns nil when the lane is disabled
+func newPriorityLane(cfg *types.PriorityConfig, logSend batch.SendFunc[*transport.Log], eventSend batch.SendFunc[*transport.Event]) *priorityLane {
+	if cfg.Disabled {
+		return nil
+	}
+
+	levels := cfg.LogLevels
+	if len(levels) == 0 {
+		levels = defaultPriorityLevels
+	}
+	lane := &priorityLane{
+		levels: make(map[types.LogLevel]struct{}, len(levels)),
+		events: make(map[types.EventName]struct{}, len(cfg.Events)),
+	}
+	for _, level := range levels {
+		lane.levels[level] = struct{}{}
+	}
+	for _, name := range cfg.Events {
+		lane.events[name] = struct{}{}
+	}
+
+	// A batch size of one sends each item as soon as it is added
+	size := defaultBatchSize
+	if cfg.Immediate {
+		size = 1
+	}
+	lane.logBatcher = batch.NewManager(size, cfg.Window, prioritySend(logSend),
+		batch.WithSizeFunc(logSize), batch.WithMaxBatchBytes[*transport.Log](transport.MaxBatchBytes))
+	lane.eventBatcher = batch.NewManager(size, cfg.Window, prioritySend(eventSend),
+		batch.WithSizeFunc(eventSize), batch.WithMaxBatchBytes[*transport.Event](transport.MaxBatchBytes))
+	return lane
+}
+
+// prioritySend marks batches from the lane so the transport writes them first
+func prioritySend[T any](send batch.SendFunc[T]) batch.SendFunc[T] {
+	return func(ctx context.Context, items []T) error {
+		return send(transport.WithPriority(ctx), items)
+	}
+}
+
+// logQueue returns the batcher for a log entry at level
+func (c *Client) logQueue(level types.LogLevel) *batch.Manager[*transport.Log] {
+	if c.priority != nil {
+		if _, ok := c.priority.levels[level]; ok {
+			return c.priority.logBatcher
+		}
+	}
+	return c.logBatcher
+}
+
+// eventQueue returns the batcher for an event called name
+func (c *Client) eventQueue(name types.EventName) *batch.Manager[*transport.Event] {
+	if c.priority != nil {
+		if _, ok := c.priority.events[name]; ok {
+			return c.priority.eventBatcher
+		}
+	}
+	return c.eventBatcher
+}
+
+// flushPriority sends whatever is waiting in the priority lane
+func (c *Client) flushPriority(ctx context.Context) error {
+	if c.priority == nil {
+		return nil
+	}
+	if err := c.priority.logBatcher.Flush(ctx); err != nil {
+		return err
+	}
+	return c.priority.eventBatcher.Flush(ctx)
+}
+
+// closePriority flushes and stops the priority lane
+func (c *Client) closePriority() error {
+	if c.priority == nil {
+		return nil
+	}
+	logErr := c.priority.logBatcher.Close()
+	if err := c.priority.eventBatcher.Close(); err != nil {
+		return err
+	}
+	return logErr
+}
+
+// streamBatchers returns the event and log batchers, bulk queue first
+func (c *Client) streamBatchers() (events []*batch.Manager[*transport.Event], logs []*batch.Manager[*transport.Log]) {
+	events = []*batch.Manager[*transport.Event]{c.eventBatcher}
+	logs = []*batch.Manager[*transport.Log]{c.logBatcher}
+	if c.priority != nil {
+		events = append(events, c.priority.eventBatcher)
+		logs = append(logs, c.priority.logBatcher)
+	}
+	return events, logs
+}
@@ -0,0 +1,154 @@
+// sdk-go/internal/api/priority_test.go
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestPriorityRouting(t *testing.T) {
+	levels := []types.LogLevel{
+		types.LogEmergency, types.LogAlert, types.LogCritical, types.LogError,
+		types.LogWarning, types.LogNotice, types.LogInfo, types.LogDebug, types.LogTrace,
+	}
+	tests := []struct {
+		name       string
+		cfg        *types.PriorityConfig
+		wantLevels map[types.LogLevel]bool
+		wantEvents map[types.EventName]bool
+	}{
+		{
+			name:       "default levels",
+			wantLevels: map[types.LogLevel]bool{types.LogEmergency: true, types.LogAlert: true, types.LogCritical: true},
+		},
+		{
+			name:       "configured levels and events",
+			cfg:        &types.PriorityConfig{LogLevels: []types.LogLevel{types.LogError}, Events: []types.EventName{types.SubscriptionStarted}},
+			wantLevels: map[types.LogLevel]bool{types.LogError: true},
+			wantEvents: map[types.EventName]bool{types.SubscriptionStarted: true},
+		},
+		{
+			name: "disabled",
+			cfg:  &types.PriorityConfig{Disabled: true, Events: []types.EventName{types.SubscriptionStarted}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.cfg != nil {
+				opts = append(opts, WithPriority(tt.cfg))
+			}
+			c := newTestClient(t, opts...)
+			if (c.priority == nil) != (tt.cfg != nil && tt.cfg.Disabled) {
+				t.Fatalf("priority lane = %v, disabled = %v", c.priority, tt.cfg != nil && tt.cfg.Disabled)
+			}
+
+			ctx := context.Background()
+			for _, level := range levels {
+				if err := c.Log(ctx, types.LogEntry{Level: level, Service: "api", Source: "host-1", Message: "m"}); err != nil {
+					t.Fatalf("Log(%v): %v", level, err)
+				}
+				if got := c.logQueue(level) != c.logBatcher; got != tt.wantLevels[level] {
+					t.Errorf("level %v routed to the priority lane = %v, want %v", level, got, tt.wantLevels[level])
+				}
+			}
+			for _, name := range []types.EventName{types.FeatureUsed, types.SubscriptionStarted} {
+				if err := c.Track(ctx, types.Event{UserId: "user_1", Name: name}); err != nil {
+					t.Fatalf("Track(%s): %v", name, err)
+				}
+				if got := c.eventQueue(name) != c.eventBatcher; got != tt.wantEvents[name] {
+					t.Errorf("event %s routed to the priority lane = %v, want %v", name, got, tt.wantEvents[name])
+				}
+			}
+
+			wantPriorityLogs, wantPriorityEvents := int64(len(tt.wantLevels)), int64(len(tt.wantEvents))
+			if c.priority != nil {
+				if got := c.priority.logBatcher.QueueSize(); got != wantPriorityLogs {
+					t.Errorf("priority log queue = %d, want %d", got, wantPriorityLogs)
+				}
+				if got := c.priority.eventBatcher.QueueSize(); got != wantPriorityEvents {
+					t.Errorf("priority event queue = %d, want %d", got, wantPriorityEvents)
+				}
+			}
+			if got := c.logBatcher.QueueSize(); got != int64(len(levels))-wantPriorityLogs {
+				t.Errorf("bulk log queue = %d, want %d", got, int64(len(levels))-wantPriorityLogs)
+			}
+			if got := c.eventBatcher.QueueSize(); got != 2-wantPriorityEvents {
+				t.Errorf("bulk event queue = %d, want %d", got, 2-wantPriorityEvents)
+			}
+		})
+	}
+}
+
+type callerKey struct{}
+
+func TestPriorityLaneSends(t *testing.T) {
+	tests := []struct {
+		name      string
+		immediate bool
+	}{
+		{name: "batched"},
+		{name: "immediate", immediate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make(chan context.Context, 2)
+			send := func(ctx context.Context, logs []*transport.Log) error {
+				sent <- ctx
+				return nil
+			}
+			noEvents := func(context.Context, []*transport.Event) error { return nil }
+			lane := newPriorityLane(&types.PriorityConfig{Window: time.Hour, Immediate: tt.immediate}, send, noEvents)
+			defer lane.logBatcher.Close()
+			defer lane.eventBatcher.Close()
+
+			ctx := context.WithValue(context.Background(), callerKey{}, "caller")
+			if err := lane.logBatcher.Add(ctx, &transport.Log{}); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+
+			// An immediate lane has sent the item, from the caller, by the time Add returns
+			select {
+			case sendCtx := <-sent:
+				if !tt.immediate {
+					t.Fatal("batched lane sent before its window")
+				}
+				if sendCtx.Value(callerKey{}) != "caller" {
+					t.Error("immediate send did not run with the caller's context")
+				}
+			default:
+				if tt.immediate {
+					t.Fatal("immediate lane had not sent when Add returned")
+				}
+			}
+		})
+	}
+}
+
+func TestRequestDeletionClearsPriorityQueue(t *testing.T) {
+	c := newTestClient(t, WithPriority(&types.PriorityConfig{Window: time.Hour, Events: []types.EventName{types.SubscriptionStarted}}))
+	ctx := context.Background()
+
+	for _, user := range []string{"user_1", "user_2"} {
+		if err := c.Track(ctx, types.Event{UserId: user, Name: types.SubscriptionStarted}); err != nil {
+			t.Fatalf("Track: %v", err)
+		}
+	}
+	if got := c.priority.eventBatcher.QueueSize(); got != 2 {
+		t.Fatalf("priority event queue = %d, want 2", got)
+	}
+
+	if err := c.RequestDeletion(ctx, "user_1"); err != nil {
+		t.Fatalf("RequestDeletion: %v", err)
+	}
+	queued := queuedEvents(c)
+	if len(queued) != 1 || queued[0].UserID != "user_2" {
+		t.Errorf("queued events after deletion = %v, want only user_2's", queued)
+	}
+}
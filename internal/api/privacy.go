@@ -0,0 +1,94 @@
+// sdk-go/internal/api/privacy.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// RequestDeletion asks the collector to erase all data held for userID.
+// Events for the user still queued, or in a batch whose send fails, are dropped,
+// and further events for the user are suppressed locally. The request is sent
+// immediately, retrying up to MaxRetries times while ctx allows.
+func (c *Client) RequestDeletion(ctx context.Context, userID string) error {
+	return c.sendPrivacyRequest(ctx, userID, types.PrivacyDelete)
+}
+
+// Suppress asks the collector to stop processing data for userID. Queued events
+// for the user are dropped and any further events for the user are suppressed locally.
+func (c *Client) Suppress(ctx context.Context, userID string) error {
+	return c.sendPrivacyRequest(ctx, userID, types.PrivacySuppress)
+}
+
+// privacyRetryInterval is the delay before the first retry of a privacy request
+var privacyRetryInterval = 500 * time.Millisecond
+
+// isSuppressed reports whether a deletion or suppression was recently requested
+// for userID. The list is bounded and kept in memory only, so it does not survive
+// a restart; the collector enforces the request itself.
+func (c *Client) isSuppressed(userID string) bool {
+	return c.suppressed.Contains(userID)
+}
+
+func (c *Client) sendPrivacyRequest(ctx context.Context, userID string, action types.PrivacyAction) error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
+
+	event, err := c.converter.PrivacyRequestToInternal(userID, action)
+	if err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+
+	// Block the user first, so only events already past the check can be queued
+	// after the queues are cleared
+	c.suppressed.Add(userID)
+
+	matchUser := func(queued *transport.Event) bool {
+		return queued.UserID == userID
+	}
+	dropped := c.eventBatcher.Remove(matchUser)
+	if c.priority != nil {
+		dropped += c.priority.eventBatcher.Remove(matchUser)
+	}
+	if dropped > 0 {
+		logger.Debug("Dropped %d queued events for %s request", dropped, action)
+	}
+
+	// Bypass the batcher: privacy requests are delivered immediately and retried
+	if err := retryPrivacyRequest(ctx, c.sender.SendEvents, event, action, c.cfg.maxRetries); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", action, err)
+	}
+
+	logger.Debug("Privacy %s request delivered", action)
+	return nil
+}
+
+// retryPrivacyRequest sends event until it is delivered, it is rejected as invalid,
+// maxRetries retries have failed or ctx ends
+func retryPrivacyRequest(ctx context.Context, send func(context.Context, []*transport.Event) error, event *transport.Event, action types.PrivacyAction, maxRetries int) error {
+	policy := backoff.NewExponentialBackOff()
+	policy.InitialInterval = privacyRetryInterval
+	policy.MaxElapsedTime = 0
+	retry := backoff.WithContext(backoff.WithMaxRetries(policy, uint64(maxRetries)), ctx)
+
+	operation := func() error {
+		err := send(ctx, []*transport.Event{event})
+		if errors.Is(err, types.ErrInvalidInput) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	notify := func(err error, d time.Duration) {
+		logger.Warn("Privacy %s request failed, retrying in %v: %v", action, d, err)
+	}
+
+	return backoff.RetryNotify(operation, retry, notify)
+}
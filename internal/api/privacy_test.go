@@ -0,0 +1,130 @@
+// sdk-go/internal/api/privacy_test.go
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestRetryPrivacyRequest(t *testing.T) {
+	prev := privacyRetryInterval
+	privacyRetryInterval = time.Millisecond
+	defer func() { privacyRetryInterval = prev }()
+
+	errNetwork := &types.NetworkError{Operation: "Send", Message: "connection reset"}
+	errInvalid := types.ErrInvalidInput
+
+	tests := []struct {
+		name       string
+		results    []error // Result of each send, repeating the last
+		maxRetries int
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "delivered first time", results: []error{nil}, maxRetries: 3, wantCalls: 1},
+		{name: "delivered after failures", results: []error{errNetwork, errNetwork, nil}, maxRetries: 3, wantCalls: 3},
+		{name: "gives up after max retries", results: []error{errNetwork}, maxRetries: 2, wantCalls: 3, wantErr: true},
+		{name: "invalid request is not retried", results: []error{errInvalid}, maxRetries: 3, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			send := func(_ context.Context, events []*transport.Event) error {
+				if len(events) != 1 {
+					t.Fatalf("sent %d events, want 1", len(events))
+				}
+				err := tt.results[min(calls, len(tt.results)-1)]
+				calls++
+				return err
+			}
+
+			err := retryPrivacyRequest(context.Background(), send, &transport.Event{}, types.PrivacyDelete, tt.maxRetries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("send called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryPrivacyRequestStopsWithContext(t *testing.T) {
+	prev := privacyRetryInterval
+	privacyRetryInterval = time.Millisecond
+	defer func() { privacyRetryInterval = prev }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	send := func(context.Context, []*transport.Event) error {
+		calls++
+		cancel()
+		return errors.New("unreachable")
+	}
+
+	if err := retryPrivacyRequest(ctx, send, &transport.Event{}, types.PrivacySuppress, 100); err == nil {
+		t.Fatal("expected error after the context ended")
+	}
+	if calls != 1 {
+		t.Errorf("send called %d times after cancel, want 1", calls)
+	}
+}
+
+func TestPrivacyRequestsSuppressUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func(*Client, context.Context, string) error
+	}{
+		{name: "deletion", request: (*Client).RequestDeletion},
+		{name: "suppression", request: (*Client).Suppress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			ctx := context.Background()
+
+			for _, user := range []string{"user_1", "user_2", "user_1"} {
+				if err := c.Track(ctx, types.Event{UserId: user, Name: types.FeatureUsed}); err != nil {
+					t.Fatalf("Track: %v", err)
+				}
+			}
+
+			if err := tt.request(c, ctx, "user_1"); err != nil {
+				t.Fatalf("request: %v", err)
+			}
+
+			// Events tracked after the request are dropped locally
+			if err := c.Track(ctx, types.Event{UserId: "user_1", Name: types.FeatureUsed}); err != nil {
+				t.Fatalf("Track: %v", err)
+			}
+
+			queued := queuedEvents(c)
+			if len(queued) != 1 || queued[0].UserID != "user_2" {
+				t.Fatalf("queued %d events, want only the event for user_2", len(queued))
+			}
+			if !c.isSuppressed("user_1") || c.isSuppressed("user_2") {
+				t.Error("only user_1 should be suppressed")
+			}
+			if got := c.suppressedEvents(); got != 1 {
+				t.Errorf("suppressed events = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestPrivacyRequestValidation(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.RequestDeletion(context.Background(), ""); !errors.Is(err, types.ErrInvalidInput) {
+		t.Fatalf("err = %v, want ErrInvalidInput", err)
+	}
+	if c.isSuppressed("") {
+		t.Error("invalid request suppressed a user")
+	}
+}
@@ -0,0 +1,20 @@
+// sdk-go/internal/api/properties.go
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// prepareProperties merges registered properties under props, converts struct and
+// typed values to plain maps and slices, then adds trace context and applies
+// redaction, so rules see nested and registered fields
+func (c *Client) prepareProperties(ctx context.Context, props types.Properties) (types.Properties, error) {
+	props, err := types.NormalizeProperties(c.superProps.Merge(props))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+	return c.redactProperties(withTraceContext(ctx, props)), nil
+}
@@ -0,0 +1,31 @@
+// sdk-go/internal/api/redaction.go
+package api
+
+import "github.com/usercanal/sdk-go/types"
+
+// redactProperties applies the configured redaction rules to event properties
+func (c *Client) redactProperties(props types.Properties) types.Properties {
+	if c.redactor == nil {
+		return props
+	}
+	return c.redactor.Properties(props)
+}
+
+// redactLog applies the configured redaction rules to a log message and its data
+func (c *Client) redactLog(entry *types.LogEntry) {
+	if c.redactor == nil {
+		return
+	}
+	if entry.Message != "" {
+		entry.Message = c.redactor.String(entry.Message)
+	}
+	entry.Data = c.redactor.Map(entry.Data)
+}
+
+// redactionCount returns how many values have been redacted
+func (c *Client) redactionCount() int64 {
+	if c.redactor == nil {
+		return 0
+	}
+	return c.redactor.Count()
+}
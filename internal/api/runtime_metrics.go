@@ -0,0 +1,41 @@
+// sdk-go/internal/api/runtime_metrics.go
+package api
+
+import (
+	"context"
+
+	"github.com/usercanal/sdk-go/internal/runtimestats"
+	"github.com/usercanal/sdk-go/types"
+)
+
+const defaultRuntimeService = "go-runtime"
+
+// recordRuntimeMetrics sends one runtime sample through the configured output
+func (c *Client) recordRuntimeMetrics(ctx context.Context, readings []runtimestats.Reading) error {
+	cfg := c.cfg.runtimeMetrics
+
+	if cfg.Output == types.RuntimeMetricsAsLogs {
+		data := make(map[string]interface{}, len(readings))
+		for _, r := range readings {
+			data[r.Name] = r.Value
+		}
+		return c.Log(ctx, types.LogEntry{
+			EventType: types.LogCollect,
+			Level:     types.LogInfo,
+			Service:   cfg.Service,
+			Source:    cfg.Source,
+			Message:   "runtime metrics",
+			Data:      data,
+		})
+	}
+
+	for _, r := range readings {
+		switch r.Kind {
+		case runtimestats.ReadingDelta:
+			c.metrics.Counter(r.Name, cfg.Tags).Add(r.Value)
+		default:
+			c.metrics.Gauge(r.Name, cfg.Tags).Set(r.Value)
+		}
+	}
+	return nil
+}
@@ -17,16 +17,39 @@ func (c *Client) GetStats() types.Stats {
 	// Get connection state (would need to add this method)
 	// connInfo := c.sender.GetConnectionInfo()
 
+	eventBatchers, logBatchers := c.streamBatchers()
+	events := streamStats(transportMetrics.EventsSent, eventBatchers...)
+	logs := streamStats(transportMetrics.LogsSent, logBatchers...)
+
 	// Compose client-level stats from multiple sources
 	return types.Stats{
 		// Queue info from batch managers
-		EventsInQueue: int64(c.eventBatcher.QueueSize()),
-		LogsInQueue:   int64(c.logBatcher.QueueSize()),
+		EventsInQueue:  events.InQueue,
+		LogsInQueue:    logs.InQueue,
+		MetricsInQueue: int64(c.metricBatcher.QueueSize()),
 
 		// Summary from transport metrics
 		EventsSent:   transportMetrics.EventsSent,
 		LogsSent:     transportMetrics.LogsSent,
-		EventsFailed: transportMetrics.FailedAttempts,
+		MetricsSent:  transportMetrics.MetricsSent,
+		SpansSent:    transportMetrics.SpansSent,
+		EventsFailed: events.Failed,
+		LogsFailed:   logs.Failed,
+
+		Events: events,
+		Logs:   logs,
+
+		// Privacy pipeline
+		Redactions:       c.redactionCount(),
+		EventsSuppressed: c.suppressedEvents(),
+		EventsAnonymized: c.anonymizedEvents(),
+
+		// Error capture
+		ErrorsRateLimited: c.rateLimitedErrors(),
+
+		// Log filtering
+		LogsFiltered:     c.logFilter.Dropped(),
+		LogsDeduplicated: c.dedupedLogs(),
 
 		// Connection from transport
 		ConnectionState:  c.sender.State(),
@@ -54,7 +77,17 @@ func (c *Client) DumpStatus() {
 	logger.Info("Events in Queue: %d", stats.EventsInQueue)
 	logger.Info("Events Sent: %d", stats.EventsSent)
 	logger.Info("Failed Events: %d", stats.EventsFailed)
+	logger.Info("Logs in Queue: %d (%d bytes, dropped: %d)", stats.Logs.InQueue, stats.Logs.QueueBytes, stats.Logs.Dropped)
+	logger.Info("Logs Sent: %d (failed: %d)", stats.LogsSent, stats.LogsFailed)
+	logger.Info("Metrics Sent: %d (queued: %d)", stats.MetricsSent, stats.MetricsInQueue)
+	logger.Info("Spans Sent: %d", stats.SpansSent)
+	logger.Info("Redactions: %d", stats.Redactions)
+	logger.Info("Suppressed Events: %d (anonymized: %d)", stats.EventsSuppressed, stats.EventsAnonymized)
+	logger.Info("Rate-limited Error Captures: %d", stats.ErrorsRateLimited)
+	logger.Info("Filtered Logs: %d (deduplicated: %d)", stats.LogsFiltered, stats.LogsDeduplicated)
 	logger.Info("Average Batch Size: %.2f", stats.AverageBatchSize)
+	logger.Info("Event Batching: %d items every %v (adaptive: %t, latency: %v)", stats.Events.BatchSize, stats.Events.FlushInterval, stats.Events.Adaptive, stats.Events.SendLatency)
+	logger.Info("Log Batching: %d items every %v (adaptive: %t, latency: %v)", stats.Logs.BatchSize, stats.Logs.FlushInterval, stats.Logs.Adaptive, stats.Logs.SendLatency)
 	logger.Info("Last Flush: %v", stats.LastFlushTime)
 	logger.Info("Last Failure: %v", stats.LastFailureTime)
 }
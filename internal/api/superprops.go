@@ -0,0 +1,55 @@
+// sdk-go/internal/api/superprops.go
+package api
+
+import (
+	"fmt"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Register adds properties to every event and log entry; both read the same
+// registry. Per-call values take precedence; registering a key again replaces its value.
+func (c *Client) Register(props types.Properties) error {
+	props, err := normalizeSuperProperties(props)
+	if err != nil {
+		return err
+	}
+	c.superProps.Register(props)
+	return nil
+}
+
+// RegisterOnce adds only the properties whose keys are not registered yet, so
+// values set earlier, e.g. at startup, are kept
+func (c *Client) RegisterOnce(props types.Properties) error {
+	props, err := normalizeSuperProperties(props)
+	if err != nil {
+		return err
+	}
+	c.superProps.RegisterOnce(props)
+	return nil
+}
+
+// Unregister stops adding the given keys
+func (c *Client) Unregister(keys ...string) {
+	c.superProps.Unregister(keys...)
+}
+
+// SuperProperties returns a copy of the registered properties
+func (c *Client) SuperProperties() types.Properties {
+	return c.superProps.Properties()
+}
+
+// normalizeSuperProperties checks keys and converts values up front, so a bad
+// registration fails once instead of on every later event
+func normalizeSuperProperties(props types.Properties) (types.Properties, error) {
+	for key := range props {
+		if key == "" {
+			return nil, fmt.Errorf("%w: %v", types.ErrInvalidInput, types.NewValidationError("PropertyKey", "cannot be empty"))
+		}
+	}
+	normalized, err := types.NormalizeProperties(props)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidInput, err)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,209 @@
+// sdk-go/internal/api/superprops_test.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/trace"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestRegisterRejectsEmptyKey(t *testing.T) {
+	c := newTestClient(t)
+	bad := types.Properties{"": 1, "ok": 2}
+
+	if err := c.Register(bad); !errors.Is(err, types.ErrInvalidInput) {
+		t.Errorf("Register err = %v, want ErrInvalidInput", err)
+	}
+	if err := c.RegisterOnce(bad); !errors.Is(err, types.ErrInvalidInput) {
+		t.Errorf("RegisterOnce err = %v, want ErrInvalidInput", err)
+	}
+	if got := c.SuperProperties(); len(got) != 0 {
+		t.Errorf("SuperProperties = %v after rejected registrations, want none", got)
+	}
+
+	if _, err := New(testAPIKey, WithDefaultProperties(bad)); err == nil {
+		t.Error("New accepted a default property with an empty key")
+	}
+}
+
+func TestRegisteredPropertiesPrecedence(t *testing.T) {
+	c := newTestClient(t, WithDefaultProperties(types.Properties{"layer": "default", "default": true}))
+	if err := c.Register(types.Properties{"layer": "registered", "registered": true}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := c.RegisterOnce(types.Properties{"layer": "once", "once": true}); err != nil {
+		t.Fatalf("RegisterOnce: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call types.Properties
+		want map[string]interface{}
+	}{
+		{
+			name: "no per-call values",
+			want: map[string]interface{}{"layer": "registered", "default": true, "registered": true, "once": true},
+		},
+		{
+			name: "per-call values win",
+			call: types.Properties{"layer": "call", "registered": false},
+			want: map[string]interface{}{"layer": "call", "default": true, "registered": false, "once": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := c.Track(ctx, types.Event{UserId: "user_1", Name: types.FeatureUsed, Properties: tt.call}); err != nil {
+				t.Fatalf("Track: %v", err)
+			}
+			if err := c.LogInfo(ctx, "svc", "", "hello", tt.call); err != nil {
+				t.Fatalf("LogInfo: %v", err)
+			}
+
+			events, logs := queuedEvents(c), queuedLogs(c)
+			if len(events) != 1 || len(logs) != 1 {
+				t.Fatalf("queued %d events and %d logs, want 1 each", len(events), len(logs))
+			}
+			if got := payload(t, events[0].Payload); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("event properties = %v, want %v", got, tt.want)
+			}
+			got := payload(t, logs[0].Payload)
+			delete(got, "message")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("log data = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendDoesNotModifyInputs(t *testing.T) {
+	c := newTestClient(t,
+		WithEncryption(&types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": make([]byte, 32)}, Fields: []string{"email"}}),
+		WithRedaction(&types.RedactionConfig{Rules: []types.RedactionRule{
+			{Keys: []string{"password"}, Action: types.RedactDrop},
+			{Keys: []string{"token"}, Action: types.RedactMask},
+		}}),
+	)
+	tracer := trace.NewTracer("svc", func(trace.Record) {})
+	spanCtx, span := tracer.Start(context.Background(), "op")
+	defer span.End()
+
+	// Loggers and slog handlers pass their fields as Data without copying, so
+	// nothing on the send path may write to the map it is given
+	newData := func() map[string]interface{} {
+		return map[string]interface{}{
+			"email":    "a@example.com",
+			"password": "hunter2",
+			"token":    "abc",
+			"nested":   map[string]interface{}{"token": "def"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		registered types.Properties
+		ctx        context.Context
+	}{
+		{name: "nothing registered", ctx: context.Background()},
+		{name: "registered properties", registered: types.Properties{"env": "prod"}, ctx: context.Background()},
+		{name: "trace context", ctx: spanCtx},
+		{name: "registered properties and trace context", registered: types.Properties{"env": "prod"}, ctx: spanCtx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.Unregister("env")
+			if err := c.Register(tt.registered); err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+
+			shared := newData()
+			for i := 0; i < 2; i++ {
+				if err := c.Track(tt.ctx, types.Event{UserId: "user_1", Name: types.FeatureUsed, Properties: shared}); err != nil {
+					t.Fatalf("Track: %v", err)
+				}
+				if err := c.Log(tt.ctx, types.LogEntry{
+					EventType: types.LogCollect,
+					Level:     types.LogInfo,
+					Service:   "svc",
+					Source:    "host",
+					Message:   "hello",
+					Data:      shared,
+				}); err != nil {
+					t.Fatalf("Log: %v", err)
+				}
+			}
+			if want := newData(); !reflect.DeepEqual(shared, want) {
+				t.Errorf("shared data = %v after sending, want %v", shared, want)
+			}
+
+			// The second send must see the same data as the first
+			events, logs := queuedEvents(c), queuedLogs(c)
+			if len(events) != 2 || len(logs) != 2 {
+				t.Fatalf("queued %d events and %d logs, want 2 each", len(events), len(logs))
+			}
+			for _, pair := range [][2][]byte{{events[0].Payload, events[1].Payload}, {logs[0].Payload, logs[1].Payload}} {
+				first, second := payload(t, pair[0]), payload(t, pair[1])
+				if _, ok := first["password"]; ok {
+					t.Errorf("payload %v kept a dropped key", first)
+				}
+				// Encrypted values differ by nonce; compare everything else
+				delete(first, "email")
+				delete(second, "email")
+				if !reflect.DeepEqual(first, second) {
+					t.Errorf("second payload = %v, want %v", second, first)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterWhileSending(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	props := types.Properties{"call": true}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("k%d", i%5)
+				if err := c.Register(types.Properties{key: i}); err != nil {
+					t.Errorf("Register: %v", err)
+					return
+				}
+				c.Unregister(key)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if err := c.Track(ctx, types.Event{UserId: "user_1", Name: types.FeatureUsed, Properties: props}); err != nil {
+					t.Errorf("Track: %v", err)
+					return
+				}
+				if err := c.LogInfo(ctx, "svc", "", "hello", props); err != nil {
+					t.Errorf("LogInfo: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(queuedEvents(c)); got != 400 {
+		t.Errorf("queued %d events, want 400", got)
+	}
+	if len(props) != 1 {
+		t.Errorf("per-call props = %v, want them unchanged", props)
+	}
+}
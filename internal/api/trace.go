@@ -0,0 +1,59 @@
+// sdk-go/internal/api/trace.go
+package api
+
+import (
+	"context"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/internal/trace"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// StartSpan begins a span as a child of the span in ctx, or of a remote parent extracted
+// from incoming headers. The span is sent when End is called.
+func (c *Client) StartSpan(ctx context.Context, name string, opts ...trace.StartOption) (context.Context, *trace.Span) {
+	return c.tracer.Start(ctx, name, opts...)
+}
+
+// exportSpan queues a finished span for sending
+func (c *Client) exportSpan(r trace.Record) {
+	if err := c.checkClosed(); err != nil {
+		logger.Debug("Span %q dropped: %v", r.Name, err)
+		return
+	}
+
+	r.Attributes = c.redactProperties(r.Attributes)
+
+	transportSpan, err := c.converter.SpanToInternal(&r)
+	if err != nil {
+		logger.Warn("Span %q dropped: %v", r.Name, err)
+		return
+	}
+
+	if err := c.spanBatcher.Add(context.Background(), transportSpan); err != nil {
+		logger.Warn("Failed to add span %q: %v", r.Name, err)
+	}
+}
+
+// withTraceContext returns props with the IDs of the active span in ctx added, so logs and
+// events emitted inside a span can be joined with it. Without a local span, the remote
+// parent extracted from incoming headers is used. Existing keys are left untouched and
+// the caller's map is never modified.
+func withTraceContext(ctx context.Context, props map[string]interface{}) map[string]interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return props
+	}
+
+	out := make(map[string]interface{}, len(props)+2)
+	for k, v := range props {
+		out[k] = v
+	}
+	if _, ok := out[types.TraceIDKey]; !ok {
+		out[types.TraceIDKey] = sc.TraceID.String()
+	}
+	if _, ok := out[types.SpanIDKey]; !ok {
+		out[types.SpanIDKey] = sc.SpanID.String()
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+// sdk-go/internal/api/trace_test.go
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/trace"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestWithTraceContext(t *testing.T) {
+	remote, _ := trace.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	tracer := trace.NewTracer("svc", func(trace.Record) {})
+	remoteCtx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+	localCtx, local := tracer.Start(remoteCtx, "op")
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		props map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "no span",
+			ctx:   context.Background(),
+			props: map[string]interface{}{"a": 1},
+			want:  map[string]interface{}{"a": 1},
+		},
+		{
+			name:  "local span",
+			ctx:   localCtx,
+			props: map[string]interface{}{"a": 1},
+			want: map[string]interface{}{
+				"a":              1,
+				types.TraceIDKey: local.SpanContext().TraceID.String(),
+				types.SpanIDKey:  local.SpanContext().SpanID.String(),
+			},
+		},
+		{
+			name: "remote parent without a local span",
+			ctx:  remoteCtx,
+			want: map[string]interface{}{
+				types.TraceIDKey: "4bf92f3577b34da6a3ce929d0e0e4736",
+				types.SpanIDKey:  "00f067aa0ba902b7",
+			},
+		},
+		{
+			name:  "existing keys are kept",
+			ctx:   localCtx,
+			props: map[string]interface{}{types.TraceIDKey: "mine"},
+			want: map[string]interface{}{
+				types.TraceIDKey: "mine",
+				types.SpanIDKey:  local.SpanContext().SpanID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := make(map[string]interface{}, len(tt.props))
+			for k, v := range tt.props {
+				before[k] = v
+			}
+
+			got := withTraceContext(tt.ctx, tt.props)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withTraceContext = %v, want %v", got, tt.want)
+			}
+			if tt.props != nil && !reflect.DeepEqual(tt.props, before) {
+				t.Errorf("caller's map changed to %v", tt.props)
+			}
+		})
+	}
+}
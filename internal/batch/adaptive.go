@@ -0,0 +1,100 @@
+// sdk-go/internal/batch/adaptive.go
+package batch
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	DefaultAdaptiveMinSize       = 10
+	DefaultAdaptiveMaxSize       = 1000
+	DefaultAdaptiveMinInterval   = 250 * time.Millisecond
+	DefaultAdaptiveMaxInterval   = 30 * time.Second
+	DefaultAdaptiveTargetLatency = 250 * time.Millisecond
+
+	// latencyWeight is the share of the newest sample in the smoothed latency
+	latencyWeight = 0.2
+)
+
+// WithAdaptive tunes the batch size and flush interval within bounds after every
+// send. Zero bounds use the defaults.
+func WithAdaptive[T any](bounds types.AdaptiveBatching) Option[T] {
+	if bounds.MinSize <= 0 {
+		bounds.MinSize = DefaultAdaptiveMinSize
+	}
+	if bounds.MaxSize <= 0 {
+		bounds.MaxSize = DefaultAdaptiveMaxSize
+	}
+	if bounds.MinInterval <= 0 {
+		bounds.MinInterval = DefaultAdaptiveMinInterval
+	}
+	if bounds.MaxInterval <= 0 {
+		bounds.MaxInterval = DefaultAdaptiveMaxInterval
+	}
+	if bounds.TargetLatency <= 0 {
+		bounds.TargetLatency = DefaultAdaptiveTargetLatency
+	}
+	bounds.MaxSize = max(bounds.MaxSize, bounds.MinSize)
+	bounds.MaxInterval = max(bounds.MaxInterval, bounds.MinInterval)
+
+	return func(m *Manager[T]) {
+		m.adaptive = &bounds
+		m.size.Store(int64(min(max(int(m.size.Load()), bounds.MinSize), bounds.MaxSize)))
+		m.interval = min(max(m.interval, bounds.MinInterval), bounds.MaxInterval)
+	}
+}
+
+// WithJitter randomly shortens or lengthens each flush interval by up to fraction
+func WithJitter[T any](fraction float64) Option[T] {
+	return func(m *Manager[T]) {
+		m.jitter = min(max(fraction, 0), 1)
+	}
+}
+
+// observe records the outcome of one send and, in adaptive mode, adjusts the batch
+// size and interval. depth is how many items were queued when the flush began.
+// Must be called with m.mu held.
+func (m *Manager[T]) observe(sent, depth int, latency time.Duration, err error) {
+	if m.latency == 0 {
+		m.latency = latency
+	} else {
+		m.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(m.latency))
+	}
+
+	a := m.adaptive
+	if a == nil {
+		return
+	}
+
+	size := int(m.size.Load())
+	switch {
+	case err != nil || m.latency > a.TargetLatency:
+		// Multiplicative decrease: smaller batches and fewer sends while the collector struggles
+		m.size.Store(int64(max(size/2, a.MinSize)))
+		m.interval = min(m.interval*2, a.MaxInterval)
+
+	case depth >= size:
+		// Additive increase: the queue is keeping up with full batches, so batch more
+		m.size.Store(int64(min(size+a.MinSize, a.MaxSize)))
+		m.interval = min(m.interval+a.MinInterval, a.MaxInterval)
+
+	case sent < size/4:
+		// Light traffic: flush sooner so sparse items are not held for long
+		m.interval = max(m.interval*3/4, a.MinInterval)
+	}
+}
+
+// nextInterval returns the wait before the next periodic flush, with jitter applied
+func (m *Manager[T]) nextInterval() time.Duration {
+	m.mu.RLock()
+	interval, jitter := m.interval, m.jitter
+	m.mu.RUnlock()
+
+	if jitter == 0 {
+		return interval
+	}
+	return time.Duration(float64(interval) * (1 + jitter*(2*rand.Float64()-1)))
+}
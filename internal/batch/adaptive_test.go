@@ -0,0 +1,207 @@
+// sdk-go/internal/batch/adaptive_test.go
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+var testBounds = types.AdaptiveBatching{
+	MinSize:       10,
+	MaxSize:       1000,
+	MinInterval:   250 * time.Millisecond,
+	MaxInterval:   30 * time.Second,
+	TargetLatency: 250 * time.Millisecond,
+}
+
+func newAdaptiveManager(t *testing.T, size int, interval time.Duration, bounds types.AdaptiveBatching) *Manager[int] {
+	t.Helper()
+	m := NewManager(size, interval, func(context.Context, []int) error { return nil }, WithAdaptive[int](bounds))
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func TestObserve(t *testing.T) {
+	errSend := errors.New("connection reset")
+	tests := []struct {
+		name         string
+		size         int
+		interval     time.Duration
+		sent, depth  int
+		latency      time.Duration
+		err          error
+		wantSize     int
+		wantInterval time.Duration
+	}{
+		{
+			name: "error halves the size and backs off",
+			size: 100, interval: time.Second, sent: 100, depth: 100, latency: 10 * time.Millisecond, err: errSend,
+			wantSize: 50, wantInterval: 2 * time.Second,
+		},
+		{
+			name: "slow send halves the size",
+			size: 100, interval: time.Second, sent: 100, depth: 100, latency: 500 * time.Millisecond,
+			wantSize: 50, wantInterval: 2 * time.Second,
+		},
+		{
+			name: "halving stops at MinSize",
+			size: 15, interval: time.Second, sent: 15, depth: 15, err: errSend,
+			wantSize: 10, wantInterval: 2 * time.Second,
+		},
+		{
+			name: "back-off stops at MaxInterval",
+			size: 100, interval: 20 * time.Second, sent: 100, depth: 100, err: errSend,
+			wantSize: 50, wantInterval: 30 * time.Second,
+		},
+		{
+			name: "full queue grows the size additively",
+			size: 100, interval: time.Second, sent: 100, depth: 150, latency: 10 * time.Millisecond,
+			wantSize: 110, wantInterval: 1250 * time.Millisecond,
+		},
+		{
+			name: "growth stops at MaxSize",
+			size: 995, interval: time.Second, sent: 995, depth: 995, latency: 10 * time.Millisecond,
+			wantSize: 1000, wantInterval: 1250 * time.Millisecond,
+		},
+		{
+			name: "light traffic shortens the interval",
+			size: 100, interval: time.Second, sent: 10, depth: 10, latency: 10 * time.Millisecond,
+			wantSize: 100, wantInterval: 750 * time.Millisecond,
+		},
+		{
+			name: "shortening stops at MinInterval",
+			size: 100, interval: 300 * time.Millisecond, sent: 10, depth: 10, latency: 10 * time.Millisecond,
+			wantSize: 100, wantInterval: 250 * time.Millisecond,
+		},
+		{
+			name: "steady traffic changes nothing",
+			size: 100, interval: time.Second, sent: 50, depth: 50, latency: 10 * time.Millisecond,
+			wantSize: 100, wantInterval: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdaptiveManager(t, tt.size, tt.interval, testBounds)
+			m.mu.Lock()
+			m.observe(tt.sent, tt.depth, tt.latency, tt.err)
+			m.mu.Unlock()
+
+			if got := m.BatchSize(); got != tt.wantSize {
+				t.Errorf("BatchSize = %d, want %d", got, tt.wantSize)
+			}
+			if got := m.FlushInterval(); got != tt.wantInterval {
+				t.Errorf("FlushInterval = %v, want %v", got, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestObserveSmoothsLatency(t *testing.T) {
+	m := NewManager(100, time.Second, func(context.Context, []int) error { return nil })
+	defer m.Close()
+
+	m.mu.Lock()
+	m.observe(10, 10, 100*time.Millisecond, nil)
+	m.observe(10, 10, 200*time.Millisecond, nil)
+	m.mu.Unlock()
+
+	if got := m.SendLatency(); got != 120*time.Millisecond {
+		t.Errorf("SendLatency = %v, want 120ms", got)
+	}
+	// Without adaptive bounds nothing is tuned
+	if m.BatchSize() != 100 || m.FlushInterval() != time.Second {
+		t.Errorf("size/interval = %d/%v, want them unchanged", m.BatchSize(), m.FlushInterval())
+	}
+}
+
+func TestWithAdaptiveBounds(t *testing.T) {
+	tests := []struct {
+		name         string
+		size         int
+		interval     time.Duration
+		bounds       types.AdaptiveBatching
+		want         types.AdaptiveBatching
+		wantSize     int
+		wantInterval time.Duration
+	}{
+		{
+			name: "defaults",
+			size: 100, interval: time.Second,
+			want: types.AdaptiveBatching{
+				MinSize: DefaultAdaptiveMinSize, MaxSize: DefaultAdaptiveMaxSize,
+				MinInterval: DefaultAdaptiveMinInterval, MaxInterval: DefaultAdaptiveMaxInterval,
+				TargetLatency: DefaultAdaptiveTargetLatency,
+			},
+			wantSize: 100, wantInterval: time.Second,
+		},
+		{
+			name: "starting values are clamped to the bounds",
+			size: 5000, interval: time.Millisecond,
+			bounds:   testBounds,
+			want:     testBounds,
+			wantSize: 1000, wantInterval: 250 * time.Millisecond,
+		},
+		{
+			name: "maximums are raised to the minimums",
+			size: 10, interval: time.Second,
+			bounds:   types.AdaptiveBatching{MinSize: 50, MaxSize: 20, MinInterval: 2 * time.Second, MaxInterval: time.Second},
+			want:     types.AdaptiveBatching{MinSize: 50, MaxSize: 50, MinInterval: 2 * time.Second, MaxInterval: 2 * time.Second, TargetLatency: DefaultAdaptiveTargetLatency},
+			wantSize: 50, wantInterval: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdaptiveManager(t, tt.size, tt.interval, tt.bounds)
+			if *m.adaptive != tt.want {
+				t.Errorf("bounds = %+v, want %+v", *m.adaptive, tt.want)
+			}
+			if m.BatchSize() != tt.wantSize || m.FlushInterval() != tt.wantInterval {
+				t.Errorf("size/interval = %d/%v, want %d/%v", m.BatchSize(), m.FlushInterval(), tt.wantSize, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestNextIntervalJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		fraction float64
+		want     float64 // Effective fraction
+	}{
+		{name: "none", fraction: 0, want: 0},
+		{name: "ten percent", fraction: 0.1, want: 0.1},
+		{name: "clamped above one", fraction: 2, want: 1},
+		{name: "clamped below zero", fraction: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := 10 * time.Second
+			m := NewManager(100, interval, func(context.Context, []int) error { return nil }, WithJitter[int](tt.fraction))
+			defer m.Close()
+
+			if m.jitter != tt.want {
+				t.Fatalf("jitter = %v, want %v", m.jitter, tt.want)
+			}
+			lo := time.Duration(float64(interval) * (1 - tt.want))
+			hi := time.Duration(float64(interval) * (1 + tt.want))
+			varied := false
+			for i := 0; i < 1000; i++ {
+				got := m.nextInterval()
+				if got < lo || got > hi {
+					t.Fatalf("nextInterval = %v, want within [%v, %v]", got, lo, hi)
+				}
+				varied = varied || got != interval
+			}
+			if varied != (tt.want > 0) {
+				t.Errorf("intervals varied = %v with jitter %v", varied, tt.want)
+			}
+		})
+	}
+}
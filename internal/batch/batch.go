@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/usercanal/sdk-go/internal/logger"
@@ -16,25 +17,90 @@ const (
 	defaultFlushInterval = 10 * time.Second
 )
 
-// SendFunc is the function type for sending items (generic)
-type SendFunc func(context.Context, []interface{}) error
+// SendFunc sends one batch. The slice is reused once the call returns, so it must
+// not be retained.
+type SendFunc[T any] func(context.Context, []T) error
 
-// Manager handles batching and sending of any type of items
-type Manager struct {
-	size         int
+// SizeFunc returns the encoded size of an item in bytes
+type SizeFunc[T any] func(T) int
+
+// Option configures a Manager
+type Option[T any] func(*Manager[T])
+
+// WithQueueLimit bounds the queue by item count and bytes; zero means
+// unbounded. policy decides what happens to items that do not fit.
+func WithQueueLimit[T any](maxItems, maxBytes int, policy types.OverflowPolicy) Option[T] {
+	return func(m *Manager[T]) {
+		m.maxItems = maxItems
+		m.maxBytes = maxBytes
+		m.overflow = policy
+	}
+}
+
+// WithMaxBatchBytes flushes once queued items reach maxBytes, as measured by the
+// size function, and keeps every sent batch within that size
+func WithMaxBatchBytes[T any](maxBytes int) Option[T] {
+	return func(m *Manager[T]) {
+		m.maxBatchBytes = maxBytes
+	}
+}
+
+// WithSizeFunc sets how item sizes are measured for byte limits and QueueBytes
+func WithSizeFunc[T any](size SizeFunc[T]) Option[T] {
+	return func(m *Manager[T]) {
+		m.sizeOf = size
+	}
+}
+
+// Manager handles batching and sending of items of type T
+type Manager[T any] struct {
+	size         atomic.Int64 // Items per batch; atomic for the lock-free Add path
 	interval     time.Duration
-	send         SendFunc
-	items        []interface{} // Changed from []*transport.Event to []interface{}
+	send         SendFunc[T]
+	items        []T
+	spare        []T // Emptied backing array of a sent batch, reused by the next swap
 	lastFlush    time.Time
 	lastFailure  time.Time
 	mu           sync.RWMutex
 	failedCount  int64
 	successCount int64
-	ticker       *time.Ticker
 	done         chan struct{}
+	wg           sync.WaitGroup
+
+	// Byte accounting and queue limits
+	sizeOf        SizeFunc[T]
+	maxBatchBytes int
+	maxItems      int
+	maxBytes      int
+	overflow      types.OverflowPolicy
+	bytes         int
+	droppedCount  int64
+	space         chan struct{} // Closed and replaced whenever items leave the queue
+
+	// Batches being sent, and Remove predicates applied to those batches if they
+	// fail and are re-queued. The predicates are kept until no send is in flight.
+	inflight   int
+	tombstones []func(T) bool
+
+	// Sharded ingestion, used when the queue is unbounded and there are several
+	// CPUs. added counts items put in the shards and drained those moved out;
+	// pendingBytes is the size of those not yet drained. flushPending is set by
+	// the producer that triggers a flush and cleared when the shards are drained.
+	shards       []shard[T]
+	added        atomic.Int64
+	drained      atomic.Int64
+	pendingBytes atomic.Int64
+	flushPending atomic.Bool
+	drainBuf     []entry[T]
+	slots        []entry[T]
+
+	// Flush timing and self-tuning
+	jitter   float64
+	adaptive *types.AdaptiveBatching
+	latency  time.Duration // Smoothed send latency
 }
 
-func NewManager(size int, interval time.Duration, send SendFunc) *Manager {
+func NewManager[T any](size int, interval time.Duration, send SendFunc[T], opts ...Option[T]) *Manager[T] {
 	if send == nil {
 		panic("send function cannot be nil")
 	}
@@ -49,40 +115,53 @@ func NewManager(size int, interval time.Duration, send SendFunc) *Manager {
 		interval = defaultFlushInterval
 	}
 
-	m := &Manager{
-		size:     size,
+	m := &Manager[T]{
 		interval: interval,
 		send:     send,
-		items:    make([]interface{}, 0, size), // Changed to interface{}
+		items:    make([]T, 0, size),
 		done:     make(chan struct{}),
-		ticker:   time.NewTicker(interval),
+		space:    make(chan struct{}),
+	}
+	m.size.Store(int64(size))
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	// Queue limits need a global view of the queue, so only unbounded queues
+	// take the sharded path
+	if m.maxItems <= 0 && m.maxBytes <= 0 {
+		m.shards = newShards[T]()
 	}
 
 	// Start periodic flush
+	m.wg.Add(1)
 	go m.periodicFlush()
 
 	return m
 }
 
-func (m *Manager) periodicFlush() {
+func (m *Manager[T]) periodicFlush() {
+	defer m.wg.Done()
+
+	// A timer rather than a ticker, since the interval can change between flushes
+	timer := time.NewTimer(m.nextInterval())
+	defer timer.Stop()
+
 	for {
 		select {
 		case <-m.done:
 			return
-		case <-m.ticker.C:
+		case <-timer.C:
 			if err := m.Flush(context.Background()); err != nil {
 				logger.Warn("Periodic flush failed: %v", err)
 			}
+			timer.Reset(m.nextInterval())
 		}
 	}
 }
 
-// Add accepts any type of item (interface{})
-func (m *Manager) Add(ctx context.Context, item interface{}) error {
-	if item == nil {
-		return types.NewValidationError("item", "cannot be nil")
-	}
-
+// Add queues an item, flushing if the batch is full
+func (m *Manager[T]) Add(ctx context.Context, item T) error {
 	select {
 	case <-ctx.Done():
 		return &types.TimeoutError{
@@ -90,96 +169,345 @@ func (m *Manager) Add(ctx context.Context, item interface{}) error {
 			Duration:  ctx.Err().Error(),
 		}
 	default:
-		m.mu.Lock()
-		m.items = append(m.items, item)
-		needsFlush := len(m.items) >= m.size
-		m.mu.Unlock()
+	}
+
+	size := m.itemSize(item)
+	if m.shards != nil {
+		return m.addSharded(ctx, item, size)
+	}
+
+	m.mu.Lock()
+	for !m.fits(size) {
+		switch m.overflow {
+		case types.OverflowDropNewest:
+			m.droppedCount++
+			m.mu.Unlock()
+			return types.ErrQueueFull
+
+		case types.OverflowBlock:
+			space := m.space
+			m.mu.Unlock()
+			select {
+			case <-space:
+			case <-ctx.Done():
+				return &types.TimeoutError{
+					Operation: "BatchAdd",
+					Duration:  ctx.Err().Error(),
+				}
+			case <-m.done:
+				return errClosed
+			}
+			// Close frees space with its final flush; don't queue behind it
+			if m.closed() {
+				return errClosed
+			}
+			m.mu.Lock()
 
-		if needsFlush {
-			return m.Flush(ctx)
+		default:
+			m.dropOldest()
 		}
+	}
+	m.items = append(m.items, item)
+	m.bytes += size
+	needsFlush := len(m.items) >= int(m.size.Load()) || (m.maxBatchBytes > 0 && m.bytes >= m.maxBatchBytes)
+	m.mu.Unlock()
 
-		return nil
+	if needsFlush {
+		return m.Flush(ctx)
+	}
+
+	return nil
+}
+
+// errClosed is returned to producers blocked on a full queue when the manager is closed
+var errClosed = types.NewValidationError("batch", "is closed")
+
+func (m *Manager[T]) closed() bool {
+	select {
+	case <-m.done:
+		return true
+	default:
+		return false
 	}
 }
 
-func (m *Manager) Flush(ctx context.Context) error {
+// fits reports whether an item of size can be queued. An empty queue always
+// accepts an item so that one oversized item cannot block the queue forever.
+// Must be called with m.mu held.
+func (m *Manager[T]) fits(size int) bool {
+	if len(m.items) == 0 {
+		return true
+	}
+	if m.maxItems > 0 && len(m.items) >= m.maxItems {
+		return false
+	}
+	return m.maxBytes <= 0 || m.bytes+size <= m.maxBytes
+}
+
+// dropOldest discards the item at the head of the queue. Must be called with m.mu held.
+func (m *Manager[T]) dropOldest() {
+	var zero T
+	m.bytes -= m.itemSize(m.items[0])
+	m.items[0] = zero
+	m.items = m.items[1:]
+	m.droppedCount++
+}
+
+// signalSpace wakes producers blocked on a full queue. Must be called with m.mu held.
+func (m *Manager[T]) signalSpace() {
+	close(m.space)
+	m.space = make(chan struct{})
+}
+
+func (m *Manager[T]) itemSize(item T) int {
+	if m.sizeOf == nil {
+		return 0
+	}
+	return m.sizeOf(item)
+}
+
+func (m *Manager[T]) Flush(ctx context.Context) error {
 	m.mu.Lock()
+	m.drainShards()
 	if len(m.items) == 0 {
 		m.mu.Unlock()
 		return nil
 	}
 
+	// Swap in the spare buffer so producers keep appending while this batch is sent
 	items := m.items
-	m.items = make([]interface{}, 0, m.size) // Changed to interface{}
+	depth := len(items)
+	m.items = m.spare[:0]
+	m.spare = nil
+	if m.items == nil {
+		m.items = make([]T, 0, m.size.Load())
+	}
+	m.bytes = 0
+	m.inflight++
+	m.signalSpace()
 	m.mu.Unlock()
+	defer m.recycle(items)
 
-	if err := m.send(ctx, items); err != nil {
-		m.mu.Lock()
-		m.failedCount += int64(len(items))
-		m.lastFailure = time.Now()
-		m.mu.Unlock()
+	// Items can build up beyond one batch after failures, so send in chunks that
+	// respect both the item count and the byte limit
+	for len(items) > 0 {
+		n := m.chunkLen(items)
+		chunk := items[:n]
 
-		// Re-queue items on failure if context isn't cancelled
-		select {
-		case <-ctx.Done():
-			return &types.TimeoutError{
-				Operation: "Flush",
-				Duration:  ctx.Err().Error(),
-			}
-		default:
+		start := time.Now()
+		if err := m.send(ctx, chunk); err != nil {
+			// Re-queue the failed chunk and every chunk not yet tried
 			m.mu.Lock()
-			m.items = append(m.items, items...)
+			m.failedCount += int64(len(items))
+			m.lastFailure = time.Now()
+			m.observe(len(chunk), depth, time.Since(start), err)
+			m.requeue(items)
 			m.mu.Unlock()
+
+			if ctx.Err() != nil {
+				return &types.TimeoutError{
+					Operation: "Flush",
+					Duration:  ctx.Err().Error(),
+				}
+			}
 			return &types.NetworkError{
 				Operation: "Flush",
 				Message:   err.Error(),
 			}
 		}
+
+		m.mu.Lock()
+		m.successCount += int64(len(chunk))
+		m.lastFlush = time.Now()
+		m.observe(len(chunk), depth, time.Since(start), nil)
+		m.mu.Unlock()
+
+		logger.Debug("Flushed %d items successfully", len(chunk))
+		items = items[n:]
+	}
+	return nil
+}
+
+// chunkLen returns how many leading items fit in one batch. At least one item is
+// always taken so that an item larger than the byte limit is still sent.
+func (m *Manager[T]) chunkLen(items []T) int {
+	size := int(m.size.Load())
+	if len(items) <= size && m.maxBatchBytes <= 0 {
+		return len(items)
+	}
+
+	bytes := 0
+	for i, item := range items {
+		if i == size {
+			return i
+		}
+		size := m.itemSize(item)
+		if m.maxBatchBytes > 0 && i > 0 && bytes+size > m.maxBatchBytes {
+			return i
+		}
+		bytes += size
 	}
+	return len(items)
+}
 
+// requeue returns failed items to the queue, except those matched by Remove while
+// they were being sent. Items beyond the queue limits are dropped, oldest first,
+// whatever the overflow policy, since nothing waits on them.
+// Must be called with m.mu held.
+func (m *Manager[T]) requeue(items []T) {
+	removed := 0
+	for _, item := range items {
+		if m.tombstoned(item) {
+			removed++
+			continue
+		}
+		m.bytes += m.itemSize(item)
+		m.items = append(m.items, item)
+	}
+	if removed > 0 {
+		logger.Debug("Dropped %d removed items from a failed batch", removed)
+	}
+	for len(m.items) > 1 && ((m.maxItems > 0 && len(m.items) > m.maxItems) || (m.maxBytes > 0 && m.bytes > m.maxBytes)) {
+		m.dropOldest()
+	}
+}
+
+// tombstoned reports whether Remove matched item while it was being sent.
+// Must be called with m.mu held.
+func (m *Manager[T]) tombstoned(item T) bool {
+	for _, match := range m.tombstones {
+		if match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// recycle ends a flush and keeps the backing array of its batch for the next
+// swap. Items are cleared first so the batch can be garbage collected.
+func (m *Manager[T]) recycle(items []T) {
+	clear(items[:cap(items)])
 	m.mu.Lock()
-	m.successCount += int64(len(items))
-	m.lastFlush = time.Now()
+	if m.spare == nil {
+		m.spare = items[:0]
+	}
+	m.inflight--
+	if m.inflight == 0 {
+		m.tombstones = nil
+	}
 	m.mu.Unlock()
+}
 
-	logger.Debug("Flushed %d items successfully", len(items))
-	return nil
+// Remove drops queued items for which match returns true and reports how many were dropped.
+// Items already handed to the send function cannot be recalled, but if their send
+// fails they are dropped instead of being re-queued.
+func (m *Manager[T]) Remove(match func(item T) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.inflight > 0 {
+		m.tombstones = append(m.tombstones, match)
+	}
+
+	m.drainShards()
+	kept := m.items[:0]
+	for _, item := range m.items {
+		if !match(item) {
+			kept = append(kept, item)
+		} else {
+			m.bytes -= m.itemSize(item)
+		}
+	}
+	removed := len(m.items) - len(kept)
+
+	// Clear the tail so dropped items can be garbage collected
+	clear(m.items[len(kept):])
+	m.items = kept
+	if removed > 0 {
+		m.signalSpace()
+	}
+
+	return removed
+}
+
+func (m *Manager[T]) QueueSize() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pending, _ := m.pendingCounts()
+	return int64(len(m.items)) + pending
+}
+
+// QueueBytes returns the payload bytes queued, as measured by the size function
+func (m *Manager[T]) QueueBytes() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, pending := m.pendingCounts()
+	return int64(m.bytes) + pending
+}
+
+// DroppedCount returns the number of items discarded by the overflow policy
+func (m *Manager[T]) DroppedCount() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.droppedCount
+}
+
+// MaxBatchBytes returns the byte size that triggers a flush, or zero if unlimited
+func (m *Manager[T]) MaxBatchBytes() int {
+	return m.maxBatchBytes
+}
+
+// BatchSize returns the number of items that currently triggers a flush
+func (m *Manager[T]) BatchSize() int {
+	return int(m.size.Load())
+}
+
+// FlushInterval returns the current time between periodic flushes, before jitter
+func (m *Manager[T]) FlushInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.interval
+}
+
+// Adaptive reports whether the batch size and interval tune themselves
+func (m *Manager[T]) Adaptive() bool {
+	return m.adaptive != nil
 }
 
-func (m *Manager) QueueSize() int64 {
+// SendLatency returns the smoothed time taken by one send
+func (m *Manager[T]) SendLatency() time.Duration {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return int64(len(m.items)) // Changed from events to items
+	return m.latency
 }
 
-func (m *Manager) FailedCount() int64 {
+func (m *Manager[T]) FailedCount() int64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.failedCount
 }
 
-func (m *Manager) SuccessCount() int64 {
+func (m *Manager[T]) SuccessCount() int64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.successCount
 }
 
-func (m *Manager) LastFlushTime() time.Time {
+func (m *Manager[T]) LastFlushTime() time.Time {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.lastFlush
 }
 
-func (m *Manager) LastFailureTime() time.Time {
+func (m *Manager[T]) LastFailureTime() time.Time {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.lastFailure
 }
 
-func (m *Manager) Close() error {
-	m.ticker.Stop()
+func (m *Manager[T]) Close() error {
 	close(m.done)
+	m.wg.Wait() // Let an in-flight periodic flush finish before the final one
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
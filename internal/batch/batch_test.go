@@ -0,0 +1,502 @@
+// sdk-go/internal/batch/batch_test.go
+package batch
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+type benchItem struct {
+	payload []byte
+}
+
+func BenchmarkManagerAdd(b *testing.B) {
+	var sent int
+	m := NewManager(1000, time.Hour, func(_ context.Context, items []*benchItem) error {
+		sent += len(items)
+		return nil
+	})
+	defer m.Close()
+
+	ctx := context.Background()
+	it := &benchItem{payload: make([]byte, 64)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := m.Add(ctx, it); err != nil {
+			b.Fatalf("Add: %v", err)
+		}
+	}
+}
+
+// Adding to a batch only stores the item in a reused slice
+func TestManagerAddAllocs(t *testing.T) {
+	m := NewManager(1000, time.Hour, func(context.Context, []*benchItem) error { return nil })
+	defer m.Close()
+
+	ctx := context.Background()
+	it := &benchItem{}
+	allocs := testing.AllocsPerRun(500, func() {
+		if err := m.Add(ctx, it); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("Add allocated %.1f times per item, want 0", allocs)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		name      string
+		queued    []int
+		match     func(int) bool
+		wantCount int
+		wantLeft  int64
+	}{
+		{name: "matching items", queued: []int{1, 2, 3, 4}, match: func(n int) bool { return n%2 == 0 }, wantCount: 2, wantLeft: 2},
+		{name: "nothing matches", queued: []int{1, 3}, match: func(n int) bool { return n%2 == 0 }, wantCount: 0, wantLeft: 2},
+		{name: "empty queue", match: func(int) bool { return true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(100, time.Hour, func(context.Context, []int) error { return nil })
+			defer m.Close()
+			for _, n := range tt.queued {
+				m.Add(context.Background(), n)
+			}
+
+			if got := m.Remove(tt.match); got != tt.wantCount {
+				t.Errorf("Remove = %d, want %d", got, tt.wantCount)
+			}
+			if got := m.QueueSize(); got != tt.wantLeft {
+				t.Errorf("QueueSize = %d, want %d", got, tt.wantLeft)
+			}
+		})
+	}
+}
+
+// Items matched by Remove while their batch is being sent are not re-queued if
+// the send fails
+func TestRemoveDuringFailedSend(t *testing.T) {
+	sending := make(chan struct{})
+	release := make(chan struct{})
+	fail := true
+	m := NewManager(100, time.Hour, func(context.Context, []int) error {
+		if !fail {
+			return nil
+		}
+		close(sending)
+		<-release
+		return errors.New("connection reset")
+	})
+	defer m.Close()
+
+	ctx := context.Background()
+	for _, n := range []int{1, 2, 3, 4} {
+		m.Add(ctx, n)
+	}
+
+	flushed := make(chan error)
+	go func() { flushed <- m.Flush(ctx) }()
+	<-sending
+
+	if got := m.Remove(func(n int) bool { return n%2 == 0 }); got != 0 {
+		t.Errorf("Remove = %d while the batch is in flight, want 0", got)
+	}
+	close(release)
+	if err := <-flushed; err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+
+	fail = false
+	var requeued []int
+	m.Remove(func(n int) bool {
+		requeued = append(requeued, n)
+		return true
+	})
+	if len(requeued) != 2 || requeued[0] != 1 || requeued[1] != 3 {
+		t.Errorf("re-queued %v, want [1 3]", requeued)
+	}
+	if len(m.tombstones) != 0 {
+		t.Errorf("%d predicates kept after the send ended", len(m.tombstones))
+	}
+}
+
+// intSize measures an int item as its own value, in bytes
+func intSize(n int) int { return n }
+
+// drain removes and returns everything queued in m
+func drain(m *Manager[int]) []int {
+	var items []int
+	m.Remove(func(n int) bool {
+		items = append(items, n)
+		return true
+	})
+	return items
+}
+
+func TestQueueLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxItems    int
+		maxBytes    int
+		policy      types.OverflowPolicy
+		adds        []int
+		wantErrs    int // Adds rejected with ErrQueueFull
+		wantQueue   []int
+		wantDropped int64
+	}{
+		{
+			name:     "drop oldest by count",
+			maxItems: 3, policy: types.OverflowDropOldest,
+			adds:      []int{1, 2, 3, 4, 5},
+			wantQueue: []int{3, 4, 5}, wantDropped: 2,
+		},
+		{
+			name:     "drop oldest by bytes",
+			maxBytes: 10, policy: types.OverflowDropOldest,
+			adds:      []int{4, 4, 4},
+			wantQueue: []int{4, 4}, wantDropped: 1,
+		},
+		{
+			name:     "drop oldest until a large item fits",
+			maxBytes: 10, policy: types.OverflowDropOldest,
+			adds:      []int{3, 3, 3, 9},
+			wantQueue: []int{9}, wantDropped: 3,
+		},
+		{
+			name:     "drop newest by count",
+			maxItems: 2, policy: types.OverflowDropNewest,
+			adds:     []int{1, 2, 3, 4},
+			wantErrs: 2, wantQueue: []int{1, 2}, wantDropped: 2,
+		},
+		{
+			name:     "drop newest by bytes",
+			maxBytes: 10, policy: types.OverflowDropNewest,
+			adds:     []int{6, 5, 4},
+			wantErrs: 1, wantQueue: []int{6, 4}, wantDropped: 1,
+		},
+		{
+			name:     "an empty queue takes an oversized item",
+			maxBytes: 5, policy: types.OverflowDropNewest,
+			adds:      []int{9},
+			wantQueue: []int{9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(100, time.Hour, func(context.Context, []int) error { return nil },
+				WithSizeFunc(intSize), WithQueueLimit[int](tt.maxItems, tt.maxBytes, tt.policy))
+			defer m.Close()
+
+			errs := 0
+			for _, n := range tt.adds {
+				if err := m.Add(context.Background(), n); errors.Is(err, types.ErrQueueFull) {
+					errs++
+				} else if err != nil {
+					t.Fatalf("Add(%d): %v", n, err)
+				}
+			}
+			if errs != tt.wantErrs {
+				t.Errorf("%d adds rejected, want %d", errs, tt.wantErrs)
+			}
+			if got := m.DroppedCount(); got != tt.wantDropped {
+				t.Errorf("DroppedCount = %d, want %d", got, tt.wantDropped)
+			}
+			wantBytes := 0
+			for _, n := range tt.wantQueue {
+				wantBytes += n
+			}
+			if got := m.QueueBytes(); got != int64(wantBytes) {
+				t.Errorf("QueueBytes = %d, want %d", got, wantBytes)
+			}
+			if got := drain(m); !slices.Equal(got, tt.wantQueue) {
+				t.Errorf("queue = %v, want %v", got, tt.wantQueue)
+			}
+			if got := m.QueueBytes(); got != 0 {
+				t.Errorf("QueueBytes = %d after removing everything", got)
+			}
+		})
+	}
+}
+
+func TestQueueLimitBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		unblock func(m *Manager[int], cancel context.CancelFunc)
+		wantErr func(error) bool
+		wantQ   []int // Queue after the blocked Add returns; nil skips the check
+	}{
+		{
+			name:    "flush makes room",
+			unblock: func(m *Manager[int], _ context.CancelFunc) { m.Flush(context.Background()) },
+			wantErr: func(err error) bool { return err == nil },
+			wantQ:   []int{2},
+		},
+		{
+			name:    "remove makes room",
+			unblock: func(m *Manager[int], _ context.CancelFunc) { m.Remove(func(n int) bool { return n == 1 }) },
+			wantErr: func(err error) bool { return err == nil },
+			wantQ:   []int{2},
+		},
+		{
+			name:    "context is cancelled",
+			unblock: func(_ *Manager[int], cancel context.CancelFunc) { cancel() },
+			wantErr: func(err error) bool {
+				var timeout *types.TimeoutError
+				return errors.As(err, &timeout)
+			},
+			wantQ: []int{1},
+		},
+		{
+			name:    "manager is closed",
+			unblock: func(m *Manager[int], _ context.CancelFunc) { m.Close() },
+			wantErr: func(err error) bool {
+				var verr *types.ValidationError
+				return errors.As(err, &verr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(100, time.Hour, func(context.Context, []int) error { return nil },
+				WithQueueLimit[int](1, 0, types.OverflowBlock))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if err := m.Add(ctx, 1); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			added := make(chan error, 1)
+			go func() { added <- m.Add(ctx, 2) }()
+
+			select {
+			case err := <-added:
+				t.Fatalf("Add returned %v on a full queue, want it to block", err)
+			case <-time.After(20 * time.Millisecond):
+			}
+
+			tt.unblock(m, cancel)
+			select {
+			case err := <-added:
+				if !tt.wantErr(err) {
+					t.Errorf("blocked Add returned %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Add stayed blocked")
+			}
+
+			if tt.wantQ != nil {
+				if got := drain(m); !slices.Equal(got, tt.wantQ) {
+					t.Errorf("queue = %v, want %v", got, tt.wantQ)
+				}
+				m.Close()
+			}
+		})
+	}
+}
+
+// Failed batches are re-queued only as far as the queue limits allow
+func TestRequeueTrimsToLimits(t *testing.T) {
+	sending := make(chan struct{})
+	release := make(chan struct{})
+	m := NewManager(100, time.Hour, func(context.Context, []int) error {
+		close(sending)
+		<-release
+		return errors.New("connection reset")
+	}, WithSizeFunc(intSize), WithQueueLimit[int](3, 0, types.OverflowDropNewest))
+	defer m.Close()
+
+	ctx := context.Background()
+	for _, n := range []int{1, 2, 3} {
+		m.Add(ctx, n)
+	}
+	flushed := make(chan error)
+	go func() { flushed <- m.Flush(ctx) }()
+	<-sending
+
+	// The queue is empty while its batch is in flight
+	m.Add(ctx, 4)
+	m.Add(ctx, 5)
+	close(release)
+	if err := <-flushed; err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+
+	if got := m.QueueSize(); got != 3 {
+		t.Errorf("QueueSize = %d after re-queueing, want the limit of 3", got)
+	}
+	if got := m.DroppedCount(); got != 2 {
+		t.Errorf("DroppedCount = %d, want 2", got)
+	}
+	queuedBytes := m.QueueBytes()
+	queued := drain(m)
+	sum := 0
+	for _, n := range queued {
+		sum += n
+	}
+	if len(queued) != 3 || queuedBytes != int64(sum) {
+		t.Errorf("queue = %v with QueueBytes %d, want 3 items and their total size", queued, queuedBytes)
+	}
+}
+
+// newLockedManager creates a manager on the locked path, whatever the machine
+func newLockedManager(t *testing.T, size int, send SendFunc[int], opts ...Option[int]) *Manager[int] {
+	t.Helper()
+	prev := runtime.GOMAXPROCS(1)
+	m := NewManager(size, time.Hour, send, opts...)
+	runtime.GOMAXPROCS(prev)
+	if m.shards != nil {
+		t.Fatal("expected locked ingestion")
+	}
+	return m
+}
+
+func TestChunkLen(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		maxBytes int
+		items    []int
+		want     int
+	}{
+		{name: "fits in one batch", size: 10, items: []int{1, 2, 3}, want: 3},
+		{name: "split by item count", size: 2, items: []int{1, 2, 3, 4, 5}, want: 2},
+		{name: "split by bytes", size: 10, maxBytes: 10, items: []int{4, 4, 4, 4}, want: 2},
+		{name: "bytes exactly at the limit", size: 10, maxBytes: 8, items: []int{4, 4, 4}, want: 2},
+		{name: "count reached before bytes", size: 2, maxBytes: 100, items: []int{1, 1, 1}, want: 2},
+		{name: "oversized first item is sent alone", size: 10, maxBytes: 10, items: []int{50, 1, 1}, want: 1},
+		{name: "oversized later item starts a new batch", size: 10, maxBytes: 10, items: []int{1, 50, 1}, want: 1},
+		{name: "everything fits under both limits", size: 10, maxBytes: 100, items: []int{10, 20, 30}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option[int]{WithSizeFunc(intSize)}
+			if tt.maxBytes > 0 {
+				opts = append(opts, WithMaxBatchBytes[int](tt.maxBytes))
+			}
+			m := NewManager(tt.size, time.Hour, func(context.Context, []int) error { return nil }, opts...)
+			defer m.Close()
+
+			if got := m.chunkLen(tt.items); got != tt.want {
+				t.Errorf("chunkLen(%v) = %d, want %d", tt.items, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushSendsInChunks(t *testing.T) {
+	var batches [][]int
+	m := NewManager(100, time.Hour, func(_ context.Context, items []int) error {
+		batches = append(batches, append([]int(nil), items...))
+		return nil
+	}, WithSizeFunc(intSize), WithMaxBatchBytes[int](10))
+	defer m.Close()
+
+	// Queue more than one batch's worth, as builds up after failed sends
+	m.items = append(m.items, 4, 4, 4, 20, 1, 1)
+	m.bytes = 34
+	m.size.Store(2)
+
+	if err := m.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	want := [][]int{{4, 4}, {4}, {20}, {1, 1}}
+	if !slices.EqualFunc(batches, want, slices.Equal) {
+		t.Errorf("sent %v, want %v", batches, want)
+	}
+	if got := m.SuccessCount(); got != 6 {
+		t.Errorf("SuccessCount = %d, want 6", got)
+	}
+}
+
+func TestAddFlushesOnBatchBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		adds      []int
+		wantSent  int
+		wantQueue int64
+	}{
+		{name: "below the byte limit", adds: []int{3, 3, 3}, wantSent: 0, wantQueue: 3},
+		{name: "reaching the byte limit", adds: []int{5, 5}, wantSent: 2, wantQueue: 0},
+		{name: "passing the byte limit", adds: []int{4, 4, 4}, wantSent: 3, wantQueue: 0},
+		{name: "one oversized item", adds: []int{50}, wantSent: 1, wantQueue: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := 0
+			m := newLockedManager(t, 100, func(_ context.Context, items []int) error {
+				sent += len(items)
+				return nil
+			}, WithSizeFunc(intSize), WithMaxBatchBytes[int](10))
+			defer m.Close()
+
+			for _, n := range tt.adds {
+				if err := m.Add(context.Background(), n); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+			if sent != tt.wantSent {
+				t.Errorf("sent %d items, want %d", sent, tt.wantSent)
+			}
+			if got := m.QueueSize(); got != tt.wantQueue {
+				t.Errorf("QueueSize = %d, want %d", got, tt.wantQueue)
+			}
+		})
+	}
+}
+
+// A flush cut short by its context puts every unsent item back on the queue
+func TestFlushCancelledRequeues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sent []int
+	calls := 0
+	m := NewManager(100, time.Hour, func(_ context.Context, items []int) error {
+		calls++
+		if calls == 2 {
+			cancel()
+			return context.Canceled
+		}
+		sent = append(sent, items...)
+		return nil
+	})
+	defer m.Close()
+
+	for n := 1; n <= 5; n++ {
+		m.Add(context.Background(), n)
+	}
+	m.size.Store(2) // Three chunks: [1 2] [3 4] [5]
+
+	err := m.Flush(ctx)
+	var timeout *types.TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("Flush = %v, want a TimeoutError", err)
+	}
+	if !slices.Equal(sent, []int{1, 2}) {
+		t.Errorf("sent %v, want [1 2]", sent)
+	}
+	if got := drain(m); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("re-queued %v, want [3 4 5]", got)
+	}
+	if got := m.FailedCount(); got != 3 {
+		t.Errorf("FailedCount = %d, want 3", got)
+	}
+	if got := m.SuccessCount(); got != 2 {
+		t.Errorf("SuccessCount = %d, want 2", got)
+	}
+}
@@ -0,0 +1,148 @@
+// sdk-go/internal/batch/shard.go
+package batch
+
+import (
+	"cmp"
+	"context"
+	"math/bits"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// maxShards bounds the ingestion shards created on machines with many CPUs
+const maxShards = 64
+
+// shard is one producer-side buffer. Producers spread over the shards so that
+// concurrent Adds rarely wait on the same lock.
+type shard[T any] struct {
+	mu      sync.Mutex
+	entries []entry[T]
+	_       [32]byte // Pad to a cache line so neighbouring shards don't contend
+}
+
+// entry is an item stamped with its position in the order items were added
+type entry[T any] struct {
+	seq  int64
+	item T
+	size int
+}
+
+// newShards returns a power-of-two number of shards, about one per CPU, or nil
+// when there is only one CPU and so nothing to contend
+func newShards[T any]() []shard[T] {
+	n := min(runtime.GOMAXPROCS(0), maxShards)
+	if n < 2 {
+		return nil
+	}
+	return make([]shard[T], 1<<bits.Len(uint(n-1)))
+}
+
+// addSharded queues an item without taking the manager lock. The producer whose
+// item fills a batch flushes it, as on the locked path.
+func (m *Manager[T]) addSharded(ctx context.Context, item T, size int) error {
+	// One counter both orders items and, with drained, counts those not yet drained
+	seq := m.added.Add(1)
+	bytes := m.pendingBytes.Add(int64(size))
+
+	// The global generator is per-thread, so picking a shard is contention-free
+	sh := &m.shards[rand.Uint32()&uint32(len(m.shards)-1)]
+	sh.mu.Lock()
+	sh.entries = append(sh.entries, entry[T]{seq: seq, item: item, size: size})
+	sh.mu.Unlock()
+
+	// Only the first producer past a threshold flushes until the shards are
+	// drained, so a slow send does not pile up concurrent flushes. The batch size
+	// may change between Adds in adaptive mode, hence a threshold, not a multiple.
+	pending := seq - m.drained.Load()
+	full := pending >= m.size.Load() || (m.maxBatchBytes > 0 && bytes >= int64(m.maxBatchBytes))
+	if full && m.flushPending.CompareAndSwap(false, true) {
+		return m.Flush(ctx)
+	}
+	return nil
+}
+
+// drainShards moves shard contents into the main queue in the order they were
+// added, so items from each producer keep their order. Must be called with m.mu held.
+func (m *Manager[T]) drainShards() {
+	if m.shards == nil {
+		return
+	}
+	// Let the next producer past a threshold flush, even if another flush got here first
+	m.flushPending.Store(false)
+	if m.added.Load() == m.drained.Load() {
+		return
+	}
+
+	// Hold every shard at once: a producer's later item can then only be taken
+	// together with its earlier ones
+	for i := range m.shards {
+		m.shards[i].mu.Lock()
+	}
+	drain := m.drainBuf[:0]
+	lo, hi := int64(0), int64(0)
+	for i := range m.shards {
+		sh := &m.shards[i]
+		for _, e := range sh.entries {
+			if lo == 0 || e.seq < lo {
+				lo = e.seq
+			}
+			hi = max(hi, e.seq)
+		}
+		drain = append(drain, sh.entries...)
+		clear(sh.entries)
+		sh.entries = sh.entries[:0]
+	}
+	for i := range m.shards {
+		m.shards[i].mu.Unlock()
+	}
+
+	drainedBytes := 0
+	for _, e := range m.inOrder(drain, lo, hi) {
+		m.items = append(m.items, e.item)
+		drainedBytes += e.size
+	}
+	m.bytes += drainedBytes
+	m.pendingBytes.Add(-int64(drainedBytes))
+	m.drained.Add(int64(len(drain)))
+
+	clear(drain)
+	m.drainBuf = drain[:0]
+}
+
+// inOrder returns entries sorted by seq. Drained sequence numbers are nearly
+// contiguous, so entries are placed by seq in linear time, with a sort as the
+// fallback for sparse ranges. Must be called with m.mu held.
+func (m *Manager[T]) inOrder(entries []entry[T], lo, hi int64) []entry[T] {
+	span := int(hi - lo + 1)
+	if span > 2*len(entries) {
+		slices.SortFunc(entries, func(a, b entry[T]) int { return cmp.Compare(a.seq, b.seq) })
+		return entries
+	}
+
+	if cap(m.slots) < span {
+		m.slots = make([]entry[T], span)
+	}
+	slots := m.slots[:span]
+	for _, e := range entries {
+		slots[e.seq-lo] = e
+	}
+	ordered := entries[:0]
+	for i := range slots {
+		if slots[i].seq != 0 {
+			ordered = append(ordered, slots[i])
+		}
+	}
+	clear(slots)
+	return ordered
+}
+
+// pendingCounts returns the items and bytes waiting in the shards. Producers
+// count an item just before storing it, so the totals may briefly run ahead.
+func (m *Manager[T]) pendingCounts() (items, bytes int64) {
+	if m.shards == nil {
+		return 0, 0
+	}
+	return m.added.Load() - m.drained.Load(), m.pendingBytes.Load()
+}
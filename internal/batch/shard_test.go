@@ -0,0 +1,244 @@
+// sdk-go/internal/batch/shard_test.go
+package batch
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// item identifies the n-th item added by a producer
+type item struct {
+	producer int
+	n        int
+}
+
+// recorder is a send function that keeps every batch it is given
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]item
+}
+
+func (r *recorder) send(_ context.Context, items []item) error {
+	batch := append([]item(nil), items...)
+	r.mu.Lock()
+	r.batches = append(r.batches, batch)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *recorder) items() []item {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var all []item
+	for _, b := range r.batches {
+		all = append(all, b...)
+	}
+	return all
+}
+
+// newShardedManager creates a manager on the sharded path, whatever the machine
+func newShardedManager(t *testing.T, size int, send SendFunc[item], opts ...Option[item]) *Manager[item] {
+	t.Helper()
+	prev := runtime.GOMAXPROCS(8)
+	m := NewManager(size, time.Hour, send, opts...)
+	runtime.GOMAXPROCS(prev)
+	if m.shards == nil {
+		t.Fatal("expected sharded ingestion")
+	}
+	return m
+}
+
+func TestShardedAddKeepsProducerOrder(t *testing.T) {
+	const producers, perProducer = 8, 2000
+
+	rec := &recorder{}
+	// Large batches so only the single flusher below sends, keeping batches in order
+	m := newShardedManager(t, 1_000_000, rec.send)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for n := 0; n < perProducer; n++ {
+				if err := m.Add(context.Background(), item{p, n}); err != nil {
+					t.Errorf("Add: %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	stop := make(chan struct{})
+	flusherDone := make(chan struct{})
+	go func() {
+		defer close(flusherDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if err := m.Flush(context.Background()); err != nil {
+					t.Errorf("Flush: %v", err)
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	<-flusherDone
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	all := rec.items()
+	if len(all) != producers*perProducer {
+		t.Fatalf("sent %d items, want %d", len(all), producers*perProducer)
+	}
+	next := make([]int, producers)
+	for _, it := range all {
+		if it.n != next[it.producer] {
+			t.Fatalf("producer %d: got item %d, want %d", it.producer, it.n, next[it.producer])
+		}
+		next[it.producer]++
+	}
+}
+
+func TestShardedConcurrentAddFlushRemoveClose(t *testing.T) {
+	const producers, perProducer = 8, 2000
+
+	rec := &recorder{}
+	m := newShardedManager(t, 50, rec.send)
+
+	var removed atomic.Int64
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for n := 0; n < perProducer; n++ {
+				if err := m.Add(context.Background(), item{p, n}); err != nil {
+					t.Errorf("Add: %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	// Flushes and removals race with the producers and, at the end, with Close
+	stop := make(chan struct{})
+	var background sync.WaitGroup
+	background.Add(2)
+	go func() {
+		defer background.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Flush(context.Background())
+			}
+		}
+	}()
+	go func() {
+		defer background.Done()
+		for n := 0; ; n++ {
+			select {
+			case <-stop:
+				return
+			default:
+				producer := n % producers
+				removed.Add(int64(m.Remove(func(it item) bool {
+					return it.producer == producer && it.n%7 == 0
+				})))
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	wg.Wait()
+	closed := make(chan error)
+	go func() { closed <- m.Close() }()
+	time.Sleep(time.Millisecond)
+	close(stop)
+	background.Wait()
+	if err := <-closed; err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	m.Flush(context.Background())
+
+	all := rec.items()
+	if got, want := int64(len(all))+removed.Load(), int64(producers*perProducer); got != want {
+		t.Fatalf("sent %d + removed %d = %d items, want %d", len(all), removed.Load(), got, want)
+	}
+	seen := make(map[item]bool, len(all))
+	for _, it := range all {
+		if seen[it] {
+			t.Fatalf("item %+v sent twice", it)
+		}
+		seen[it] = true
+	}
+	if size := m.QueueSize(); size != 0 {
+		t.Fatalf("queue holds %d items after close", size)
+	}
+
+	// Within one batch, each producer's items are in the order they were added
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, batch := range rec.batches {
+		last := make(map[int]int)
+		for _, it := range batch {
+			if prev, ok := last[it.producer]; ok && it.n <= prev {
+				t.Fatalf("producer %d: item %d follows %d in one batch", it.producer, it.n, prev)
+			}
+			last[it.producer] = it.n
+		}
+	}
+}
+
+func TestShardedFlushesWhenBatchSizeShrinks(t *testing.T) {
+	rec := &recorder{}
+	m := newShardedManager(t, 10, rec.send)
+	defer m.Close()
+
+	ctx := context.Background()
+	for n := 0; n < 4; n++ {
+		m.Add(ctx, item{0, n})
+	}
+
+	// As adaptive batching does; 5 queued items are past the new size but not a multiple of it
+	m.size.Store(3)
+	m.Add(ctx, item{0, 4})
+
+	if got := len(rec.items()); got != 5 {
+		t.Fatalf("sent %d items after the batch size shrank, want 5", got)
+	}
+}
+
+func TestShardedFlushesOnTotalBytes(t *testing.T) {
+	rec := &recorder{}
+	size := func(item) int { return 300 }
+	m := newShardedManager(t, 1000, rec.send, WithSizeFunc(size), WithMaxBatchBytes[item](1000))
+	defer m.Close()
+
+	ctx := context.Background()
+	for n := 0; n < 3; n++ {
+		m.Add(ctx, item{0, n})
+	}
+	if got := len(rec.items()); got != 0 {
+		t.Fatalf("sent %d items below the byte limit", got)
+	}
+
+	m.Add(ctx, item{0, 3})
+	if got := len(rec.items()); got != 4 {
+		t.Fatalf("sent %d items once 1200 bytes were queued, want 4", got)
+	}
+	if bytes := m.QueueBytes(); bytes != 0 {
+		t.Fatalf("%d bytes still queued after the flush", bytes)
+	}
+}
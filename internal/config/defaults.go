@@ -16,6 +16,12 @@ const (
 	// DefaultMaxRetries is the default number of retry attempts
 	DefaultMaxRetries = 3
 	
+	// DefaultMetricsInterval is the default aggregation window for metric instruments
+	DefaultMetricsInterval = 10 * time.Second
+	
+	// DefaultPriorityWindow is the default flush window of the priority lane
+	DefaultPriorityWindow = 100 * time.Millisecond
+	
 	// DefaultCloseTimeout is the default timeout for graceful shutdown
 	DefaultCloseTimeout = 5 * time.Second
 	
@@ -27,11 +33,13 @@ const (
 // This is useful for documentation and testing
 func Defaults() map[string]interface{} {
 	return map[string]interface{}{
-		"endpoint":       DefaultEndpoint,
-		"batch_size":     DefaultBatchSize,
-		"flush_interval": DefaultFlushInterval,
-		"max_retries":    DefaultMaxRetries,
-		"close_timeout":  DefaultCloseTimeout,
-		"debug":          DefaultDebug,
+		"endpoint":         DefaultEndpoint,
+		"batch_size":       DefaultBatchSize,
+		"flush_interval":   DefaultFlushInterval,
+		"max_retries":      DefaultMaxRetries,
+		"metrics_interval": DefaultMetricsInterval,
+		"priority_window":  DefaultPriorityWindow,
+		"close_timeout":    DefaultCloseTimeout,
+		"debug":            DefaultDebug,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+// sdk-go/internal/consent/consent.go
+package consent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Memory is an in-memory consent store
+type Memory struct {
+	defaultGranted bool
+	mu             sync.RWMutex
+	users          map[string]map[types.ConsentCategory]bool
+}
+
+// NewMemory creates a store; defaultGranted applies to users and categories never set
+func NewMemory(defaultGranted bool) *Memory {
+	return &Memory{
+		defaultGranted: defaultGranted,
+		users:          make(map[string]map[types.ConsentCategory]bool),
+	}
+}
+
+func (m *Memory) HasConsent(ctx context.Context, userID string, category types.ConsentCategory) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if granted, ok := m.users[userID][category]; ok {
+		return granted, nil
+	}
+	return m.defaultGranted, nil
+}
+
+func (m *Memory) SetConsent(ctx context.Context, userID string, category types.ConsentCategory, granted bool) error {
+	if userID == "" {
+		return types.NewValidationError("userID", "is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	categories, ok := m.users[userID]
+	if !ok {
+		categories = make(map[types.ConsentCategory]bool)
+		m.users[userID] = categories
+	}
+	categories[category] = granted
+	return nil
+}
+
+// Forget removes all consent records for a user
+func (m *Memory) Forget(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.users, userID)
+}
+
+// Func adapts a callback, e.g. a lookup in your own consent service, to a consent store
+type Func func(ctx context.Context, userID string, category types.ConsentCategory) (bool, error)
+
+func (f Func) HasConsent(ctx context.Context, userID string, category types.ConsentCategory) (bool, error) {
+	return f(ctx, userID, category)
+}
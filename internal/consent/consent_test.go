@@ -0,0 +1,103 @@
+// sdk-go/internal/consent/consent_test.go
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestMemory(t *testing.T) {
+	type grant struct {
+		user     string
+		category types.ConsentCategory
+		granted  bool
+	}
+	tests := []struct {
+		name           string
+		defaultGranted bool
+		grants         []grant
+		forget         string
+		user           string
+		category       types.ConsentCategory
+		want           bool
+	}{
+		{name: "default denied", user: "u1", category: types.ConsentAnalytics, want: false},
+		{name: "default granted", defaultGranted: true, user: "u1", category: types.ConsentAnalytics, want: true},
+		{
+			name:     "explicit grant",
+			grants:   []grant{{"u1", types.ConsentAnalytics, true}},
+			user:     "u1",
+			category: types.ConsentAnalytics,
+			want:     true,
+		},
+		{
+			name:           "explicit opt-out overrides default",
+			defaultGranted: true,
+			grants:         []grant{{"u1", types.ConsentMarketing, false}},
+			user:           "u1",
+			category:       types.ConsentMarketing,
+			want:           false,
+		},
+		{
+			name:     "categories are independent",
+			grants:   []grant{{"u1", types.ConsentAnalytics, true}},
+			user:     "u1",
+			category: types.ConsentMarketing,
+			want:     false,
+		},
+		{
+			name:     "users are independent",
+			grants:   []grant{{"u1", types.ConsentAnalytics, true}},
+			user:     "u2",
+			category: types.ConsentAnalytics,
+			want:     false,
+		},
+		{
+			name:     "latest change wins",
+			grants:   []grant{{"u1", types.ConsentAnalytics, true}, {"u1", types.ConsentAnalytics, false}},
+			user:     "u1",
+			category: types.ConsentAnalytics,
+			want:     false,
+		},
+		{
+			name:           "forget restores the default",
+			defaultGranted: true,
+			grants:         []grant{{"u1", types.ConsentAnalytics, false}},
+			forget:         "u1",
+			user:           "u1",
+			category:       types.ConsentAnalytics,
+			want:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewMemory(tt.defaultGranted)
+			for _, g := range tt.grants {
+				if err := m.SetConsent(ctx, g.user, g.category, g.granted); err != nil {
+					t.Fatalf("SetConsent: %v", err)
+				}
+			}
+			if tt.forget != "" {
+				m.Forget(tt.forget)
+			}
+
+			got, err := m.HasConsent(ctx, tt.user, tt.category)
+			if err != nil {
+				t.Fatalf("HasConsent: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasConsent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryRequiresUser(t *testing.T) {
+	if err := NewMemory(false).SetConsent(context.Background(), "", types.ConsentAnalytics, true); err == nil {
+		t.Fatal("expected error for an empty user ID")
+	}
+}
@@ -2,19 +2,73 @@
 package convert
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/usercanal/sdk-go/internal/encrypt"
 	"github.com/usercanal/sdk-go/types"
 )
 
+// Converter turns public SDK types into transport structures and applies
+// client-level payload processing, such as field encryption, on the way
+type Converter struct {
+	encryptor *encrypt.Encryptor
+}
+
+// Option configures a Converter
+type Option func(*Converter)
+
+// WithEncryptor encrypts the configured property paths of every payload
+func WithEncryptor(e *encrypt.Encryptor) Option {
+	return func(c *Converter) {
+		c.encryptor = e
+	}
+}
+
+// NewConverter creates a converter with the given options
+func NewConverter(opts ...Option) *Converter {
+	c := &Converter{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// protect encrypts sensitive fields of user-supplied data before it is embedded in a payload
+func (c *Converter) protect(data map[string]interface{}) (map[string]interface{}, error) {
+	if c.encryptor == nil || len(data) == 0 {
+		return data, nil
+	}
+	protected, err := c.encryptor.Apply(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt payload fields: %w", err)
+	}
+	return protected, nil
+}
+
 // Common payload marshaling utilities
 func marshalPayload(data map[string]interface{}) ([]byte, error) {
+	// Normalized properties are encoded into a pooled buffer; anything else
+	// goes through encoding/json
+	bufp := payloadPool.Get().(*[]byte)
+	buf, ok := appendJSONObject((*bufp)[:0], data)
+	var payload []byte
+	if ok {
+		payload = bytes.Clone(buf)
+	}
+	if cap(buf) <= maxPooledPayloadSize {
+		*bufp = buf[:0]
+		payloadPool.Put(bufp)
+	}
+	if ok {
+		return payload, nil
+	}
+
 	if data == nil {
 		data = make(map[string]interface{})
 	}
-
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
@@ -22,6 +76,32 @@ func marshalPayload(data map[string]interface{}) ([]byte, error) {
 	return payload, nil
 }
 
+// marshalLimited marshals a payload and rejects it if it is larger than limit, so an
+// oversized item fails on its own instead of failing every batch it is queued in
+func marshalLimited(data map[string]interface{}, limit int) ([]byte, error) {
+	payload, err := marshalPayload(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > limit {
+		return nil, types.NewValidationError("payload", fmt.Sprintf("size %d exceeds limit %d bytes", len(payload), limit))
+	}
+	return payload, nil
+}
+
+// withEventID returns data with a caller-supplied event ID added, leaving data unchanged
+func withEventID(data map[string]interface{}, id string) map[string]interface{} {
+	if id == "" {
+		return data
+	}
+	out := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		out[k] = v
+	}
+	out[types.EventIDKey] = id
+	return out
+}
+
 // Common timestamp handling
 func resolveTimestamp(t time.Time) uint64 {
 	if t.IsZero() {
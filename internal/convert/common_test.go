@@ -0,0 +1,113 @@
+// sdk-go/internal/convert/common_test.go
+package convert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestMarshalLimited(t *testing.T) {
+	// {"s":"..."} is 8 bytes plus the string
+	tests := []struct {
+		name    string
+		value   string
+		limit   int
+		wantErr bool
+	}{
+		{name: "under the limit", value: "abc", limit: 20},
+		{name: "at the limit", value: strings.Repeat("a", 12), limit: 20},
+		{name: "over the limit", value: strings.Repeat("a", 13), limit: 20, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := marshalLimited(map[string]interface{}{"s": tt.value}, tt.limit)
+			if !tt.wantErr {
+				if err != nil || len(payload) > tt.limit {
+					t.Errorf("marshalLimited = %d bytes, %v", len(payload), err)
+				}
+				return
+			}
+			var verr *types.ValidationError
+			if !errors.As(err, &verr) || payload != nil {
+				t.Errorf("marshalLimited = %q, %v; want a ValidationError", payload, err)
+			}
+		})
+	}
+}
+
+func TestPayloadSizeLimits(t *testing.T) {
+	// The JSON framing of each payload keeps it just past the limit
+	oversized := func(limit int) string { return strings.Repeat("a", limit) }
+
+	tests := []struct {
+		name    string
+		convert func() error
+		wantErr bool
+	}{
+		{
+			name: "event within the limit",
+			convert: func() error {
+				_, err := NewConverter().EventToInternal(&types.Event{UserId: "u1", Name: types.FeatureUsed, Properties: types.Properties{"s": "v"}})
+				return err
+			},
+		},
+		{
+			name: "event over MaxEventSize",
+			convert: func() error {
+				_, err := NewConverter().EventToInternal(&types.Event{
+					UserId: "u1", Name: types.FeatureUsed, Properties: types.Properties{"s": oversized(transport.MaxEventSize)},
+				})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "identify over MaxEventSize",
+			convert: func() error {
+				_, err := NewConverter().IdentityToInternal(&types.Identity{
+					UserId: "u1", Properties: types.Properties{"s": oversized(transport.MaxEventSize)},
+				})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "log within the limit",
+			convert: func() error {
+				_, err := NewConverter().LogToInternal(&types.LogEntry{Level: types.LogInfo, Service: "api", Source: "h", Message: "m"})
+				return err
+			},
+		},
+		{
+			name: "log over MaxLogSize",
+			convert: func() error {
+				_, err := NewConverter().LogToInternal(&types.LogEntry{
+					Level: types.LogInfo, Service: "api", Source: "h", Message: oversized(transport.MaxLogSize),
+				})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.convert()
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("convert = %v, want nil", err)
+				}
+				return
+			}
+			var verr *types.ValidationError
+			if !errors.As(err, &verr) || verr.Field != "payload" {
+				t.Errorf("convert = %v, want a payload ValidationError", err)
+			}
+		})
+	}
+}
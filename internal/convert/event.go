@@ -22,10 +22,11 @@ var eventTypeMap = map[types.EventName]event_collector.EventType{
 	types.CartViewed:           event_collector.EventTypeTRACK,
 	types.CheckoutStarted:      event_collector.EventTypeTRACK,
 	types.CheckoutCompleted:    event_collector.EventTypeTRACK,
+	types.ConsentUpdated:       event_collector.EventTypeTRACK,
 }
 
 // EventToInternal converts a types.Event to an internal transport.Event
-func EventToInternal(e *types.Event) (*transport.Event, error) {
+func (c *Converter) EventToInternal(e *types.Event) (*transport.Event, error) {
 	if err := validateRequired("UserId", e.UserId); err != nil {
 		return nil, err
 	}
@@ -40,7 +41,12 @@ func EventToInternal(e *types.Event) (*transport.Event, error) {
 		return nil, fmt.Errorf("unmapped event type: %s", e.Name)
 	}
 
-	payload, err := marshalPayload(e.Properties)
+	properties, err := c.protect(e.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := marshalLimited(withEventID(properties, e.ID), transport.MaxEventSize)
 	if err != nil {
 		return nil, err
 	}
@@ -49,37 +55,44 @@ func EventToInternal(e *types.Event) (*transport.Event, error) {
 		Timestamp: resolveTimestamp(e.Timestamp),
 		EventType: eventType,
 		EventName: e.Name.String(), // Extract event name for performance optimization
-		DeviceID:  nil,             // Will be set by identity manager or overridden in TrackAdvanced
+		DeviceID:  e.DeviceID,      // Will be set by identity manager or overridden in TrackAdvanced
 		SessionID: e.SessionID,     // Will be set by identity manager if nil
 		Payload:   payload,
+		UserID:    e.UserId,
 	}, nil
 }
 
 // IdentityToInternal converts a types.Identity to an internal transport.Event
-func IdentityToInternal(i *types.Identity) (*transport.Event, error) {
+func (c *Converter) IdentityToInternal(i *types.Identity) (*transport.Event, error) {
 	if err := validateRequired("UserId", i.UserId); err != nil {
 		return nil, err
 	}
 
-	payload, err := marshalPayload(map[string]interface{}{
-		"traits": i.Properties,
-	})
+	traits, err := c.protect(i.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := marshalLimited(withEventID(map[string]interface{}{
+		"traits": traits,
+	}, i.ID), transport.MaxEventSize)
 	if err != nil {
 		return nil, err
 	}
 
 	return &transport.Event{
-		Timestamp: resolveTimestamp(time.Time{}), // Always use current time
+		Timestamp: resolveTimestamp(i.Timestamp),
 		EventType: event_collector.EventTypeIDENTIFY,
 		EventName: "identify",  // Set event name for identify events
-		DeviceID:  nil,         // Will be set by identity manager or overridden in TrackAdvanced
+		DeviceID:  i.DeviceID,  // Will be set by identity manager or overridden in TrackAdvanced
 		SessionID: i.SessionID, // Will be set by identity manager if nil
 		Payload:   payload,
+		UserID:    i.UserId,
 	}, nil
 }
 
 // GroupToInternal converts a types.GroupInfo to an internal transport.Event
-func GroupToInternal(g *types.GroupInfo) (*transport.Event, error) {
+func (c *Converter) GroupToInternal(g *types.GroupInfo) (*transport.Event, error) {
 	if err := validateRequired("UserId", g.UserId); err != nil {
 		return nil, err
 	}
@@ -88,25 +101,66 @@ func GroupToInternal(g *types.GroupInfo) (*transport.Event, error) {
 		return nil, err
 	}
 
-	payload, err := marshalPayload(map[string]interface{}{
+	groupProperties, err := c.protect(g.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := marshalLimited(withEventID(map[string]interface{}{
 		"group_id":   g.GroupId,
-		"properties": g.Properties,
-	})
+		"properties": groupProperties,
+	}, g.ID), transport.MaxEventSize)
 	if err != nil {
 		return nil, err
 	}
 
 	return &transport.Event{
-		Timestamp: resolveTimestamp(time.Time{}), // Always use current time
+		Timestamp: resolveTimestamp(g.Timestamp),
 		EventType: event_collector.EventTypeGROUP,
 		EventName: "group",     // Set event name for group events
-		DeviceID:  nil,         // Will be set by identity manager or overridden in TrackAdvanced
+		DeviceID:  g.DeviceID,  // Will be set by identity manager or overridden in TrackAdvanced
 		SessionID: g.SessionID, // Will be set by identity manager if nil
 		Payload:   payload,
+		UserID:    g.UserId,
+	}, nil
+}
+
+// AliasToInternal converts a types.Alias to an internal transport.Event
+func (c *Converter) AliasToInternal(a *types.Alias) (*transport.Event, error) {
+	if err := validateRequired("PreviousId", a.PreviousId); err != nil {
+		return nil, err
+	}
+
+	if err := validateRequired("UserId", a.UserId); err != nil {
+		return nil, err
+	}
+
+	aliasProperties, err := c.protect(a.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := marshalLimited(withEventID(map[string]interface{}{
+		"previous_id": a.PreviousId,
+		"user_id":     a.UserId,
+		"properties":  aliasProperties,
+	}, a.ID), transport.MaxEventSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transport.Event{
+		Timestamp: resolveTimestamp(a.Timestamp),
+		EventType: event_collector.EventTypeALIAS,
+		EventName: "alias",
+		DeviceID:  a.DeviceID,
+		SessionID: a.SessionID,
+		Payload:   payload,
+		UserID:    a.UserId,
 	}, nil
 }
 
-func RevenueToInternal(r *types.Revenue) (*transport.Event, error) {
+func (c *Converter) RevenueToInternal(r *types.Revenue) (*transport.Event, error) {
 	if err := validateRequired("UserID", r.UserID); err != nil {
 		return nil, err
 	}
@@ -147,22 +201,65 @@ func RevenueToInternal(r *types.Revenue) (*transport.Event, error) {
 		properties["products"] = products
 	}
 
+	customProperties, err := c.protect(r.Properties)
+	if err != nil {
+		return nil, err
+	}
+
 	// Merge custom properties
-	for k, v := range r.Properties {
+	for k, v := range customProperties {
 		properties[k] = v
 	}
+	if r.ID != "" {
+		properties[types.EventIDKey] = r.ID
+	}
 
-	payload, err := marshalPayload(properties)
+	payload, err := marshalLimited(properties, transport.MaxEventSize)
 	if err != nil {
 		return nil, err
 	}
 
 	return &transport.Event{
-		Timestamp: resolveTimestamp(time.Time{}),
+		Timestamp: resolveTimestamp(r.Timestamp),
 		EventType: event_collector.EventTypeTRACK,
 		EventName: types.OrderCompleted.String(), // Set event name for revenue events
-		DeviceID:  nil,                           // Will be set by identity manager or overridden in TrackAdvanced
+		DeviceID:  r.DeviceID,                    // Will be set by identity manager or overridden in TrackAdvanced
 		SessionID: r.SessionID,                   // Will be set by identity manager if nil
 		Payload:   payload,                       // OrderID is in the payload data
+		UserID:    r.UserID,
+	}, nil
+}
+
+// PrivacyRequestToInternal converts a data-subject request to an internal transport.Event
+func (c *Converter) PrivacyRequestToInternal(userID string, action types.PrivacyAction) (*transport.Event, error) {
+	if err := validateRequired("UserId", userID); err != nil {
+		return nil, err
+	}
+
+	var name types.EventName
+	switch action {
+	case types.PrivacyDelete:
+		name = types.DeletionRequested
+	case types.PrivacySuppress:
+		name = types.SuppressionRequested
+	default:
+		return nil, types.NewValidationError("Action", fmt.Sprintf("unknown privacy action: %s", action))
+	}
+
+	// Identifiers are sent in clear on purpose: the collector needs them to find the data
+	payload, err := marshalPayload(map[string]interface{}{
+		"user_id": userID,
+		"action":  string(action),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transport.Event{
+		Timestamp: resolveTimestamp(time.Time{}),
+		EventType: event_collector.EventTypePRIVACY,
+		EventName: name.String(),
+		Payload:   payload,
+		UserID:    userID,
 	}, nil
 }
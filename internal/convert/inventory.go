@@ -0,0 +1,65 @@
+// sdk-go/internal/convert/inventory.go
+package convert
+
+import (
+	"fmt"
+
+	schema_inventory "github.com/usercanal/sdk-go/internal/schema/inventory"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/internal/version"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Map SDK inventory kinds to FlatBuffer inventory types
+var inventoryKindMap = map[types.InventoryKind]schema_inventory.InventoryType{
+	types.InventoryService: schema_inventory.InventoryTypeSERVICE,
+	types.InventoryAsset:   schema_inventory.InventoryTypeASSET,
+}
+
+// InventoryToInternal converts a types.Inventory to an internal transport.Inventory.
+// The SDK version is always included so the catalogue shows which SDK each instance runs.
+func (c *Converter) InventoryToInternal(inv *types.Inventory, instanceID []byte) (*transport.Inventory, error) {
+	if err := validateRequired("Service", inv.Service); err != nil {
+		return nil, err
+	}
+
+	fbType, ok := inventoryKindMap[inv.Kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid inventory kind: %d", inv.Kind)
+	}
+
+	attributes, err := c.protect(inv.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"sdk": version.Get(),
+	}
+	if inv.Runtime != nil {
+		payload["runtime"] = inv.Runtime
+	}
+	if inv.Build != nil {
+		payload["build"] = inv.Build
+	}
+	if len(inv.Dependencies) > 0 {
+		payload["dependencies"] = inv.Dependencies
+	}
+	if len(attributes) > 0 {
+		payload["attributes"] = attributes
+	}
+
+	payloadBytes, err := marshalPayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inventory payload: %w", err)
+	}
+
+	return &transport.Inventory{
+		InventoryType: fbType,
+		Timestamp:     resolveTimestamp(inv.Timestamp),
+		InstanceID:    instanceID,
+		Source:        inv.Hostname,
+		Service:       inv.Service,
+		Payload:       payloadBytes,
+	}, nil
+}
@@ -0,0 +1,124 @@
+// sdk-go/internal/convert/inventory_test.go
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/encrypt"
+	schema_inventory "github.com/usercanal/sdk-go/internal/schema/inventory"
+	"github.com/usercanal/sdk-go/internal/version"
+	"github.com/usercanal/sdk-go/types"
+)
+
+var testKey = bytes.Repeat([]byte{7}, 32)
+
+func newEncryptingConverter(t *testing.T, fields ...string) *Converter {
+	t.Helper()
+	e, err := encrypt.New(types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": testKey}, Fields: fields})
+	if err != nil {
+		t.Fatalf("encrypt.New: %v", err)
+	}
+	return NewConverter(WithEncryptor(e))
+}
+
+func decodePayload(t *testing.T, payload []byte) map[string]interface{} {
+	t.Helper()
+	var out map[string]interface{}
+	if err := json.Unmarshal(payload, &out); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	return out
+}
+
+func TestInventoryToInternal(t *testing.T) {
+	stamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	instanceID := bytes.Repeat([]byte{1}, 16)
+	inv := &types.Inventory{
+		Kind:         types.InventoryAsset,
+		Service:      "api",
+		Hostname:     "host-1",
+		Timestamp:    stamp,
+		Runtime:      &types.RuntimeInfo{GoVersion: "go1.24", OS: "linux"},
+		Build:        &types.BuildInfo{Path: "example.com/api", Version: "v1.0.0"},
+		Dependencies: []types.Dependency{{Path: "example.com/dep", Version: "v0.1.0"}},
+		Attributes:   types.Properties{"region": "eu", "token": "secret"},
+	}
+
+	got, err := newEncryptingConverter(t, "token").InventoryToInternal(inv, instanceID)
+	if err != nil {
+		t.Fatalf("InventoryToInternal: %v", err)
+	}
+	if got.InventoryType != schema_inventory.InventoryTypeASSET || got.Service != "api" || got.Source != "host-1" {
+		t.Errorf("inventory = %+v", got)
+	}
+	if got.Timestamp != uint64(stamp.UnixMilli()) || !bytes.Equal(got.InstanceID, instanceID) {
+		t.Errorf("timestamp/instance = %d/%x", got.Timestamp, got.InstanceID)
+	}
+
+	payload := decodePayload(t, got.Payload)
+	if sdk, _ := payload["sdk"].(map[string]interface{}); sdk["version"] != version.Get().Version {
+		t.Errorf("sdk = %v, want version %s", payload["sdk"], version.Get().Version)
+	}
+	if rt, _ := payload["runtime"].(map[string]interface{}); rt["go_version"] != "go1.24" || rt["os"] != "linux" {
+		t.Errorf("runtime = %v", payload["runtime"])
+	}
+	if build, _ := payload["build"].(map[string]interface{}); build["path"] != "example.com/api" {
+		t.Errorf("build = %v", payload["build"])
+	}
+	if deps, _ := payload["dependencies"].([]interface{}); len(deps) != 1 {
+		t.Errorf("dependencies = %v", payload["dependencies"])
+	}
+
+	attrs, _ := payload["attributes"].(map[string]interface{})
+	if attrs["region"] != "eu" {
+		t.Errorf("region = %v, want it in plain text", attrs["region"])
+	}
+	token, err := encrypt.Decrypt(attrs["token"], map[string][]byte{"k1": testKey})
+	if err != nil || token != "secret" {
+		t.Errorf("decrypted token = %v, %v; want secret", token, err)
+	}
+	if inv.Attributes["token"] != "secret" {
+		t.Error("encryption modified the caller's attributes")
+	}
+}
+
+func TestInventoryToInternalOptionalSections(t *testing.T) {
+	got, err := NewConverter().InventoryToInternal(&types.Inventory{Kind: types.InventoryService, Service: "api"}, nil)
+	if err != nil {
+		t.Fatalf("InventoryToInternal: %v", err)
+	}
+	payload := decodePayload(t, got.Payload)
+	if len(payload) != 1 || payload["sdk"] == nil {
+		t.Errorf("payload = %v, want only the SDK version", payload)
+	}
+	if got.Timestamp == 0 {
+		t.Error("zero timestamp was not defaulted")
+	}
+}
+
+func TestInventoryToInternalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		inv  types.Inventory
+	}{
+		{name: "missing service", inv: types.Inventory{Kind: types.InventoryService}},
+		{name: "unknown kind", inv: types.Inventory{Kind: 9, Service: "api"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewConverter().InventoryToInternal(&tt.inv, nil); err == nil {
+				t.Error("InventoryToInternal succeeded, want an error")
+			}
+		})
+	}
+
+	var verr *types.ValidationError
+	_, err := NewConverter().InventoryToInternal(&types.Inventory{Kind: types.InventoryService}, nil)
+	if !errors.As(err, &verr) || verr.Field != "Service" {
+		t.Errorf("missing service error = %v, want a Service ValidationError", err)
+	}
+}
@@ -0,0 +1,29 @@
+// sdk-go/internal/convert/json_alloc_test.go
+
+// The race detector makes sync.Pool drop items at random, so allocations are
+// only counted without it.
+//go:build !race
+
+package convert
+
+import "testing"
+
+// Normalized properties are encoded in a pooled buffer, so the payload copy is
+// the only allocation
+func TestMarshalPayloadAllocs(t *testing.T) {
+	data := map[string]interface{}{
+		"feature_name": "export",
+		"duration_ms":  1500,
+		"ratio":        0.25,
+		"tags":         []interface{}{"a", "b"},
+		"nested":       map[string]interface{}{"ok": true},
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := marshalPayload(data); err != nil {
+			t.Fatalf("marshalPayload: %v", err)
+		}
+	})
+	if allocs > 1 {
+		t.Errorf("marshalPayload allocated %.1f times, want 1", allocs)
+	}
+}
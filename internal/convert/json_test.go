@@ -0,0 +1,138 @@
+// sdk-go/internal/convert/json_test.go
+package convert
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// encodingJSON marshals v with encoding/json. Releases before Go 1.25 escape
+// the replacement character for invalid UTF-8 rather than writing it as is.
+func encodingJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return strings.ReplaceAll(string(out), `\ufffd`, "\ufffd")
+}
+
+// allBytes is every byte value, including invalid UTF-8 and control characters
+func allBytes() string {
+	var b strings.Builder
+	for i := 0; i < 256; i++ {
+		b.WriteByte(byte(i))
+	}
+	return b.String()
+}
+
+func TestAppendJSONMatchesEncodingJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+	}{
+		{name: "empty", data: map[string]interface{}{}},
+		{name: "scalars", data: map[string]interface{}{"s": "v", "t": true, "f": false, "n": nil}},
+		{name: "keys are sorted", data: map[string]interface{}{"b": 1, "a": 2, "c": 3, "aa": 4, "B": 5}},
+		{name: "many keys", data: func() map[string]interface{} {
+			m := make(map[string]interface{})
+			for i := 0; i < 40; i++ {
+				m[strings.Repeat("k", i+1)] = i
+			}
+			return m
+		}()},
+		{name: "integers", data: map[string]interface{}{
+			"int": -1, "int8": int8(math.MinInt8), "int16": int16(math.MaxInt16), "int32": int32(-7), "int64": int64(math.MinInt64),
+			"uint": uint(1), "uint8": uint8(255), "uint16": uint16(65535), "uint32": uint32(math.MaxUint32), "uint64": uint64(math.MaxUint64),
+		}},
+		{name: "floats", data: map[string]interface{}{
+			"zero": 0.0, "neg_zero": math.Copysign(0, -1), "frac": 1.5, "third": 1.0 / 3, "whole": 1500.0,
+			"tiny": 1e-7, "small": 0.000001, "below_small": 0.00000099, "big": 1e21, "below_big": 1e20, "huge": 1.7976931348623157e308,
+			"neg_tiny": -1.23e-9, "f32": float32(3.14), "f32_tiny": float32(1e-7), "f32_big": float32(1e21), "f32_frac": float32(0.1),
+		}},
+		{name: "string escaping", data: map[string]interface{}{
+			"quotes": `"quoted" \back\slash`, "html": "<a href='x'>&amp;</a>", "controls": "\b\f\n\r\t\x00\x1f\x7f",
+			"unicode": "héllo, 世界 🎉", "separators": "a b c", "invalid": "a\xffb\xc3(c", "all_bytes": allBytes(),
+			"<key>&": "keys are escaped too",
+		}},
+		{name: "nested", data: map[string]interface{}{
+			"map":      map[string]interface{}{"z": 1, "a": []interface{}{1, "two", 3.5, nil, map[string]interface{}{"x": true}}},
+			"props":    types.Properties{"k": "v"},
+			"empty":    []interface{}{},
+			"nil_list": []interface{}(nil),
+			"nil_map":  map[string]interface{}(nil),
+			"nil_prop": types.Properties(nil),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := encodingJSON(t, tt.data)
+			got, ok := appendJSONObject(nil, tt.data)
+			if !ok {
+				t.Fatal("appendJSONObject fell back")
+			}
+			if string(got) != want {
+				t.Errorf("appendJSONObject =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestAppendJSONStringAllRunes(t *testing.T) {
+	var b strings.Builder
+	for r := rune(0); r < 0x3000; r++ {
+		b.WriteRune(r)
+	}
+	s := b.String()
+
+	want := encodingJSON(t, s)
+	if got := appendJSONString(nil, s); string(got) != want {
+		t.Error("appendJSONString differs from encoding/json over the first 0x3000 runes")
+	}
+}
+
+func TestMarshalPayloadFallback(t *testing.T) {
+	type point struct {
+		X int `json:"x"`
+	}
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "nil map", data: nil, want: `{}`},
+		{name: "struct value", data: map[string]interface{}{"p": point{X: 1}, "s": "v"}, want: `{"p":{"x":1},"s":"v"}`},
+		{name: "nested struct value", data: map[string]interface{}{"a": []interface{}{&point{X: 2}}}, want: `{"a":[{"x":2}]}`},
+		{name: "typed map", data: map[string]interface{}{"m": map[string]string{"k": "v"}}, want: `{"m":{"k":"v"}}`},
+		{name: "NaN", data: map[string]interface{}{"f": math.NaN()}, wantErr: true},
+		{name: "infinity", data: map[string]interface{}{"f": math.Inf(1)}, wantErr: true},
+		{name: "unsupported", data: map[string]interface{}{"c": make(chan int)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := marshalPayload(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("marshalPayload = %s, %v; wantErr %v", got, err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("marshalPayload = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Payloads must not share the pooled buffer they were encoded in
+func TestMarshalPayloadOwnsResult(t *testing.T) {
+	first, _ := marshalPayload(map[string]interface{}{"a": "first"})
+	second, _ := marshalPayload(map[string]interface{}{"a": "second"})
+	if string(first) != `{"a":"first"}` || string(second) != `{"a":"second"}` {
+		t.Errorf("payloads = %s, %s", first, second)
+	}
+}
@@ -45,7 +45,7 @@ func generateSessionID() []byte {
 }
 
 // LogToInternal converts a types.LogEntry to an internal transport.LogEntry
-func LogToInternal(l *types.LogEntry) (*transport.Log, error) {
+func (c *Converter) LogToInternal(l *types.LogEntry) (*transport.Log, error) {
 	// Validate required fields
 	if err := validateRequired("Service", l.Service); err != nil {
 		return nil, err
@@ -72,18 +72,23 @@ func LogToInternal(l *types.LogEntry) (*transport.Log, error) {
 		sessionID = generateSessionID()
 	}
 
+	data, err := c.protect(l.Data)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare payload - combine message and data
 	payload := make(map[string]interface{})
 	if l.Message != "" {
 		payload["message"] = l.Message
 	}
-	if l.Data != nil {
-		for k, v := range l.Data {
+	if data != nil {
+		for k, v := range data {
 			payload[k] = v
 		}
 	}
 
-	payloadBytes, err := marshalPayload(payload)
+	payloadBytes, err := marshalLimited(payload, transport.MaxLogSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal log payload: %w", err)
 	}
@@ -0,0 +1,59 @@
+// sdk-go/internal/convert/metric.go
+package convert
+
+import (
+	"fmt"
+
+	"github.com/usercanal/sdk-go/internal/metrics"
+	schema_metric "github.com/usercanal/sdk-go/internal/schema/metric"
+	"github.com/usercanal/sdk-go/internal/transport"
+)
+
+// Map SDK instrument kinds to FlatBuffer metric types
+var metricKindMap = map[metrics.Kind]schema_metric.MetricType{
+	metrics.KindCounter:   schema_metric.MetricTypeCOUNTER,
+	metrics.KindGauge:     schema_metric.MetricTypeGAUGE,
+	metrics.KindHistogram: schema_metric.MetricTypeHISTOGRAM,
+	metrics.KindTimer:     schema_metric.MetricTypeTIMER,
+}
+
+// MetricToInternal converts an aggregated metrics.Snapshot to an internal transport.Metric
+func (c *Converter) MetricToInternal(s *metrics.Snapshot) (*transport.Metric, error) {
+	if err := validateRequired("Name", s.Name); err != nil {
+		return nil, err
+	}
+
+	fbType, ok := metricKindMap[s.Kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid metric kind: %d", s.Kind)
+	}
+
+	// Tags and distribution details travel in the payload
+	payload := make(map[string]interface{})
+	if s.Kind != metrics.KindCounter {
+		payload["min"] = s.Min
+		payload["max"] = s.Max
+	}
+	if len(s.Tags) > 0 {
+		payload["tags"] = s.Tags
+	}
+	if len(s.Buckets) > 0 {
+		payload["bounds"] = s.Bounds
+		payload["buckets"] = s.Buckets
+	}
+
+	payloadBytes, err := marshalPayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metric payload: %w", err)
+	}
+
+	return &transport.Metric{
+		MetricType: fbType,
+		Timestamp:  resolveTimestamp(s.Timestamp),
+		Name:       s.Name,
+		Value:      s.Value,
+		Count:      s.Count,
+		IntervalMs: uint32(s.Interval.Milliseconds()),
+		Payload:    payloadBytes,
+	}, nil
+}
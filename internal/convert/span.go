@@ -0,0 +1,79 @@
+// sdk-go/internal/convert/span.go
+package convert
+
+import (
+	"fmt"
+
+	schema_span "github.com/usercanal/sdk-go/internal/schema/span"
+	"github.com/usercanal/sdk-go/internal/trace"
+	"github.com/usercanal/sdk-go/internal/transport"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Map SDK span kinds to FlatBuffer span kinds
+var spanKindMap = map[types.SpanKind]schema_span.SpanKind{
+	types.SpanInternal: schema_span.SpanKindINTERNAL,
+	types.SpanServer:   schema_span.SpanKindSERVER,
+	types.SpanClient:   schema_span.SpanKindCLIENT,
+	types.SpanProducer: schema_span.SpanKindPRODUCER,
+	types.SpanConsumer: schema_span.SpanKindCONSUMER,
+}
+
+// Map SDK span statuses to FlatBuffer span statuses
+var spanStatusMap = map[types.SpanStatus]schema_span.SpanStatus{
+	types.SpanStatusUnset: schema_span.SpanStatusUNSET,
+	types.SpanStatusOK:    schema_span.SpanStatusOK,
+	types.SpanStatusError: schema_span.SpanStatusERROR,
+}
+
+// SpanToInternal converts a finished trace.Record to an internal transport.Span
+func (c *Converter) SpanToInternal(r *trace.Record) (*transport.Span, error) {
+	if err := validateRequired("Name", r.Name); err != nil {
+		return nil, err
+	}
+
+	fbKind, ok := spanKindMap[r.Kind]
+	if !ok {
+		return nil, fmt.Errorf("invalid span kind: %d", r.Kind)
+	}
+	fbStatus, ok := spanStatusMap[r.Status]
+	if !ok {
+		return nil, fmt.Errorf("invalid span status: %d", r.Status)
+	}
+
+	attributes, err := c.protect(r.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make(map[string]interface{})
+	if len(attributes) > 0 {
+		payload["attributes"] = attributes
+	}
+	if r.StatusMessage != "" {
+		payload["status_message"] = r.StatusMessage
+	}
+
+	payloadBytes, err := marshalPayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal span payload: %w", err)
+	}
+
+	var parent []byte
+	if r.Parent.IsValid() {
+		parent = r.Parent[:]
+	}
+
+	return &transport.Span{
+		TraceID:      r.Context.TraceID[:],
+		SpanID:       r.Context.SpanID[:],
+		ParentSpanID: parent,
+		Kind:         fbKind,
+		Status:       fbStatus,
+		StartTime:    uint64(r.Start.UnixNano()),
+		EndTime:      uint64(r.End.UnixNano()),
+		Name:         r.Name,
+		Service:      r.Service,
+		Payload:      payloadBytes,
+	}, nil
+}
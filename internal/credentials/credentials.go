@@ -0,0 +1,175 @@
+// sdk-go/internal/credentials/credentials.go
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	// DefaultEnvVar is the environment variable read by Env when no name is given
+	DefaultEnvVar = "USERCANAL_API_KEY"
+
+	defaultWatchInterval = 30 * time.Second
+)
+
+// Static always returns the same API key
+type Static struct {
+	key string
+}
+
+// NewStatic creates a provider for a fixed API key
+func NewStatic(key string) *Static {
+	return &Static{key: key}
+}
+
+func (s *Static) APIKey(ctx context.Context) (string, error) {
+	return s.key, nil
+}
+
+// Env reads the API key from an environment variable on every call,
+// so updating the variable in-process rotates the key
+type Env struct {
+	name string
+}
+
+// NewEnv creates a provider backed by the named environment variable
+func NewEnv(name string) *Env {
+	if name == "" {
+		name = DefaultEnvVar
+	}
+	return &Env{name: name}
+}
+
+func (e *Env) APIKey(ctx context.Context) (string, error) {
+	key := strings.TrimSpace(os.Getenv(e.name))
+	if key == "" {
+		return "", types.NewValidationError("apiKey", fmt.Sprintf("environment variable %s is not set", e.name))
+	}
+	return key, nil
+}
+
+// Func adapts a callback to the CredentialProvider interface
+type Func func(ctx context.Context) (string, error)
+
+func (f Func) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// File reads the API key from a file and re-reads it whenever the file changes.
+// The file is polled in the background; call Close to stop watching.
+type File struct {
+	path     string
+	interval time.Duration
+
+	mu      sync.RWMutex
+	key     string
+	modTime time.Time
+	size    int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewFile creates a provider that watches path for key rotations
+func NewFile(path string, interval time.Duration) (*File, error) {
+	if path == "" {
+		return nil, types.NewValidationError("path", "is required")
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	f := &File{
+		path:     path,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+
+	f.wg.Add(1)
+	go f.watch()
+
+	return f, nil
+}
+
+func (f *File) APIKey(ctx context.Context) (string, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.key, nil
+}
+
+func (f *File) watch() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+			if err := f.reload(); err != nil {
+				logger.Warn("Failed to reload API key from %s: %v", f.path, err)
+			}
+		}
+	}
+}
+
+// reload re-reads the key file if its size or modification time changed
+func (f *File) reload() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat credentials file: %w", err)
+	}
+
+	f.mu.RLock()
+	unchanged := f.key != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size
+	f.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	key := string(bytes.TrimSpace(data))
+	if err := types.ValidateAPIKey(key); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	if f.key != "" && f.key != key {
+		logger.Debug("API key rotated from %s", f.path)
+	}
+	f.key = key
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.mu.Unlock()
+
+	return nil
+}
+
+// Close stops watching the key file
+func (f *File) Close() error {
+	f.once.Do(func() {
+		close(f.done)
+	})
+	f.wg.Wait()
+	return nil
+}
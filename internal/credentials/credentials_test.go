@@ -0,0 +1,135 @@
+// sdk-go/internal/credentials/credentials_test.go
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	keyA = "000102030405060708090a0b0c0d0e0f"
+	keyB = "0f0e0d0c0b0a09080706050403020100"
+)
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		envName string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "default variable", value: keyA, want: keyA},
+		{name: "named variable", envName: "TEST_USERCANAL_KEY", value: keyB, want: keyB},
+		{name: "surrounding whitespace is trimmed", value: "  " + keyA + "\n", want: keyA},
+		{name: "unset variable", envName: "TEST_USERCANAL_UNSET", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := tt.envName
+			if name == "" {
+				name = DefaultEnvVar
+			}
+			t.Setenv(name, tt.value)
+
+			got, err := NewEnv(tt.envName).APIKey(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("APIKey: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("APIKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvRotation(t *testing.T) {
+	t.Setenv(DefaultEnvVar, keyA)
+	env := NewEnv("")
+	if got, _ := env.APIKey(context.Background()); got != keyA {
+		t.Fatalf("APIKey = %q, want %q", got, keyA)
+	}
+	t.Setenv(DefaultEnvVar, keyB)
+	if got, _ := env.APIKey(context.Background()); got != keyB {
+		t.Errorf("APIKey after rotation = %q, want %q", got, keyB)
+	}
+}
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string
+		rotated string // written after the provider is created, "" to leave the file
+		want    string
+		wantErr bool
+	}{
+		{name: "initial key", initial: keyA + "\n", want: keyA},
+		{name: "rotated key is picked up", initial: keyA, rotated: keyB, want: keyB},
+		{name: "invalid rotated key keeps the previous key", initial: keyA, rotated: "not-a-key", want: keyA},
+		{name: "invalid initial key", initial: "not-a-key", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "api-key")
+			writeKey(t, path, tt.initial, time.Now().Add(-time.Hour))
+
+			f, err := NewFile(path, time.Hour)
+			if tt.wantErr {
+				if err == nil {
+					f.Close()
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFile: %v", err)
+			}
+			defer f.Close()
+
+			if tt.rotated != "" {
+				writeKey(t, path, tt.rotated, time.Now())
+				f.reload()
+			}
+
+			got, err := f.APIKey(context.Background())
+			if err != nil {
+				t.Fatalf("APIKey: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("APIKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileMissing(t *testing.T) {
+	if _, err := NewFile(filepath.Join(t.TempDir(), "missing"), time.Hour); err == nil {
+		t.Fatal("expected error for a missing file")
+	}
+	if _, err := NewFile("", time.Hour); err == nil {
+		t.Fatal("expected error for an empty path")
+	}
+}
+
+// writeKey writes key to path with the given modification time, so a reload
+// sees a change even within the filesystem's timestamp resolution
+func writeKey(t *testing.T, path, key string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(key), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+}
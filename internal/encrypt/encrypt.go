@@ -0,0 +1,240 @@
+// sdk-go/internal/encrypt/encrypt.go
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Tag values written into every encrypted field so downstream consumers can find and decrypt them.
+// An encrypted field is replaced by:
+//
+//	{"_enc": "aes-gcm/v1", "kid": "<key id>", "nonce": "<base64>", "data": "<base64>"}
+//
+// The plaintext is the JSON encoding of the original value; the key ID is used as additional data.
+const (
+	TagField   = "_enc"
+	TagVersion = "aes-gcm/v1"
+)
+
+// Encryptor encrypts configured property paths with AES-GCM
+type Encryptor struct {
+	keyID string
+	aeads map[string]cipher.AEAD
+	paths [][]string
+}
+
+// New creates an encryptor from the given configuration
+func New(cfg types.EncryptionConfig) (*Encryptor, error) {
+	if cfg.KeyID == "" {
+		return nil, types.NewValidationError("KeyID", "is required")
+	}
+	if len(cfg.Fields) == 0 {
+		return nil, types.NewValidationError("Fields", "at least one field path is required")
+	}
+
+	aeads, err := newAEADs(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := aeads[cfg.KeyID]; !ok {
+		return nil, types.NewValidationError("KeyID", fmt.Sprintf("key %q not found in Keys", cfg.KeyID))
+	}
+
+	paths := make([][]string, 0, len(cfg.Fields))
+	for _, field := range cfg.Fields {
+		if field == "" {
+			return nil, types.NewValidationError("Fields", "path cannot be empty")
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+
+	return &Encryptor{
+		keyID: cfg.KeyID,
+		aeads: aeads,
+		paths: paths,
+	}, nil
+}
+
+func newAEADs(keys map[string][]byte) (map[string]cipher.AEAD, error) {
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, types.NewValidationError("Keys", fmt.Sprintf("key %q: %v", id, err))
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		aeads[id] = aead
+	}
+	return aeads, nil
+}
+
+// Apply returns a copy of data with every configured path encrypted.
+// Maps on the way to an encrypted field are copied; the caller's data is never modified.
+func (e *Encryptor) Apply(data map[string]interface{}) (map[string]interface{}, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	var out interface{} = data
+	for _, path := range e.paths {
+		var err error
+		out, err = e.applyPath(out, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out.(map[string]interface{}), nil
+}
+
+func (e *Encryptor) applyPath(value interface{}, rest []string) (interface{}, error) {
+	if len(rest) == 0 {
+		if isEncrypted(value) {
+			return value, nil
+		}
+		return e.encrypt(value)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return e.applyMap(v, rest)
+	case types.Properties:
+		return e.applyMap(v, rest)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			encrypted, err := e.applyPath(item, rest)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = encrypted
+		}
+		return out, nil
+	default:
+		// Path does not exist in this payload
+		return value, nil
+	}
+}
+
+func (e *Encryptor) applyMap(m map[string]interface{}, rest []string) (interface{}, error) {
+	key := rest[0]
+	if key != "*" {
+		child, ok := m[key]
+		if !ok || child == nil {
+			return m, nil
+		}
+		encrypted, err := e.applyPath(child, rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		out := copyMap(m)
+		out[key] = encrypted
+		return out, nil
+	}
+
+	out := copyMap(m)
+	for k, child := range m {
+		if child == nil {
+			continue
+		}
+		encrypted, err := e.applyPath(child, rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		out[k] = encrypted
+	}
+	return out, nil
+}
+
+func (e *Encryptor) encrypt(value interface{}) (map[string]interface{}, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode field for encryption: %w", err)
+	}
+
+	aead := e.aeads[e.keyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := aead.Seal(nil, nonce, plaintext, []byte(e.keyID))
+	return map[string]interface{}{
+		TagField: TagVersion,
+		"kid":    e.keyID,
+		"nonce":  base64.StdEncoding.EncodeToString(nonce),
+		"data":   base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// Decrypt reverses the encryption of a single tagged value, as decoded from the JSON payload.
+// keys maps key IDs to AES keys and must contain the key the value was encrypted with.
+func Decrypt(value interface{}, keys map[string][]byte) (interface{}, error) {
+	tagged, ok := value.(map[string]interface{})
+	if !ok || !isEncrypted(tagged) {
+		return nil, types.NewValidationError("value", "is not an encrypted field")
+	}
+
+	keyID, _ := tagged["kid"].(string)
+	key, ok := keys[keyID]
+	if !ok {
+		return nil, types.NewValidationError("kid", fmt.Sprintf("unknown key %q", keyID))
+	}
+	aeads, err := newAEADs(map[string][]byte{keyID: key})
+	if err != nil {
+		return nil, err
+	}
+
+	nonceStr, _ := tagged["nonce"].(string)
+	dataStr, _ := tagged["data"].(string)
+	nonce, err := base64.StdEncoding.DecodeString(nonceStr)
+	if err != nil {
+		return nil, types.NewValidationError("nonce", "invalid encoding")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(dataStr)
+	if err != nil {
+		return nil, types.NewValidationError("data", "invalid encoding")
+	}
+
+	aead := aeads[keyID]
+	if len(nonce) != aead.NonceSize() {
+		return nil, types.NewValidationError("nonce", "invalid length")
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt field: %w", err)
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(plaintext, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode decrypted field: %w", err)
+	}
+	return out, nil
+}
+
+func isEncrypted(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	tag, _ := m[TagField].(string)
+	return tag == TagVersion
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
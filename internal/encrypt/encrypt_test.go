@@ -0,0 +1,238 @@
+// sdk-go/internal/encrypt/encrypt_test.go
+package encrypt
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 16)
+)
+
+func newEncryptor(t *testing.T, keyID string, fields ...string) *Encryptor {
+	t.Helper()
+	e, err := New(types.EncryptionConfig{
+		KeyID:  keyID,
+		Keys:   map[string][]byte{"k1": key1, "k2": key2},
+		Fields: fields,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+// roundTrip sends data through JSON, as the payload would travel
+func roundTrip(t *testing.T, data map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return out
+}
+
+// lookup follows a path of map keys and array indexes
+func lookup(value interface{}, path ...interface{}) interface{} {
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			value = value.(map[string]interface{})[s]
+		case int:
+			value = value.([]interface{})[s]
+		}
+	}
+	return value
+}
+
+func TestApplyDecryptRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		fields    []string
+		data      map[string]interface{}
+		encrypted [][]interface{} // paths expected to hold an encrypted value
+		plain     [][]interface{} // paths expected to be left alone
+	}{
+		{
+			name:      "top-level field",
+			fields:    []string{"email"},
+			data:      map[string]interface{}{"email": "a@example.com", "plan": "pro"},
+			encrypted: [][]interface{}{{"email"}},
+			plain:     [][]interface{}{{"plan"}},
+		},
+		{
+			name:      "nested field",
+			fields:    []string{"address.street"},
+			data:      map[string]interface{}{"address": map[string]interface{}{"street": "Main St 1", "city": "Oslo"}},
+			encrypted: [][]interface{}{{"address", "street"}},
+			plain:     [][]interface{}{{"address", "city"}},
+		},
+		{
+			name:      "wildcard",
+			fields:    []string{"secrets.*"},
+			data:      map[string]interface{}{"secrets": map[string]interface{}{"a": "x", "b": 42.0}},
+			encrypted: [][]interface{}{{"secrets", "a"}, {"secrets", "b"}},
+		},
+		{
+			name:   "arrays are traversed element-wise",
+			fields: []string{"cards.number"},
+			data: map[string]interface{}{"cards": []interface{}{
+				map[string]interface{}{"number": "4111", "brand": "visa"},
+				map[string]interface{}{"number": "5500", "brand": "mc"},
+			}},
+			encrypted: [][]interface{}{{"cards", 0, "number"}, {"cards", 1, "number"}},
+			plain:     [][]interface{}{{"cards", 0, "brand"}},
+		},
+		{
+			name:      "structured value",
+			fields:    []string{"profile"},
+			data:      map[string]interface{}{"profile": map[string]interface{}{"age": 30.0, "tags": []interface{}{"a"}}},
+			encrypted: [][]interface{}{{"profile"}},
+		},
+		{
+			name:   "missing path is ignored",
+			fields: []string{"ssn", "address.zip"},
+			data:   map[string]interface{}{"email": "a@example.com"},
+			plain:  [][]interface{}{{"email"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEncryptor(t, "k1", tt.fields...)
+			before := roundTrip(t, tt.data)
+
+			out, err := e.Apply(tt.data)
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if !reflect.DeepEqual(roundTrip(t, tt.data), before) {
+				t.Error("Apply modified the caller's data")
+			}
+
+			sent := roundTrip(t, out)
+			for _, path := range tt.encrypted {
+				value := lookup(sent, path...)
+				tagged, ok := value.(map[string]interface{})
+				if !ok || tagged[TagField] != TagVersion || tagged["kid"] != "k1" {
+					t.Fatalf("%v = %v, want an encrypted field with kid k1", path, value)
+				}
+				plain, err := Decrypt(value, map[string][]byte{"k1": key1})
+				if err != nil {
+					t.Fatalf("Decrypt %v: %v", path, err)
+				}
+				if want := lookup(before, path...); !reflect.DeepEqual(plain, want) {
+					t.Errorf("Decrypt %v = %v, want %v", path, plain, want)
+				}
+			}
+			for _, path := range tt.plain {
+				if got, want := lookup(sent, path...), lookup(before, path...); !reflect.DeepEqual(got, want) {
+					t.Errorf("%v = %v, want it unchanged (%v)", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyDoesNotEncryptTwice(t *testing.T) {
+	e := newEncryptor(t, "k1", "email")
+	once, err := e.Apply(map[string]interface{}{"email": "a@example.com"})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	twice, err := e.Apply(once)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !reflect.DeepEqual(once, twice) {
+		t.Errorf("second Apply changed an encrypted field: %v -> %v", once, twice)
+	}
+}
+
+func TestDecryptKeyIDs(t *testing.T) {
+	old := newEncryptor(t, "k1", "email")
+	out, err := old.Apply(map[string]interface{}{"email": "a@example.com"})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	encrypted := roundTrip(t, out)["email"].(map[string]interface{})
+
+	tamperedKid := copyMap(encrypted)
+	tamperedKid["kid"] = "k2"
+	tamperedData := copyMap(encrypted)
+	tamperedData["data"] = "AAAA" + encrypted["data"].(string)[4:]
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		keys    map[string][]byte
+		wantErr string
+	}{
+		{name: "retired key still decrypts", value: encrypted, keys: map[string][]byte{"k1": key1, "k2": key2}},
+		{name: "unknown key id", value: encrypted, keys: map[string][]byte{"k2": key2}, wantErr: "unknown key"},
+		{name: "wrong key under the same id", value: encrypted, keys: map[string][]byte{"k1": bytes.Repeat([]byte{9}, 32)}, wantErr: "decrypt"},
+		{name: "key id is authenticated", value: tamperedKid, keys: map[string][]byte{"k1": key1, "k2": key1}, wantErr: "decrypt"},
+		{name: "tampered ciphertext", value: tamperedData, keys: map[string][]byte{"k1": key1}, wantErr: "decrypt"},
+		{name: "untagged value", value: "a@example.com", keys: map[string][]byte{"k1": key1}, wantErr: "not an encrypted field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(tt.value, tt.keys)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Decrypt error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if got != "a@example.com" {
+				t.Errorf("Decrypt = %v, want a@example.com", got)
+			}
+		})
+	}
+
+	// A rotated encryptor writes the new key ID
+	rotated := newEncryptor(t, "k2", "email")
+	out, err = rotated.Apply(map[string]interface{}{"email": "a@example.com"})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if kid := out["email"].(map[string]interface{})["kid"]; kid != "k2" {
+		t.Errorf("kid after rotation = %v, want k2", kid)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  types.EncryptionConfig
+	}{
+		{name: "missing key id", cfg: types.EncryptionConfig{Keys: map[string][]byte{"k1": key1}, Fields: []string{"email"}}},
+		{name: "key id not in keys", cfg: types.EncryptionConfig{KeyID: "k9", Keys: map[string][]byte{"k1": key1}, Fields: []string{"email"}}},
+		{name: "no fields", cfg: types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": key1}}},
+		{name: "empty field path", cfg: types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": key1}, Fields: []string{""}}},
+		{name: "bad key length", cfg: types.EncryptionConfig{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("short")}, Fields: []string{"email"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
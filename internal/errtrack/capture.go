@@ -0,0 +1,215 @@
+// sdk-go/internal/errtrack/capture.go
+package errtrack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const (
+	// DefaultMaxFrames is the number of stack frames kept per capture
+	DefaultMaxFrames = 32
+
+	// maxChain bounds how far an error chain is walked
+	maxChain = 32
+
+	// fingerprintFrames is how many in-app frames contribute to the fingerprint
+	fingerprintFrames = 5
+)
+
+// Link is one error in an unwrapped chain
+type Link struct {
+	Type    string
+	Message string
+}
+
+// Frame is one entry of a goroutine stack
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Report describes a captured error
+type Report struct {
+	Message     string
+	Type        string
+	Chain       []Link
+	Stack       []Frame
+	Fingerprint string
+	Panic       bool
+	Suppressed  int // Captures dropped by rate limiting since the last one sent
+}
+
+// Capture records err, its unwrapped chain and the calling goroutine's stack.
+// skip is the number of caller frames to omit, as for runtime.Callers.
+func Capture(err error, skip, maxFrames int) Report {
+	chain := Chain(err)
+	stack := Stack(skip+1, maxFrames)
+	return Report{
+		Message:     err.Error(),
+		Type:        typeName(err),
+		Chain:       chain,
+		Stack:       stack,
+		Fingerprint: Fingerprint(chain, stack),
+	}
+}
+
+// CapturePanic is like Capture for a value returned by recover
+func CapturePanic(recovered interface{}, skip, maxFrames int) Report {
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+	r := Capture(err, skip+1, maxFrames)
+	if !ok {
+		r.Type = typeName(recovered)
+		r.Chain[0].Type = r.Type
+		r.Fingerprint = Fingerprint(r.Chain, r.Stack)
+	}
+	r.Panic = true
+	return r
+}
+
+// Chain walks err depth-first through Unwrap() error and Unwrap() []error, as
+// produced by fmt.Errorf("%w") and errors.Join, recording each error's concrete type
+func Chain(err error) []Link {
+	var chain []Link
+	var walk func(error)
+	walk = func(e error) {
+		if e == nil || len(chain) >= maxChain {
+			return
+		}
+		chain = append(chain, Link{Type: typeName(e), Message: e.Error()})
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// Stack returns the calling goroutine's frames. When called while panicking, the
+// frames of the panic machinery are dropped so the stack starts at the panic site.
+func Stack(skip, maxFrames int) []Frame {
+	if maxFrames <= 0 {
+		maxFrames = DefaultMaxFrames
+	}
+
+	// Collect extra frames so runtime and recovery frames can be trimmed without losing depth
+	pcs := make([]uintptr, maxFrames+32)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var all []Frame
+	for {
+		f, more := frames.Next()
+		all = append(all, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		if !more {
+			break
+		}
+	}
+
+	// While panicking, the stack runs through the deferred recovery code and runtime.gopanic
+	// (plus sigpanic for runtime errors) before reaching the panic site
+	for i, f := range all {
+		if f.Function == "runtime.gopanic" {
+			j := i + 1
+			for j < len(all) && isRuntimeFrame(all[j].Function) {
+				j++
+			}
+			all = all[j:]
+			break
+		}
+	}
+
+	// Start at the application frame that called into the SDK
+	for len(all) > 1 && isSDKFrame(all[0].Function) {
+		all = all[1:]
+	}
+
+	// Drop goroutine entry frames such as runtime.goexit
+	for len(all) > 0 && isRuntimeFrame(all[len(all)-1].Function) {
+		all = all[:len(all)-1]
+	}
+
+	if len(all) > maxFrames {
+		all = all[:maxFrames]
+	}
+	return all
+}
+
+// Fingerprint groups captures of the same problem. It hashes the error types of the
+// chain and the top stack functions; messages and line numbers are left out because
+// they change with input data and unrelated edits.
+func Fingerprint(chain []Link, stack []Frame) string {
+	h := sha256.New()
+	for _, l := range chain {
+		h.Write([]byte(l.Type))
+		h.Write([]byte{0})
+	}
+	h.Write([]byte{1})
+
+	n := 0
+	for _, f := range stack {
+		if n == fingerprintFrames {
+			break
+		}
+		if isSDKFrame(f.Function) {
+			continue
+		}
+		h.Write([]byte(f.Function))
+		h.Write([]byte{0})
+		n++
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func isRuntimeFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.")
+}
+
+func isSDKFrame(function string) bool {
+	return strings.HasPrefix(function, "github.com/usercanal/sdk-go.") ||
+		strings.HasPrefix(function, "github.com/usercanal/sdk-go/internal/")
+}
+
+func typeName(v interface{}) string {
+	return fmt.Sprintf("%T", v)
+}
+
+// Map returns the report as nested maps and slices, the shape LogEntry.Data and
+// redaction rules expect
+func (r Report) Map() map[string]interface{} {
+	chain := make([]interface{}, len(r.Chain))
+	for i, l := range r.Chain {
+		chain[i] = map[string]interface{}{"type": l.Type, "message": l.Message}
+	}
+	stack := make([]interface{}, len(r.Stack))
+	for i, f := range r.Stack {
+		stack[i] = map[string]interface{}{"function": f.Function, "file": f.File, "line": f.Line}
+	}
+
+	m := map[string]interface{}{
+		"message":     r.Message,
+		"type":        r.Type,
+		"chain":       chain,
+		"stack":       stack,
+		"fingerprint": r.Fingerprint,
+	}
+	if r.Panic {
+		m["panic"] = true
+	}
+	if r.Suppressed > 0 {
+		m["suppressed"] = r.Suppressed
+	}
+	return m
+}
@@ -0,0 +1,187 @@
+// sdk-go/internal/errtrack/capture_test.go
+package errtrack
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func stack(functions ...string) []Frame {
+	frames := make([]Frame, len(functions))
+	for i, f := range functions {
+		frames[i] = Frame{Function: f, File: "/src/app/" + f + ".go", Line: 10 + i}
+	}
+	return frames
+}
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint(
+		[]Link{{Type: "*fmt.wrapError", Message: "load user 42: EOF"}, {Type: "*errors.errorString", Message: "EOF"}},
+		stack("main.loadUser", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+	)
+
+	tests := []struct {
+		name  string
+		chain []Link
+		stack []Frame
+		same  bool
+	}{
+		{
+			name:  "messages are ignored",
+			chain: []Link{{Type: "*fmt.wrapError", Message: "load user 7: EOF"}, {Type: "*errors.errorString", Message: "EOF"}},
+			stack: stack("main.loadUser", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+			same:  true,
+		},
+		{
+			name:  "line numbers are ignored",
+			chain: []Link{{Type: "*fmt.wrapError"}, {Type: "*errors.errorString"}},
+			stack: []Frame{
+				{Function: "main.loadUser", Line: 99},
+				{Function: "main.handler", Line: 1},
+				{Function: "net/http.HandlerFunc.ServeHTTP", Line: 5},
+			},
+			same: true,
+		},
+		{
+			name:  "SDK frames are ignored",
+			chain: []Link{{Type: "*fmt.wrapError"}, {Type: "*errors.errorString"}},
+			stack: stack("github.com/usercanal/sdk-go.(*Client).CaptureError", "main.loadUser", "github.com/usercanal/sdk-go/internal/api.wrap", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+			same:  true,
+		},
+		{
+			name:  "different error type",
+			chain: []Link{{Type: "*fmt.wrapError"}, {Type: "*os.PathError"}},
+			stack: stack("main.loadUser", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+		},
+		{
+			name:  "shorter chain",
+			chain: []Link{{Type: "*fmt.wrapError"}},
+			stack: stack("main.loadUser", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+		},
+		{
+			name:  "different call site",
+			chain: []Link{{Type: "*fmt.wrapError"}, {Type: "*errors.errorString"}},
+			stack: stack("main.saveUser", "main.handler", "net/http.HandlerFunc.ServeHTTP"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fingerprint(tt.chain, tt.stack)
+			if len(got) != 16 {
+				t.Errorf("Fingerprint = %q, want 16 hex characters", got)
+			}
+			if (got == base) != tt.same {
+				t.Errorf("Fingerprint = %s, base %s, want same = %v", got, base, tt.same)
+			}
+		})
+	}
+}
+
+func TestFingerprintFrameLimit(t *testing.T) {
+	chain := []Link{{Type: "*errors.errorString"}}
+	top := stack("f1", "f2", "f3", "f4", "f5")
+	a := Fingerprint(chain, append(top, stack("g6")...))
+	b := Fingerprint(chain, append(top, stack("h6", "h7")...))
+	if a != b {
+		t.Errorf("frames past the first %d changed the fingerprint", fingerprintFrames)
+	}
+}
+
+type codeError struct{ code int }
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{name: "single", err: io.EOF, want: []string{"*errors.errorString"}},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("read config: %w", &os.PathError{Op: "open", Path: "/etc/app", Err: os.ErrNotExist}),
+			want: []string{"*fmt.wrapError", "*fs.PathError", "*errors.errorString"},
+		},
+		{
+			name: "joined",
+			err:  errors.Join(&codeError{1}, fmt.Errorf("second: %w", &codeError{2})),
+			want: []string{"*errors.joinError", "*errtrack.codeError", "*fmt.wrapError", "*errtrack.codeError"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := Chain(tt.err)
+			var got []string
+			for _, l := range chain {
+				got = append(got, l.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain types = %v, want %v", got, tt.want)
+			}
+			if chain[0].Message != tt.err.Error() {
+				t.Errorf("first message = %q, want %q", chain[0].Message, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestChainIsBounded(t *testing.T) {
+	var err error = io.EOF
+	for i := 0; i < 2*maxChain; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+	}
+	if got := len(Chain(err)); got != maxChain {
+		t.Errorf("Chain length = %d, want %d", got, maxChain)
+	}
+}
+
+func TestCaptureIsStable(t *testing.T) {
+	var fingerprints []string
+	for i := 0; i < 3; i++ {
+		r := Capture(fmt.Errorf("attempt %d: %w", i, io.EOF), 0, DefaultMaxFrames)
+		fingerprints = append(fingerprints, r.Fingerprint)
+		if r.Type != "*fmt.wrapError" || len(r.Chain) != 2 || len(r.Stack) == 0 {
+			t.Fatalf("Capture = %+v", r)
+		}
+	}
+	if fingerprints[0] != fingerprints[1] || fingerprints[1] != fingerprints[2] {
+		t.Errorf("fingerprints differ across captures: %v", fingerprints)
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	tests := []struct {
+		name      string
+		recovered interface{}
+		wantType  string
+	}{
+		{name: "error value", recovered: &codeError{500}, wantType: "*errtrack.codeError"},
+		{name: "string value", recovered: "boom", wantType: "string"},
+		{name: "other value", recovered: 42, wantType: "int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := CapturePanic(tt.recovered, 0, DefaultMaxFrames)
+			if !r.Panic {
+				t.Error("Panic is not set")
+			}
+			if r.Type != tt.wantType || r.Chain[0].Type != tt.wantType {
+				t.Errorf("types = (%s, %s), want %s", r.Type, r.Chain[0].Type, tt.wantType)
+			}
+			if r.Fingerprint != Fingerprint(r.Chain, r.Stack) {
+				t.Error("fingerprint does not match the reported chain")
+			}
+			if r.Map()["panic"] != true {
+				t.Error("Map does not report the panic")
+			}
+		})
+	}
+}
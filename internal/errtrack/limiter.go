@@ -0,0 +1,92 @@
+// sdk-go/internal/errtrack/limiter.go
+package errtrack
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimit is the number of captures sent per fingerprint per window
+	DefaultRateLimit = 10
+
+	// DefaultRateWindow is the rate limiting window
+	DefaultRateWindow = time.Minute
+
+	// maxTracked bounds the number of fingerprints held in memory
+	maxTracked = 1000
+)
+
+type window struct {
+	start      time.Time
+	sent       int
+	suppressed int
+}
+
+// Limiter caps how often each fingerprint is reported so an error storm
+// cannot flood the log pipeline
+type Limiter struct {
+	limit  int
+	period time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+// NewLimiter allows limit captures per fingerprint every period
+func NewLimiter(limit int, period time.Duration) *Limiter {
+	if limit <= 0 {
+		limit = DefaultRateLimit
+	}
+	if period <= 0 {
+		period = DefaultRateWindow
+	}
+	return &Limiter{
+		limit:   limit,
+		period:  period,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow reports whether a capture with fingerprint may be sent. When it may, it also
+// returns how many captures of the fingerprint were suppressed since the last one sent.
+func (l *Limiter) Allow(fingerprint string) (bool, int) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[fingerprint]
+	if !ok {
+		if len(l.windows) >= maxTracked {
+			l.evict(now)
+		}
+		w = &window{start: now}
+		l.windows[fingerprint] = w
+	} else if now.Sub(w.start) >= l.period {
+		w.start = now
+		w.sent = 0
+	}
+
+	if w.sent >= l.limit {
+		w.suppressed++
+		return false, 0
+	}
+
+	w.sent++
+	suppressed := w.suppressed
+	w.suppressed = 0
+	return true, suppressed
+}
+
+// evict drops expired windows, or all of them if none have expired
+func (l *Limiter) evict(now time.Time) {
+	for fp, w := range l.windows {
+		if now.Sub(w.start) >= l.period {
+			delete(l.windows, fp)
+		}
+	}
+	if len(l.windows) >= maxTracked {
+		l.windows = make(map[string]*window)
+	}
+}
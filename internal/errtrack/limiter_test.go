@@ -0,0 +1,79 @@
+// sdk-go/internal/errtrack/limiter_test.go
+package errtrack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	type call struct {
+		fingerprint    string
+		wantAllowed    bool
+		wantSuppressed int
+	}
+	tests := []struct {
+		name   string
+		limit  int
+		period time.Duration
+		calls  []call
+	}{
+		{
+			name:   "limit per fingerprint",
+			limit:  2,
+			period: time.Hour,
+			calls: []call{
+				{"a", true, 0},
+				{"a", true, 0},
+				{"a", false, 0},
+				{"b", true, 0},
+				{"a", false, 0},
+			},
+		},
+		{
+			name:   "expired window allows again",
+			limit:  1,
+			period: time.Nanosecond,
+			calls: []call{
+				{"a", true, 0},
+				{"a", true, 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(tt.limit, tt.period)
+			for i, c := range tt.calls {
+				allowed, suppressed := l.Allow(c.fingerprint)
+				if allowed != c.wantAllowed || suppressed != c.wantSuppressed {
+					t.Errorf("call %d: Allow(%q) = (%v, %d), want (%v, %d)", i, c.fingerprint, allowed, suppressed, c.wantAllowed, c.wantSuppressed)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterReportsSuppressed(t *testing.T) {
+	l := NewLimiter(1, time.Hour)
+	l.Allow("a")
+	l.Allow("a")
+	l.Allow("a")
+
+	// Expire the window so the next capture is allowed again
+	l.windows["a"].start = time.Now().Add(-2 * time.Hour)
+	allowed, suppressed := l.Allow("a")
+	if !allowed || suppressed != 2 {
+		t.Errorf("Allow after the window = (%v, %d), want (true, 2)", allowed, suppressed)
+	}
+}
+
+func TestLimiterBoundsFingerprints(t *testing.T) {
+	l := NewLimiter(1, time.Hour)
+	for i := 0; i < maxTracked+10; i++ {
+		l.Allow(string(rune('a'+i%26)) + string(rune(i)))
+	}
+	if len(l.windows) > maxTracked {
+		t.Errorf("tracking %d fingerprints, want at most %d", len(l.windows), maxTracked)
+	}
+}
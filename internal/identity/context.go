@@ -0,0 +1,20 @@
+// identity/context.go
+package identity
+
+import "context"
+
+type sessionKey struct{}
+
+// ContextWithSessionID returns a copy of ctx carrying a session ID for logs sent with it
+func ContextWithSessionID(ctx context.Context, sessionID []byte) context.Context {
+	return context.WithValue(ctx, sessionKey{}, sessionID)
+}
+
+// SessionIDFromContext returns the session ID carried by ctx, or nil
+func SessionIDFromContext(ctx context.Context) []byte {
+	if ctx == nil {
+		return nil
+	}
+	id, _ := ctx.Value(sessionKey{}).([]byte)
+	return id
+}
@@ -0,0 +1,76 @@
+// sdk-go/internal/inventory/inventory.go
+package inventory
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// processStart approximates the process start time
+var processStart = time.Now()
+
+// Collect gathers runtime and build details of the current process.
+// Hostname and Timestamp are left for the client to fill in.
+func Collect(service string, includeDependencies bool) types.Inventory {
+	inv := types.Inventory{
+		Kind:    types.InventoryService,
+		Service: service,
+		Runtime: Runtime(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return inv
+	}
+
+	inv.Build = &types.BuildInfo{
+		Path:      build.Main.Path,
+		Version:   build.Main.Version,
+		GoVersion: build.GoVersion,
+	}
+	if len(build.Settings) > 0 {
+		inv.Build.Settings = make(map[string]string, len(build.Settings))
+		for _, s := range build.Settings {
+			inv.Build.Settings[s.Key] = s.Value
+		}
+	}
+
+	if includeDependencies {
+		inv.Dependencies = dependencies(build.Deps)
+	}
+
+	return inv
+}
+
+// Runtime returns details of the Go runtime the process runs on
+func Runtime() *types.RuntimeInfo {
+	return &types.RuntimeInfo{
+		GoVersion:  runtime.Version(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		PID:        os.Getpid(),
+		StartTime:  processStart,
+	}
+}
+
+func dependencies(mods []*debug.Module) []types.Dependency {
+	deps := make([]types.Dependency, 0, len(mods))
+	for _, m := range mods {
+		dep := types.Dependency{
+			Path:    m.Path,
+			Version: m.Version,
+			Sum:     m.Sum,
+		}
+		if m.Replace != nil {
+			dep.Replace = m.Replace.Path + "@" + m.Replace.Version
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
@@ -0,0 +1,73 @@
+// sdk-go/internal/inventory/inventory_test.go
+package inventory
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestCollect(t *testing.T) {
+	for _, withDeps := range []bool{false, true} {
+		inv := Collect("api", withDeps)
+
+		if inv.Kind != types.InventoryService || inv.Service != "api" {
+			t.Errorf("kind/service = %v/%q, want service/api", inv.Kind, inv.Service)
+		}
+		if !inv.Timestamp.IsZero() || inv.Hostname != "" {
+			t.Error("Collect filled in fields left for the client")
+		}
+
+		rt := inv.Runtime
+		if rt == nil {
+			t.Fatal("Runtime is not set")
+		}
+		if rt.GoVersion != runtime.Version() || rt.OS != runtime.GOOS || rt.Arch != runtime.GOARCH {
+			t.Errorf("runtime = %+v, want the running Go runtime", rt)
+		}
+		if rt.NumCPU != runtime.NumCPU() || rt.GOMAXPROCS != runtime.GOMAXPROCS(0) || rt.PID != os.Getpid() {
+			t.Errorf("runtime = %+v, want this process", rt)
+		}
+		if rt.StartTime.IsZero() {
+			t.Error("StartTime is not set")
+		}
+
+		// Test binaries carry build info
+		build, ok := debug.ReadBuildInfo()
+		if !ok {
+			t.Skip("no build info")
+		}
+		if inv.Build == nil || inv.Build.GoVersion != build.GoVersion || inv.Build.Path != build.Main.Path {
+			t.Errorf("Build = %+v, want the binary's build info", inv.Build)
+		}
+		wantDeps := 0
+		if withDeps {
+			wantDeps = len(build.Deps)
+		}
+		if len(inv.Dependencies) != wantDeps {
+			t.Errorf("includeDependencies=%v reported %d dependencies, want %d", withDeps, len(inv.Dependencies), wantDeps)
+		}
+		for _, dep := range inv.Dependencies {
+			if dep.Path == "" || dep.Version == "" {
+				t.Errorf("dependency %+v is missing its path or version", dep)
+			}
+		}
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	got := dependencies([]*debug.Module{
+		{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:a"},
+		{Path: "example.com/b", Version: "v1.2.0", Replace: &debug.Module{Path: "../b", Version: "v0.0.0"}},
+	})
+	want := []types.Dependency{
+		{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:a"},
+		{Path: "example.com/b", Version: "v1.2.0", Replace: "../b@v0.0.0"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("dependencies = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+// sdk-go/internal/inventory/reporter.go
+package inventory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+)
+
+// DefaultInterval is the time between automatic reports
+const DefaultInterval = time.Hour
+
+// ReportFunc sends one inventory report
+type ReportFunc func(context.Context) error
+
+// Reporter sends an inventory report at startup and then on a fixed interval
+type Reporter struct {
+	report ReportFunc
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewReporter starts reporting in the background
+func NewReporter(interval time.Duration, report ReportFunc) *Reporter {
+	if report == nil {
+		panic("report function cannot be nil")
+	}
+
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	r := &Reporter{
+		report: report,
+		ticker: time.NewTicker(interval),
+		done:   make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.run()
+
+	return r
+}
+
+func (r *Reporter) run() {
+	defer r.wg.Done()
+
+	r.send()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.ticker.C:
+			r.send()
+		}
+	}
+}
+
+func (r *Reporter) send() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := r.report(ctx); err != nil {
+		logger.Warn("Inventory report failed: %v", err)
+	}
+}
+
+// Close stops the reporter and waits for an in-flight report to finish
+func (r *Reporter) Close() {
+	r.once.Do(func() {
+		r.ticker.Stop()
+		close(r.done)
+	})
+	r.wg.Wait()
+}
@@ -0,0 +1,202 @@
+// sdk-go/internal/logbridge/logger.go
+package logbridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// badKey is used for values passed without a string key, as log/slog does
+const badKey = "!BADKEY"
+
+// Logger sends leveled log entries with a fixed service, source and set of fields.
+// Loggers are immutable and safe for concurrent use; With derives children that
+// share the parent's fields.
+type Logger struct {
+	log       LogFunc
+	service   string
+	source    string
+	sessionID []byte
+	level     types.LogLevel
+	fields    map[string]interface{} // Never modified after creation
+}
+
+// LoggerOption configures a Logger
+type LoggerOption func(*Logger)
+
+// WithSource sets the source of every entry, replacing the hostname default
+func WithSource(source string) LoggerOption {
+	return func(l *Logger) {
+		if source != "" {
+			l.source = source
+		}
+	}
+}
+
+// WithSessionID sets the session of every entry, taking precedence over the context
+func WithSessionID(sessionID []byte) LoggerOption {
+	return func(l *Logger) {
+		l.sessionID = sessionID
+	}
+}
+
+// WithLevel sets the least severe level sent; entries below it are dropped.
+// The default sends every level.
+func WithLevel(level types.LogLevel) LoggerOption {
+	return func(l *Logger) {
+		l.level = level
+	}
+}
+
+// WithFields adds fields to every entry
+func WithFields(fields map[string]interface{}) LoggerOption {
+	return func(l *Logger) {
+		merged := cloneMap(l.fields)
+		for k, v := range fields {
+			merged[k] = fieldValue(v)
+		}
+		l.fields = merged
+	}
+}
+
+// NewLogger creates a logger for service that passes entries to log
+func NewLogger(log LogFunc, service, source string, opts ...LoggerOption) *Logger {
+	if log == nil {
+		panic("log function cannot be nil")
+	}
+	l := &Logger{
+		log:     log,
+		service: service,
+		source:  source,
+		level:   types.LogTrace,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// With returns a child logger that adds the key/value pairs to every entry
+func (l *Logger) With(kv ...interface{}) *Logger {
+	if len(kv) == 0 {
+		return l
+	}
+	child := *l
+	child.fields = cloneMap(l.fields)
+	addPairs(child.fields, kv)
+	return &child
+}
+
+// Enabled reports whether entries at level are sent
+func (l *Logger) Enabled(level types.LogLevel) bool {
+	return level <= l.level
+}
+
+// Log sends an entry at level with the logger's fields and the key/value pairs.
+// Keys are strings followed by their value; a map[string]interface{} or
+// Properties argument is merged as is.
+func (l *Logger) Log(ctx context.Context, level types.LogLevel, msg string, kv ...interface{}) error {
+	if !l.Enabled(level) {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	data := l.fields
+	if len(kv) > 0 {
+		data = cloneMap(l.fields)
+		addPairs(data, kv)
+	}
+
+	return l.log(ctx, types.LogEntry{
+		EventType: types.LogCollect,
+		Level:     level,
+		Timestamp: time.Now(),
+		SessionID: l.sessionID,
+		Service:   l.service,
+		Source:    l.source,
+		Message:   msg,
+		Data:      data,
+	})
+}
+
+// Trace sends a TRACE entry
+func (l *Logger) Trace(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogTrace, msg, kv...)
+}
+
+// Debug sends a DEBUG entry
+func (l *Logger) Debug(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogDebug, msg, kv...)
+}
+
+// Info sends an INFO entry
+func (l *Logger) Info(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogInfo, msg, kv...)
+}
+
+// Notice sends a NOTICE entry
+func (l *Logger) Notice(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogNotice, msg, kv...)
+}
+
+// Warn sends a WARNING entry
+func (l *Logger) Warn(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogWarning, msg, kv...)
+}
+
+// Error sends an ERROR entry
+func (l *Logger) Error(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogError, msg, kv...)
+}
+
+// Critical sends a CRITICAL entry
+func (l *Logger) Critical(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogCritical, msg, kv...)
+}
+
+// Alert sends an ALERT entry
+func (l *Logger) Alert(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogAlert, msg, kv...)
+}
+
+// Emergency sends an EMERGENCY entry
+func (l *Logger) Emergency(ctx context.Context, msg string, kv ...interface{}) error {
+	return l.Log(ctx, types.LogEmergency, msg, kv...)
+}
+
+// addPairs stores key/value pairs in m
+func addPairs(m map[string]interface{}, kv []interface{}) {
+	for i := 0; i < len(kv); i++ {
+		switch k := kv[i].(type) {
+		case string:
+			if i+1 == len(kv) {
+				m[badKey] = k
+				return
+			}
+			m[k] = fieldValue(kv[i+1])
+			i++
+		case map[string]interface{}:
+			for fk, fv := range k {
+				m[fk] = fieldValue(fv)
+			}
+		case types.Properties:
+			for fk, fv := range k {
+				m[fk] = fieldValue(fv)
+			}
+		default:
+			m[badKey] = fieldValue(k)
+		}
+	}
+}
+
+// fieldValue stores errors by their message, which is all they encode to
+func fieldValue(v interface{}) interface{} {
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return v
+}
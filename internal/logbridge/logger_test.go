@@ -0,0 +1,133 @@
+// sdk-go/internal/logbridge/logger_test.go
+package logbridge
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestLoggerEntry(t *testing.T) {
+	rec := &recorder{}
+	session := []byte("session-id-00000")
+	l := NewLogger(rec.log, "api", "host-1", WithSource("worker-1"), WithSessionID(session))
+
+	l.Warn(context.Background(), "slow request")
+	entry := rec.last(t)
+	if entry.EventType != types.LogCollect || entry.Level != types.LogWarning || entry.Message != "slow request" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Service != "api" || entry.Source != "worker-1" || string(entry.SessionID) != string(session) {
+		t.Errorf("service/source/session = %q/%q/%q", entry.Service, entry.Source, entry.SessionID)
+	}
+	if entry.Timestamp.IsZero() {
+		t.Error("timestamp is not set")
+	}
+
+	// An empty source keeps the default
+	NewLogger(rec.log, "api", "host-1", WithSource("")).Info(nil, "msg")
+	if got := rec.last(t).Source; got != "host-1" {
+		t.Errorf("Source = %q, want host-1", got)
+	}
+}
+
+func TestLoggerPairs(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   []interface{}
+		want map[string]interface{}
+	}{
+		{name: "no pairs", want: nil},
+		{name: "string keys", kv: []interface{}{"a", 1, "b", "x"}, want: map[string]interface{}{"a": 1, "b": "x"}},
+		{name: "odd trailing key", kv: []interface{}{"a", 1, "dangling"}, want: map[string]interface{}{"a": 1, badKey: "dangling"}},
+		{name: "non-string key", kv: []interface{}{42, "a", 1}, want: map[string]interface{}{badKey: 42, "a": 1}},
+		{
+			name: "maps are merged",
+			kv:   []interface{}{map[string]interface{}{"a": 1}, types.Properties{"b": 2}, "c", 3},
+			want: map[string]interface{}{"a": 1, "b": 2, "c": 3},
+		},
+		{
+			name: "errors are stored by message",
+			kv:   []interface{}{"err", errors.New("boom"), map[string]interface{}{"cause": errors.New("io")}, errors.New("bare")},
+			want: map[string]interface{}{"err": "boom", "cause": "io", badKey: "bare"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			NewLogger(rec.log, "api", "host-1").Info(context.Background(), "msg", tt.kv...)
+			got := rec.last(t).Data
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Data = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerWith(t *testing.T) {
+	rec := &recorder{}
+	parent := NewLogger(rec.log, "api", "host-1", WithFields(map[string]interface{}{"env": "prod", "err": errors.New("x")}))
+	if got := parent.With(); got != parent {
+		t.Error("With without pairs returned a new logger")
+	}
+
+	child := parent.With("request_id", "r1", "env", "staging")
+	grandchild := child.With("step", 2)
+
+	parent.Info(context.Background(), "parent", "n", 1)
+	child.Info(context.Background(), "child")
+	grandchild.Info(context.Background(), "grandchild")
+	parent.Info(context.Background(), "parent again")
+
+	want := []map[string]interface{}{
+		{"env": "prod", "err": "x", "n": 1},
+		{"env": "staging", "err": "x", "request_id": "r1"},
+		{"env": "staging", "err": "x", "request_id": "r1", "step": 2},
+		{"env": "prod", "err": "x"},
+	}
+	for i, w := range want {
+		if !reflect.DeepEqual(rec.entries[i].Data, w) {
+			t.Errorf("entry %d Data = %v, want %v", i, rec.entries[i].Data, w)
+		}
+	}
+	if !reflect.DeepEqual(parent.fields, map[string]interface{}{"env": "prod", "err": "x"}) {
+		t.Errorf("parent fields = %v after deriving children and logging", parent.fields)
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	rec := &recorder{}
+	l := NewLogger(rec.log, "api", "host-1", WithLevel(types.LogWarning))
+	ctx := context.Background()
+
+	l.Trace(ctx, "trace")
+	l.Debug(ctx, "debug")
+	l.Info(ctx, "info")
+	l.Notice(ctx, "notice")
+	l.Warn(ctx, "warn")
+	l.Error(ctx, "error")
+	l.Critical(ctx, "critical")
+	l.Alert(ctx, "alert")
+	l.Emergency(ctx, "emergency")
+
+	var got []string
+	for _, e := range rec.entries {
+		got = append(got, e.Message)
+	}
+	if want := []string{"warn", "error", "critical", "alert", "emergency"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent %v, want %v", got, want)
+	}
+	if !l.Enabled(types.LogError) || l.Enabled(types.LogInfo) {
+		t.Error("Enabled does not match the level")
+	}
+	if !NewLogger(rec.log, "api", "").Enabled(types.LogTrace) {
+		t.Error("default level drops TRACE")
+	}
+}
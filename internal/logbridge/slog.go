@@ -0,0 +1,262 @@
+// sdk-go/internal/logbridge/slog.go
+package logbridge
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Additional slog levels for the syslog severities slog does not define.
+// They slot between and around the standard levels so ordering is preserved.
+const (
+	LevelTrace     slog.Level = -8
+	LevelNotice    slog.Level = 2
+	LevelCritical  slog.Level = 12
+	LevelAlert     slog.Level = 16
+	LevelEmergency slog.Level = 20
+)
+
+// LogFunc sends one log entry, normally Client.Log
+type LogFunc func(context.Context, types.LogEntry) error
+
+// HandlerOptions configures a slog handler
+type HandlerOptions struct {
+	Service string // Required
+	Source  string // Defaults to the hostname
+
+	// Level is the minimum level handled; defaults to slog.LevelInfo
+	Level slog.Leveler
+
+	// AddSource records the calling function, file and line under Data["source"]
+	AddSource bool
+
+	// ReplaceAttr rewrites or drops attributes before they are stored, as in slog.HandlerOptions
+	ReplaceAttr func(groups []string, a slog.Attr) slog.Attr
+}
+
+// Handler is a slog.Handler that sends records through the SDK log pipeline.
+// Handlers are immutable; WithAttrs and WithGroup share all unchanged state.
+type Handler struct {
+	log  LogFunc
+	opts HandlerOptions
+
+	// base holds attributes bound with WithAttrs, already nested under their groups.
+	// It is never modified after creation.
+	base   map[string]interface{}
+	groups []string
+}
+
+// NewHandler creates a handler that passes records to log
+func NewHandler(log LogFunc, opts HandlerOptions) *Handler {
+	if log == nil {
+		panic("log function cannot be nil")
+	}
+	if opts.Level == nil {
+		opts.Level = slog.LevelInfo
+	}
+	return &Handler{log: log, opts: opts}
+}
+
+// Enabled reports whether records at level are handled
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle converts the record to a LogEntry
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	data := h.base
+	if r.NumAttrs() > 0 || h.opts.AddSource {
+		data = cloneMap(h.base)
+		if h.opts.AddSource && r.PC != 0 {
+			frames := runtime.CallersFrames([]uintptr{r.PC})
+			f, _ := frames.Next()
+			data[slog.SourceKey] = map[string]interface{}{
+				"function": f.Function,
+				"file":     f.File,
+				"line":     f.Line,
+			}
+		}
+		if r.NumAttrs() > 0 {
+			target := groupMap(data, h.groups)
+			r.Attrs(func(a slog.Attr) bool {
+				h.addAttr(target, h.groups, a)
+				return true
+			})
+			pruneEmpty(data, h.groups)
+		}
+	}
+
+	timestamp := r.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return h.log(ctx, types.LogEntry{
+		EventType: types.LogCollect,
+		Level:     ToLogLevel(r.Level),
+		Timestamp: timestamp,
+		Service:   h.opts.Service,
+		Source:    h.opts.Source,
+		Message:   r.Message,
+		Data:      data,
+	})
+}
+
+// WithAttrs returns a handler that adds attrs to every record
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.base = cloneMap(h.base)
+	target := groupMap(h2.base, h.groups)
+	for _, a := range attrs {
+		h2.addAttr(target, h.groups, a)
+	}
+	pruneEmpty(h2.base, h.groups)
+	return &h2
+}
+
+// WithGroup returns a handler that nests later attributes under name
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h2
+}
+
+// addAttr stores a into m following the slog handler rules
+func (h *Handler) addAttr(m map[string]interface{}, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if h.opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+		a = h.opts.ReplaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return
+		}
+		// Groups with an empty key are inlined
+		if a.Key == "" {
+			for _, ga := range attrs {
+				h.addAttr(m, groups, ga)
+			}
+			return
+		}
+		// Copy rather than extend an existing group, which may be shared with other handlers
+		existing, _ := m[a.Key].(map[string]interface{})
+		child := cloneMap(existing)
+		childGroups := append(groups[:len(groups):len(groups)], a.Key)
+		for _, ga := range attrs {
+			h.addAttr(child, childGroups, ga)
+		}
+		if len(child) > 0 {
+			m[a.Key] = child
+		}
+		return
+	}
+
+	if a.Key == "" {
+		return
+	}
+	m[a.Key] = attrValue(a.Value)
+}
+
+// attrValue converts a resolved, non-group slog value into a Data value
+func attrValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time()
+	default:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return v.Any()
+	}
+}
+
+// ToLogLevel maps a slog level, including the extra levels above, to a syslog severity
+func ToLogLevel(l slog.Level) types.LogLevel {
+	switch {
+	case l < slog.LevelDebug:
+		return types.LogTrace
+	case l < slog.LevelInfo:
+		return types.LogDebug
+	case l < LevelNotice:
+		return types.LogInfo
+	case l < slog.LevelWarn:
+		return types.LogNotice
+	case l < slog.LevelError:
+		return types.LogWarning
+	case l < LevelCritical:
+		return types.LogError
+	case l < LevelAlert:
+		return types.LogCritical
+	case l < LevelEmergency:
+		return types.LogAlert
+	default:
+		return types.LogEmergency
+	}
+}
+
+// groupMap returns the map for the group path, creating copies of the maps on the way
+// so that maps shared with other handlers are never modified
+func groupMap(m map[string]interface{}, groups []string) map[string]interface{} {
+	for _, g := range groups {
+		child, _ := m[g].(map[string]interface{})
+		child = cloneMap(child)
+		m[g] = child
+		m = child
+	}
+	return m
+}
+
+// pruneEmpty removes group maps left empty along the path, as slog requires
+func pruneEmpty(m map[string]interface{}, groups []string) {
+	if len(groups) == 0 {
+		return
+	}
+	child, ok := m[groups[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	pruneEmpty(child, groups[1:])
+	if len(child) == 0 {
+		delete(m, groups[0])
+	}
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m)+4)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
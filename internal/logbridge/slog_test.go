@@ -0,0 +1,204 @@
+// sdk-go/internal/logbridge/slog_test.go
+package logbridge
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// recorder is a LogFunc that keeps every entry it is given
+type recorder struct {
+	entries []types.LogEntry
+}
+
+func (r *recorder) log(_ context.Context, entry types.LogEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *recorder) last(t *testing.T) types.LogEntry {
+	t.Helper()
+	if len(r.entries) == 0 {
+		t.Fatal("no entry logged")
+	}
+	return r.entries[len(r.entries)-1]
+}
+
+type userValue struct{ id int }
+
+func (u userValue) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("id", u.id), slog.String("kind", "user"))
+}
+
+func TestHandlerAttrs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts HandlerOptions
+		log  func(*slog.Logger)
+		want map[string]interface{}
+	}{
+		{
+			name: "record attributes",
+			log: func(l *slog.Logger) {
+				l.Info("msg", "s", "v", "n", 3, "f", 1.5, "b", true, "d", 2*time.Second, "err", errors.New("boom"))
+			},
+			want: map[string]interface{}{"s": "v", "n": int64(3), "f": 1.5, "b": true, "d": "2s", "err": "boom"},
+		},
+		{
+			name: "bound attributes",
+			log:  func(l *slog.Logger) { l.With("request_id", "r1").Info("msg", "n", 1) },
+			want: map[string]interface{}{"request_id": "r1", "n": int64(1)},
+		},
+		{
+			name: "groups nest later attributes",
+			log: func(l *slog.Logger) {
+				l.With("top", 1).WithGroup("http").With("method", "GET").WithGroup("resp").Info("msg", "status", 200)
+			},
+			want: map[string]interface{}{
+				"top": int64(1),
+				"http": map[string]interface{}{
+					"method": "GET",
+					"resp":   map[string]interface{}{"status": int64(200)},
+				},
+			},
+		},
+		{
+			name: "group with no attributes is dropped",
+			log:  func(l *slog.Logger) { l.WithGroup("empty").Info("msg") },
+			want: nil,
+		},
+		{
+			name: "group attribute",
+			log:  func(l *slog.Logger) { l.Info("msg", slog.Group("req", "id", 7)) },
+			want: map[string]interface{}{"req": map[string]interface{}{"id": int64(7)}},
+		},
+		{
+			name: "empty group attribute is dropped",
+			log:  func(l *slog.Logger) { l.Info("msg", slog.Group("req"), "n", 1) },
+			want: map[string]interface{}{"n": int64(1)},
+		},
+		{
+			name: "group attribute with an empty key is inlined",
+			log:  func(l *slog.Logger) { l.Info("msg", slog.Group("", "a", 1, "b", 2)) },
+			want: map[string]interface{}{"a": int64(1), "b": int64(2)},
+		},
+		{
+			name: "attributes with an empty key are dropped",
+			log:  func(l *slog.Logger) { l.Info("msg", slog.Any("", "x"), "n", 1) },
+			want: map[string]interface{}{"n": int64(1)},
+		},
+		{
+			name: "values are resolved",
+			log:  func(l *slog.Logger) { l.Info("msg", "user", userValue{42}) },
+			want: map[string]interface{}{"user": map[string]interface{}{"id": int64(42), "kind": "user"}},
+		},
+		{
+			name: "ReplaceAttr sees groups and can drop attributes",
+			opts: HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == "password" {
+					return slog.Attr{}
+				}
+				if len(groups) > 0 && a.Key == "id" {
+					return slog.String("id", groups[0]+"-"+a.Value.String())
+				}
+				return a
+			}},
+			log:  func(l *slog.Logger) { l.WithGroup("user").Info("msg", "id", "7", "password", "x") },
+			want: map[string]interface{}{"user": map[string]interface{}{"id": "user-7"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			tt.opts.Service = "api"
+			tt.opts.Source = "host-1"
+			tt.log(slog.New(NewHandler(rec.log, tt.opts)))
+
+			entry := rec.last(t)
+			if len(entry.Data) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(entry.Data, tt.want) {
+				t.Errorf("Data = %#v, want %#v", entry.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerSiblingsDoNotShareAttrs(t *testing.T) {
+	rec := &recorder{}
+	parent := slog.New(NewHandler(rec.log, HandlerOptions{Service: "api"})).WithGroup("g").With("shared", 1)
+	a := parent.With("a", 1)
+	b := parent.With("b", 2)
+
+	a.Info("a")
+	b.Info("b")
+	parent.Info("parent")
+
+	want := []map[string]interface{}{
+		{"g": map[string]interface{}{"shared": int64(1), "a": int64(1)}},
+		{"g": map[string]interface{}{"shared": int64(1), "b": int64(2)}},
+		{"g": map[string]interface{}{"shared": int64(1)}},
+	}
+	for i, w := range want {
+		if !reflect.DeepEqual(rec.entries[i].Data, w) {
+			t.Errorf("entry %d Data = %v, want %v", i, rec.entries[i].Data, w)
+		}
+	}
+}
+
+func TestHandlerRecord(t *testing.T) {
+	rec := &recorder{}
+	h := NewHandler(rec.log, HandlerOptions{Service: "api", Source: "host-1", Level: slog.LevelWarn, AddSource: true})
+	logger := slog.New(h)
+
+	logger.Info("dropped")
+	if len(rec.entries) != 0 {
+		t.Fatalf("logged %d entries below the level", len(rec.entries))
+	}
+
+	logger.Error("failed")
+	entry := rec.last(t)
+	if entry.Message != "failed" || entry.Level != types.LogError || entry.Service != "api" || entry.Source != "host-1" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if entry.Timestamp.IsZero() {
+		t.Error("timestamp is not set")
+	}
+	source, ok := entry.Data[slog.SourceKey].(map[string]interface{})
+	if !ok || source["function"] != "github.com/usercanal/sdk-go/internal/logbridge.TestHandlerRecord" {
+		t.Errorf("source = %v, want the calling function", entry.Data[slog.SourceKey])
+	}
+}
+
+func TestToLogLevel(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  types.LogLevel
+	}{
+		{LevelTrace, types.LogTrace},
+		{slog.LevelDebug, types.LogDebug},
+		{slog.LevelInfo, types.LogInfo},
+		{LevelNotice, types.LogNotice},
+		{slog.LevelWarn, types.LogWarning},
+		{slog.LevelError, types.LogError},
+		{slog.LevelError + 1, types.LogError},
+		{LevelCritical, types.LogCritical},
+		{LevelAlert, types.LogAlert},
+		{LevelEmergency, types.LogEmergency},
+		{LevelEmergency + 100, types.LogEmergency},
+	}
+
+	for _, tt := range tests {
+		if got := ToLogLevel(tt.level); got != tt.want {
+			t.Errorf("ToLogLevel(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
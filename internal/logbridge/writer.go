@@ -0,0 +1,343 @@
+// sdk-go/internal/logbridge/writer.go
+package logbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	// DefaultMultilineWait is how long a line is held for continuation lines
+	DefaultMultilineWait = 100 * time.Millisecond
+
+	// maxLineBytes bounds a buffered partial line; longer input is sent as is
+	maxLineBytes = 64 * 1024
+
+	// maxEntryLines bounds the continuation lines folded into one entry
+	maxEntryLines = 200
+)
+
+// WriterOptions configures a Writer
+type WriterOptions struct {
+	Service string // Required
+	Source  string // Defaults to the hostname
+
+	// Level is used for lines without a recognised level; defaults to LogInfo.
+	// The zero value means unset, so LogEmergency cannot be the default.
+	Level types.LogLevel
+
+	// MultilineWait is how long a line is held for continuation lines such as
+	// stack traces written separately; defaults to DefaultMultilineWait
+	MultilineWait time.Duration
+
+	// Fields are added to the Data of every entry; keys parsed from a line win
+	Fields map[string]interface{}
+}
+
+// Writer is an io.Writer that turns each written line into a LogEntry.
+// It is safe for concurrent use and suitable for log.SetOutput.
+//
+// Levels are parsed from prefixes such as "ERROR:", "[warn]" or "level=debug",
+// after any standard log package date and file prefix. Lines holding a JSON object
+// become structured Data, and indented or stack trace lines are folded into the
+// preceding entry under Data["stack"].
+type Writer struct {
+	log  LogFunc
+	opts WriterOptions
+
+	mu      sync.Mutex
+	partial []byte          // Bytes after the last newline
+	pending *types.LogEntry // Entry waiting for continuation lines
+	stack   []string
+	inTrace bool // A "goroutine N [...]:" header has been seen for pending
+	timer   *time.Timer
+
+	closeOnce sync.Once
+	onClose   func()
+}
+
+// NewWriter creates a writer that passes entries to log
+func NewWriter(log LogFunc, opts WriterOptions) *Writer {
+	if log == nil {
+		panic("log function cannot be nil")
+	}
+	if opts.Level == types.LogEmergency {
+		opts.Level = types.LogInfo
+	}
+	if opts.MultilineWait <= 0 {
+		opts.MultilineWait = DefaultMultilineWait
+	}
+	if len(opts.Fields) > 0 {
+		// Copy so later changes by the caller do not reach entries
+		opts.Fields = cloneMap(opts.Fields)
+	}
+	return &Writer{log: log, opts: opts}
+}
+
+// Write splits p into lines and sends complete entries. It always reports
+// len(p) written so that callers such as log.Logger never see an error.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := p
+	if len(w.partial) > 0 {
+		data = append(w.partial, p...)
+		w.partial = nil
+	}
+
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		w.addLine(string(bytes.TrimSuffix(data[:i], []byte{'\r'})))
+		data = data[i+1:]
+	}
+
+	if len(data) > maxLineBytes {
+		w.addLine(string(data))
+		data = nil
+	}
+	if len(data) > 0 {
+		w.partial = append([]byte(nil), data...)
+	}
+
+	if w.pending != nil {
+		w.armTimer()
+	}
+	return len(p), nil
+}
+
+// Flush sends any buffered partial line and held entry
+func (w *Writer) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) > 0 {
+		w.addLine(string(w.partial))
+		w.partial = nil
+	}
+	w.sendPending()
+}
+
+// OnClose sets a function called once when the writer is closed
+func (w *Writer) OnClose(fn func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onClose = fn
+}
+
+// Close flushes the writer
+func (w *Writer) Close() error {
+	w.Flush()
+	w.closeOnce.Do(func() {
+		w.mu.Lock()
+		fn := w.onClose
+		w.mu.Unlock()
+		if fn != nil {
+			fn()
+		}
+	})
+	return nil
+}
+
+// addLine either folds line into the pending entry or starts a new one
+func (w *Writer) addLine(line string) {
+	if w.pending != nil && w.isContinuation(line) {
+		if len(w.stack) < maxEntryLines {
+			w.stack = append(w.stack, line)
+		}
+		if isGoroutineHeader(line) {
+			w.inTrace = true
+		}
+		return
+	}
+
+	w.sendPending()
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	entry := w.parse(line)
+	w.pending = &entry
+	w.inTrace = false
+}
+
+// isContinuation reports whether line belongs to the pending entry
+func (w *Writer) isContinuation(line string) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return true
+	}
+	if isGoroutineHeader(line) || strings.HasPrefix(line, "created by ") {
+		return true
+	}
+	// Function lines of a Go stack trace are not indented, e.g. "main.main()"
+	return w.inTrace && strings.HasSuffix(line, ")")
+}
+
+func (w *Writer) sendPending() {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if w.pending == nil {
+		return
+	}
+	entry := *w.pending
+	if stack := strings.TrimSpace(strings.Join(w.stack, "\n")); stack != "" {
+		if entry.Data == nil {
+			entry.Data = make(map[string]interface{}, 1)
+		}
+		entry.Data["stack"] = stack
+	}
+	if len(w.opts.Fields) > 0 {
+		// entry.Data belongs to this entry, so fields are added in place
+		if entry.Data == nil {
+			entry.Data = make(map[string]interface{}, len(w.opts.Fields))
+		}
+		for k, v := range w.opts.Fields {
+			if _, ok := entry.Data[k]; !ok {
+				entry.Data[k] = v
+			}
+		}
+	}
+	w.pending = nil
+	w.stack = nil
+	w.inTrace = false
+
+	// Errors from the log pipeline are already reported through the SDK logger
+	w.log(context.Background(), entry)
+}
+
+// armTimer sends the pending entry if no continuation arrives in time
+func (w *Writer) armTimer() {
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.opts.MultilineWait, func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			w.sendPending()
+		})
+		return
+	}
+	w.timer.Reset(w.opts.MultilineWait)
+}
+
+// parse builds an entry from the first line of a record
+func (w *Writer) parse(line string) types.LogEntry {
+	entry := types.LogEntry{
+		EventType: types.LogCollect,
+		Level:     w.opts.Level,
+		Timestamp: time.Now(),
+		Service:   w.opts.Service,
+		Source:    w.opts.Source,
+	}
+
+	line = stdPrefix.ReplaceAllString(line, "")
+
+	if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "{") {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &data); err == nil && len(data) > 0 {
+			parseJSON(&entry, data)
+			return entry
+		}
+	}
+
+	if level, rest, ok := ParseLevelPrefix(line); ok && rest != "" {
+		entry.Level = level
+		line = rest
+	}
+	entry.Message = line
+	return entry
+}
+
+// parseJSON lifts the conventional message, level and time keys out of data
+func parseJSON(entry *types.LogEntry, data map[string]interface{}) {
+	for _, key := range []string{"msg", "message"} {
+		if s, ok := data[key].(string); ok {
+			entry.Message = s
+			delete(data, key)
+			break
+		}
+	}
+	for _, key := range []string{"level", "lvl", "severity"} {
+		if s, ok := data[key].(string); ok {
+			if level, ok := LevelFromName(s); ok {
+				entry.Level = level
+				delete(data, key)
+			}
+			break
+		}
+	}
+	for _, key := range []string{"time", "ts", "timestamp"} {
+		if s, ok := data[key].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				entry.Timestamp = t
+				delete(data, key)
+			}
+			break
+		}
+	}
+	entry.Data = data
+}
+
+// stdPrefix matches the date, time and file prefixes added by the standard log package
+var stdPrefix = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d+)? )?([\w./-]+\.go:\d+: )?`)
+
+// levelPrefix matches a leading level such as "ERROR:", "[warn]", "<info>" or "level=debug"
+var levelPrefix = regexp.MustCompile(`^\s*(?:level=|lvl=)?[\[<(]?([A-Za-z]+)[\]>)]?(?::\s*|\s+|$)`)
+
+// ParseLevelPrefix extracts a leading level from line, returning the rest of the line
+func ParseLevelPrefix(line string) (types.LogLevel, string, bool) {
+	m := levelPrefix.FindStringSubmatchIndex(line)
+	if m == nil {
+		return 0, line, false
+	}
+	word := line[m[2]:m[3]]
+	// A capitalised word followed by a space is prose, as in "Error reading config"
+	bare := m[2] == 0 && m[1] > m[3] && line[m[3]] == ' ' && strings.ToUpper(word) != word
+	if bare {
+		return 0, line, false
+	}
+	level, ok := LevelFromName(word)
+	if !ok {
+		return 0, line, false
+	}
+	return level, line[m[1]:], true
+}
+
+// LevelFromName maps common level names and abbreviations to a LogLevel
+func LevelFromName(name string) (types.LogLevel, bool) {
+	switch strings.ToLower(name) {
+	case "trace", "trc":
+		return types.LogTrace, true
+	case "debug", "dbg":
+		return types.LogDebug, true
+	case "info", "inf":
+		return types.LogInfo, true
+	case "notice":
+		return types.LogNotice, true
+	case "warn", "warning", "wrn":
+		return types.LogWarning, true
+	case "error", "err":
+		return types.LogError, true
+	case "crit", "critical", "fatal", "panic":
+		return types.LogCritical, true
+	case "alert":
+		return types.LogAlert, true
+	case "emerg", "emergency":
+		return types.LogEmergency, true
+	}
+	return 0, false
+}
+
+// isGoroutineHeader matches "goroutine 1 [running]:"
+func isGoroutineHeader(line string) bool {
+	return strings.HasPrefix(line, "goroutine ") && strings.HasSuffix(line, "]:")
+}
@@ -0,0 +1,255 @@
+// sdk-go/internal/logbridge/writer_test.go
+package logbridge
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestWriterLines(t *testing.T) {
+	type entry struct {
+		message string
+		level   types.LogLevel
+		data    map[string]interface{}
+	}
+	tests := []struct {
+		name   string
+		opts   WriterOptions
+		writes []string
+		want   []entry
+	}{
+		{
+			name:   "plain line",
+			writes: []string{"server started\n"},
+			want:   []entry{{message: "server started", level: types.LogInfo}},
+		},
+		{
+			name:   "default level",
+			opts:   WriterOptions{Level: types.LogWarning},
+			writes: []string{"server started\n"},
+			want:   []entry{{message: "server started", level: types.LogWarning}},
+		},
+		{
+			name:   "level prefix",
+			writes: []string{"ERROR: disk full\n", "level=debug cache miss\n"},
+			want: []entry{
+				{message: "disk full", level: types.LogError},
+				{message: "cache miss", level: types.LogDebug},
+			},
+		},
+		{
+			name:   "standard log prefix",
+			writes: []string{"2024/01/02 15:04:05.123456 main.go:12: [warn] slow query\n"},
+			want:   []entry{{message: "slow query", level: types.LogWarning}},
+		},
+		{
+			name:   "prose is not a level",
+			writes: []string{"Error reading config\n"},
+			want:   []entry{{message: "Error reading config", level: types.LogInfo}},
+		},
+		{
+			name:   "JSON object",
+			writes: []string{`{"msg":"done","level":"warn","user":"u1","n":2}` + "\n"},
+			want: []entry{{
+				message: "done",
+				level:   types.LogWarning,
+				data:    map[string]interface{}{"user": "u1", "n": float64(2)},
+			}},
+		},
+		{
+			name:   "invalid JSON is a message",
+			writes: []string{"{not json\n"},
+			want:   []entry{{message: "{not json", level: types.LogInfo}},
+		},
+		{
+			name:   "lines split across writes",
+			writes: []string{"hel", "lo\nwor", "ld\r\n"},
+			want: []entry{
+				{message: "hello", level: types.LogInfo},
+				{message: "world", level: types.LogInfo},
+			},
+		},
+		{
+			name:   "partial line sent on flush",
+			writes: []string{"no newline"},
+			want:   []entry{{message: "no newline", level: types.LogInfo}},
+		},
+		{
+			name:   "indented lines folded",
+			writes: []string{"error: failed\n  at foo\n\tat bar\nnext\n"},
+			want: []entry{
+				{message: "failed", level: types.LogError, data: map[string]interface{}{"stack": "at foo\n\tat bar"}},
+				{message: "next", level: types.LogInfo},
+			},
+		},
+		{
+			name: "Go stack trace folded",
+			writes: []string{
+				"panic: boom\n\ngoroutine 1 [running]:\nmain.main()\n",
+				"\t/app/main.go:5 +0x1d\nexit status 2\n",
+			},
+			want: []entry{
+				{
+					message: "boom",
+					level:   types.LogCritical,
+					data:    map[string]interface{}{"stack": "goroutine 1 [running]:\nmain.main()\n\t/app/main.go:5 +0x1d"},
+				},
+				{message: "exit status 2", level: types.LogInfo},
+			},
+		},
+		{
+			name:   "blank lines skipped",
+			writes: []string{"\n\n"},
+		},
+		{
+			name: "fields added to every entry",
+			opts: WriterOptions{Fields: map[string]interface{}{"app": "legacy", "user": "none", "stack": "field"}},
+			writes: []string{
+				"plain\n",
+				`{"msg":"json","user":"u1"}` + "\n",
+				"error: failed\n  at foo\n",
+			},
+			want: []entry{
+				{message: "plain", level: types.LogInfo, data: map[string]interface{}{"app": "legacy", "user": "none", "stack": "field"}},
+				{message: "json", level: types.LogInfo, data: map[string]interface{}{"app": "legacy", "user": "u1", "stack": "field"}},
+				{message: "failed", level: types.LogError, data: map[string]interface{}{"app": "legacy", "user": "none", "stack": "at foo"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rec recorder
+			opts := tt.opts
+			opts.Service = "svc"
+			opts.MultilineWait = time.Hour
+			w := NewWriter(rec.log, opts)
+			for _, p := range tt.writes {
+				if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+					t.Fatalf("Write(%q) = %d, %v", p, n, err)
+				}
+			}
+			w.Flush()
+
+			if len(rec.entries) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(rec.entries), len(tt.want), rec.entries)
+			}
+			for i, want := range tt.want {
+				got := rec.entries[i]
+				if got.Message != want.message || got.Level != want.level {
+					t.Errorf("entry %d = %q at %v, want %q at %v", i, got.Message, got.Level, want.message, want.level)
+				}
+				if len(got.Data) != 0 || len(want.data) != 0 {
+					if !reflect.DeepEqual(got.Data, want.data) {
+						t.Errorf("entry %d data = %v, want %v", i, got.Data, want.data)
+					}
+				}
+				if got.Service != "svc" || got.EventType != types.LogCollect {
+					t.Errorf("entry %d service %q type %v", i, got.Service, got.EventType)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterJSONTime(t *testing.T) {
+	var rec recorder
+	w := NewWriter(rec.log, WriterOptions{Service: "svc"})
+	w.Write([]byte(`{"message":"m","time":"2024-01-02T03:04:05.5Z"}` + "\n"))
+	w.Close()
+
+	got := rec.last(t)
+	want := time.Date(2024, 1, 2, 3, 4, 5, 5e8, time.UTC)
+	if !got.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, want)
+	}
+	if _, ok := got.Data["time"]; ok {
+		t.Errorf("time left in data: %v", got.Data)
+	}
+}
+
+func TestWriterFieldsCopied(t *testing.T) {
+	var rec recorder
+	fields := map[string]interface{}{"app": "legacy"}
+	w := NewWriter(rec.log, WriterOptions{Service: "svc", Fields: fields})
+	fields["app"] = "changed"
+
+	w.Write([]byte("first\nsecond\n"))
+	w.Flush()
+	for i, entry := range rec.entries {
+		if entry.Data["app"] != "legacy" {
+			t.Errorf("entry %d app = %v, want legacy", i, entry.Data["app"])
+		}
+	}
+	rec.entries[0].Data["app"] = "modified"
+	if rec.entries[1].Data["app"] != "legacy" {
+		t.Error("entries share their Data map")
+	}
+}
+
+func TestWriterMultilineWait(t *testing.T) {
+	sent := make(chan types.LogEntry, 1)
+	w := NewWriter(func(_ context.Context, entry types.LogEntry) error {
+		sent <- entry
+		return nil
+	}, WriterOptions{Service: "svc", MultilineWait: 10 * time.Millisecond})
+	w.Write([]byte("waiting\n"))
+
+	select {
+	case entry := <-sent:
+		if entry.Message != "waiting" {
+			t.Errorf("Message = %q", entry.Message)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("entry not sent after the multiline wait")
+	}
+}
+
+func TestWriterOnClose(t *testing.T) {
+	var rec recorder
+	w := NewWriter(rec.log, WriterOptions{Service: "svc"})
+	calls := 0
+	w.OnClose(func() { calls++ })
+	w.Write([]byte("last words"))
+
+	w.Close()
+	w.Close()
+	if calls != 1 {
+		t.Errorf("OnClose called %d times, want 1", calls)
+	}
+	if rec.last(t).Message != "last words" {
+		t.Errorf("partial line not flushed on close")
+	}
+}
+
+func TestParseLevelPrefix(t *testing.T) {
+	tests := []struct {
+		line  string
+		level types.LogLevel
+		rest  string
+		ok    bool
+	}{
+		{"ERROR: disk full", types.LogError, "disk full", true},
+		{"[warn] slow", types.LogWarning, "slow", true},
+		{"<info> started", types.LogInfo, "started", true},
+		{"(debug) cache", types.LogDebug, "cache", true},
+		{"lvl=trc tick", types.LogTrace, "tick", true},
+		{"INFO started", types.LogInfo, "started", true},
+		{"WRN: slow", types.LogWarning, "slow", true},
+		{"FATAL", types.LogCritical, "", true},
+		{"Error reading config", 0, "Error reading config", false},
+		{"info started", 0, "info started", false},
+		{"foo: bar", 0, "foo: bar", false},
+		{"", 0, "", false},
+	}
+	for _, tt := range tests {
+		level, rest, ok := ParseLevelPrefix(tt.line)
+		if level != tt.level || rest != tt.rest || ok != tt.ok {
+			t.Errorf("ParseLevelPrefix(%q) = %v, %q, %v; want %v, %q, %v", tt.line, level, rest, ok, tt.level, tt.rest, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// sdk-go/internal/logdedupe/dedupe.go
+package logdedupe
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	// DefaultWindow is how long duplicates are folded before being reported
+	DefaultWindow = 5 * time.Second
+
+	// DefaultMaxKeys bounds the distinct entries tracked per window
+	DefaultMaxKeys = 10000
+)
+
+// EmitFunc receives folded entries
+type EmitFunc func(types.LogEntry)
+
+type group struct {
+	opened  time.Time // When the window opened, by the local clock
+	first   time.Time // Timestamps of the first and latest duplicate, for reporting
+	last    time.Time
+	repeats int64
+	entry   types.LogEntry // The latest duplicate
+}
+
+// Deduper folds identical log entries seen within a window
+type Deduper struct {
+	window  time.Duration
+	maxKeys int
+	emit    EmitFunc
+	now     func() time.Time
+
+	mu     sync.Mutex
+	groups map[uint64]*group
+	folded int64 // atomic
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// New creates a deduper that passes folded entries to emit
+func New(window time.Duration, maxKeys int, emit EmitFunc) *Deduper {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	if maxKeys <= 0 {
+		maxKeys = DefaultMaxKeys
+	}
+	d := &Deduper{
+		window:  window,
+		maxKeys: maxKeys,
+		emit:    emit,
+		now:     time.Now,
+		groups:  make(map[uint64]*group),
+		done:    make(chan struct{}),
+	}
+
+	d.wg.Add(1)
+	go d.run()
+	return d
+}
+
+// Add reports whether entry should be sent now. Duplicates of an entry sent within
+// the window return false and are counted towards its folded entry. Windows follow
+// the local clock, so entries with old or skewed timestamps are folded all the same.
+func (d *Deduper) Add(entry types.LogEntry) bool {
+	key := Key(entry)
+	now := d.now()
+	seen := entry.Timestamp
+	if seen.IsZero() {
+		seen = now
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	g, ok := d.groups[key]
+	if !ok {
+		// Past the limit, new kinds of entries pass through untracked
+		if len(d.groups) < d.maxKeys {
+			d.groups[key] = &group{opened: now, first: seen, last: seen}
+		}
+		return true
+	}
+
+	g.repeats++
+	g.last = seen
+	g.entry = entry
+	atomic.AddInt64(&d.folded, 1)
+	return false
+}
+
+// Folded returns how many entries have been folded into others
+func (d *Deduper) Folded() int64 {
+	return atomic.LoadInt64(&d.folded)
+}
+
+// Flush emits the folded entries of every open window
+func (d *Deduper) Flush() {
+	d.expire(time.Time{})
+}
+
+// Close stops the window timer and emits the remaining folded entries
+func (d *Deduper) Close() {
+	d.closeOnce.Do(func() {
+		close(d.done)
+		d.wg.Wait()
+		d.Flush()
+	})
+}
+
+func (d *Deduper) run() {
+	defer d.wg.Done()
+
+	// Check at a fraction of the window so no window stays open much longer than configured
+	ticker := time.NewTicker(d.window / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.expire(d.now().Add(-d.window))
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// expire closes windows opened before cutoff, or all of them for a zero cutoff
+func (d *Deduper) expire(cutoff time.Time) {
+	var out []types.LogEntry
+
+	d.mu.Lock()
+	for key, g := range d.groups {
+		if !cutoff.IsZero() && g.opened.After(cutoff) {
+			continue
+		}
+		delete(d.groups, key)
+		if g.repeats > 0 {
+			out = append(out, folded(g))
+		}
+	}
+	d.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	for _, entry := range out {
+		d.emit(entry)
+	}
+}
+
+// folded builds the entry reporting a group's duplicates
+func folded(g *group) types.LogEntry {
+	entry := g.entry
+	data := make(map[string]interface{}, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	data[types.RepeatCountKey] = g.repeats
+	data[types.FirstSeenKey] = g.first.UTC().Format(time.RFC3339Nano)
+	data[types.LastSeenKey] = g.last.UTC().Format(time.RFC3339Nano)
+	entry.Data = data
+	entry.Timestamp = g.last
+	return entry
+}
+
+// Key identifies an entry by service, level, message and the shape of its data.
+// Data values are ignored so that e.g. differing request IDs still collapse.
+func Key(entry types.LogEntry) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(entry.Service))
+	h.Write([]byte{0, byte(entry.Level)})
+	h.Write([]byte(entry.Message))
+	h.Write([]byte{0})
+	writeShape(h, entry.Data)
+	return h.Sum64()
+}
+
+// writeShape hashes the sorted keys of m, descending into nested maps
+func writeShape(h interface{ Write([]byte) (int, error) }, m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h.Write([]byte("{" + strconv.Itoa(len(keys))))
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		if child, ok := m[k].(map[string]interface{}); ok {
+			writeShape(h, child)
+		}
+	}
+	h.Write([]byte("}"))
+}
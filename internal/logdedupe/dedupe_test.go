@@ -0,0 +1,171 @@
+// sdk-go/internal/logdedupe/dedupe_test.go
+package logdedupe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestAddWindows(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	type step struct {
+		advance time.Duration // Local clock advance before the entry
+		message string
+		stamp   time.Time // Entry timestamp; zero uses the local clock
+		want    bool
+	}
+	type report struct {
+		message     string
+		repeats     int64
+		first, last time.Time
+	}
+	tests := []struct {
+		name  string
+		steps []step
+		want  []report
+	}{
+		{
+			name: "duplicates within the window are folded",
+			steps: []step{
+				{message: "a", want: true},
+				{advance: time.Second, message: "a", want: false},
+				{advance: time.Second, message: "a", want: false},
+			},
+			want: []report{{message: "a", repeats: 2, first: start, last: start.Add(2 * time.Second)}},
+		},
+		{
+			name: "different entries are not folded",
+			steps: []step{
+				{message: "a", want: true},
+				{message: "b", want: true},
+			},
+		},
+		{
+			name: "a new window opens after the window",
+			steps: []step{
+				{message: "a", want: true},
+				{advance: time.Second, message: "a", want: false},
+				{advance: 5 * time.Second, message: "a", want: true},
+				{advance: time.Second, message: "a", want: false},
+			},
+			want: []report{
+				{message: "a", repeats: 1, first: start, last: start.Add(time.Second)},
+				{message: "a", repeats: 1, first: start.Add(6 * time.Second), last: start.Add(7 * time.Second)},
+			},
+		},
+		{
+			name: "old timestamps do not close the window",
+			steps: []step{
+				{message: "a", stamp: start.Add(-time.Hour), want: true},
+				{advance: time.Second, message: "a", stamp: start.Add(-time.Hour + time.Minute), want: false},
+			},
+			want: []report{{message: "a", repeats: 1, first: start.Add(-time.Hour), last: start.Add(-time.Hour + time.Minute)}},
+		},
+		{
+			name: "future timestamps do not hold the window open",
+			steps: []step{
+				{message: "a", stamp: start.Add(time.Hour), want: true},
+				{advance: time.Second, message: "a", stamp: start.Add(time.Hour), want: false},
+				{advance: 5 * time.Second, message: "a", stamp: start.Add(time.Hour), want: true},
+			},
+			want: []report{{message: "a", repeats: 1, first: start.Add(time.Hour), last: start.Add(time.Hour)}},
+		},
+		{
+			name: "windows without duplicates report nothing",
+			steps: []step{
+				{message: "a", want: true},
+				{advance: 6 * time.Second, message: "a", want: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var emitted []types.LogEntry
+			d := New(5*time.Second, 0, func(e types.LogEntry) { emitted = append(emitted, e) })
+			d.Close() // Stop the ticker; windows are expired by hand below
+			now := start
+			d.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				d.expire(now.Add(-d.window))
+				entry := types.LogEntry{Service: "svc", Level: types.LogError, Message: s.message, Timestamp: s.stamp}
+				if got := d.Add(entry); got != s.want {
+					t.Errorf("step %d: Add(%q) = %v, want %v", i, s.message, got, s.want)
+				}
+			}
+			d.Flush()
+
+			if len(emitted) != len(tt.want) {
+				t.Fatalf("emitted %d entries, want %d: %+v", len(emitted), len(tt.want), emitted)
+			}
+			for i, want := range tt.want {
+				got := emitted[i]
+				if got.Message != want.message || got.Data[types.RepeatCountKey] != want.repeats {
+					t.Errorf("entry %d = %q repeated %v, want %q repeated %d", i, got.Message, got.Data[types.RepeatCountKey], want.message, want.repeats)
+				}
+				if first := want.first.Format(time.RFC3339Nano); got.Data[types.FirstSeenKey] != first {
+					t.Errorf("entry %d first seen %v, want %s", i, got.Data[types.FirstSeenKey], first)
+				}
+				if last := want.last.Format(time.RFC3339Nano); got.Data[types.LastSeenKey] != last {
+					t.Errorf("entry %d last seen %v, want %s", i, got.Data[types.LastSeenKey], last)
+				}
+				if !got.Timestamp.Equal(want.last) {
+					t.Errorf("entry %d timestamp %v, want %v", i, got.Timestamp, want.last)
+				}
+			}
+		})
+	}
+}
+
+func TestAddMaxKeys(t *testing.T) {
+	d := New(time.Hour, 2, func(types.LogEntry) {})
+	defer d.Close()
+
+	for _, msg := range []string{"a", "b", "c", "c"} {
+		if !d.Add(types.LogEntry{Message: msg}) {
+			t.Errorf("Add(%q) folded past the key limit", msg)
+		}
+	}
+	if d.Add(types.LogEntry{Message: "a"}) {
+		t.Error("tracked entry not folded")
+	}
+	if got := d.Folded(); got != 1 {
+		t.Errorf("Folded = %d, want 1", got)
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := types.LogEntry{
+		Service: "svc",
+		Level:   types.LogError,
+		Message: "failed",
+		Data:    map[string]interface{}{"request_id": "r1", "http": map[string]interface{}{"status": 500}},
+	}
+	tests := []struct {
+		name   string
+		change func(*types.LogEntry)
+		same   bool
+	}{
+		{name: "data values ignored", same: true, change: func(e *types.LogEntry) {
+			e.Data = map[string]interface{}{"request_id": "r2", "http": map[string]interface{}{"status": 502}}
+		}},
+		{name: "timestamp ignored", same: true, change: func(e *types.LogEntry) { e.Timestamp = time.Now() }},
+		{name: "service", change: func(e *types.LogEntry) { e.Service = "other" }},
+		{name: "level", change: func(e *types.LogEntry) { e.Level = types.LogWarning }},
+		{name: "message", change: func(e *types.LogEntry) { e.Message = "failed again" }},
+		{name: "data keys", change: func(e *types.LogEntry) {
+			e.Data = map[string]interface{}{"request_id": "r1", "http": map[string]interface{}{"code": 500}}
+		}},
+	}
+	for _, tt := range tests {
+		entry := base
+		tt.change(&entry)
+		if same := Key(entry) == Key(base); same != tt.same {
+			t.Errorf("%s: same key = %v, want %v", tt.name, same, tt.same)
+		}
+	}
+}
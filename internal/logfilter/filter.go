@@ -0,0 +1,359 @@
+// sdk-go/internal/logfilter/filter.go
+package logfilter
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	// DefaultSummaryInterval is how often suppression summaries are reported
+	DefaultSummaryInterval = time.Minute
+
+	// maxBuckets bounds the rate limiting keys held in memory
+	maxBuckets = 10000
+)
+
+// Reason records why an entry was dropped
+type Reason int
+
+const (
+	Allowed Reason = iota
+	BelowLevel
+	Sampled
+	RateLimited
+)
+
+// Summary counts the entries dropped for one service during a summary interval
+type Summary struct {
+	Service     string
+	BelowLevel  int64
+	Sampled     int64
+	RateLimited int64
+}
+
+// Total returns the number of entries dropped
+func (s Summary) Total() int64 {
+	return s.BelowLevel + s.Sampled + s.RateLimited
+}
+
+// SummaryFunc receives the summaries of services that dropped entries
+type SummaryFunc func([]Summary)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Filter decides which log entries are sent. Level checks are lock free; sampling
+// and rate limiting only run for entries that pass the level check.
+type Filter struct {
+	levels      atomic.Pointer[map[string]types.LogLevel]
+	levelsMu    sync.Mutex // Serialises level updates
+	sampleRates map[types.LogLevel]float64
+	rate        float64
+	burst       float64
+	now         func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	dropped map[string]*Summary
+	total   int64 // atomic
+
+	summarize SummaryFunc
+	interval  time.Duration
+	done      chan struct{}
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// Validate checks levels, sample rates and rate limits
+func Validate(cfg types.LogFilterConfig) error {
+	for service, level := range cfg.Levels {
+		if level > types.LogTrace {
+			return types.NewValidationError(fmt.Sprintf("Levels[%q]", service), "invalid log level")
+		}
+	}
+	for level, rate := range cfg.SampleRates {
+		if level > types.LogTrace {
+			return types.NewValidationError("SampleRates", fmt.Sprintf("invalid log level %d", level))
+		}
+		if rate < 0 || rate > 1 || math.IsNaN(rate) {
+			return types.NewValidationError(fmt.Sprintf("SampleRates[%s]", level), "must be between 0 and 1")
+		}
+	}
+	if cfg.RateLimit < 0 {
+		return types.NewValidationError("RateLimit", "cannot be negative")
+	}
+	if cfg.RateBurst < 0 {
+		return types.NewValidationError("RateBurst", "cannot be negative")
+	}
+	return nil
+}
+
+// New creates a filter from a validated cfg. summarize, if not nil, receives
+// periodic reports of dropped entries until Close. The reporter only runs once
+// a level, sample rate or rate limit that can drop entries is configured.
+func New(cfg types.LogFilterConfig, summarize SummaryFunc) *Filter {
+	f := &Filter{
+		sampleRates: cfg.SampleRates,
+		rate:        cfg.RateLimit,
+		burst:       float64(cfg.RateBurst),
+		now:         time.Now,
+		buckets:     make(map[string]*bucket),
+		dropped:     make(map[string]*Summary),
+		summarize:   summarize,
+		interval:    cfg.SummaryInterval,
+		done:        make(chan struct{}),
+	}
+	if f.burst == 0 {
+		f.burst = math.Ceil(f.rate)
+	}
+	if f.interval <= 0 {
+		f.interval = DefaultSummaryInterval
+	}
+
+	levels := make(map[string]types.LogLevel, len(cfg.Levels))
+	for service, level := range cfg.Levels {
+		levels[service] = level
+	}
+	f.levels.Store(&levels)
+
+	if f.canDrop(levels) {
+		f.startReporter()
+	}
+	return f
+}
+
+// canDrop reports whether levels or the sampling and rate limits can drop entries
+func (f *Filter) canDrop(levels map[string]types.LogLevel) bool {
+	if f.rate > 0 {
+		return true
+	}
+	for _, rate := range f.sampleRates {
+		if rate < 1 {
+			return true
+		}
+	}
+	for _, level := range levels {
+		if level < types.LogTrace {
+			return true
+		}
+	}
+	return false
+}
+
+// startReporter starts the summary reporter unless it is running or the filter is closed
+func (f *Filter) startReporter() {
+	if f.summarize == nil {
+		return
+	}
+	f.startOnce.Do(func() {
+		select {
+		case <-f.done:
+			return
+		default:
+		}
+		f.wg.Add(1)
+		go f.run()
+	})
+}
+
+// SetLevel changes the least severe level sent for service; "" sets the default
+func (f *Filter) SetLevel(service string, level types.LogLevel) {
+	f.levelsMu.Lock()
+	defer f.levelsMu.Unlock()
+
+	current := *f.levels.Load()
+	next := make(map[string]types.LogLevel, len(current)+1)
+	for k, v := range current {
+		next[k] = v
+	}
+	next[service] = level
+	f.levels.Store(&next)
+
+	if level < types.LogTrace {
+		f.startReporter()
+	}
+}
+
+// Level returns the least severe level sent for service
+func (f *Filter) Level(service string) types.LogLevel {
+	levels := *f.levels.Load()
+	if level, ok := levels[service]; ok {
+		return level
+	}
+	if level, ok := levels[""]; ok {
+		return level
+	}
+	return types.LogTrace
+}
+
+// Allow reports whether the entry should be sent, recording it as dropped if not
+func (f *Filter) Allow(service string, level types.LogLevel, message string) Reason {
+	reason := f.check(service, level, message)
+	if reason != Allowed {
+		f.record(service, reason)
+	}
+	return reason
+}
+
+func (f *Filter) check(service string, level types.LogLevel, message string) Reason {
+	if level > f.Level(service) {
+		return BelowLevel
+	}
+	if rate, ok := f.sampleRates[level]; ok && rate < 1 {
+		if rate == 0 || rand.Float64() >= rate {
+			return Sampled
+		}
+	}
+	if f.rate > 0 && !f.take(service+"\x00"+Template(message)) {
+		return RateLimited
+	}
+	return Allowed
+}
+
+// take removes a token from the key's bucket
+func (f *Filter) take(key string) bool {
+	now := f.now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, ok := f.buckets[key]
+	if !ok {
+		if len(f.buckets) >= maxBuckets {
+			f.evict(now)
+		}
+		b = &bucket{tokens: f.burst, last: now}
+		f.buckets[key] = b
+	} else {
+		b.tokens = math.Min(f.burst, b.tokens+now.Sub(b.last).Seconds()*f.rate)
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evict drops full buckets, which behave the same as new ones, or all of them
+// if none are full
+func (f *Filter) evict(now time.Time) {
+	for key, b := range f.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*f.rate >= f.burst {
+			delete(f.buckets, key)
+		}
+	}
+	if len(f.buckets) >= maxBuckets {
+		f.buckets = make(map[string]*bucket)
+	}
+}
+
+func (f *Filter) record(service string, reason Reason) {
+	atomic.AddInt64(&f.total, 1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.dropped[service]
+	if !ok {
+		s = &Summary{Service: service}
+		f.dropped[service] = s
+	}
+	switch reason {
+	case BelowLevel:
+		s.BelowLevel++
+	case Sampled:
+		s.Sampled++
+	case RateLimited:
+		s.RateLimited++
+	}
+}
+
+// Dropped returns the total number of entries dropped
+func (f *Filter) Dropped() int64 {
+	return atomic.LoadInt64(&f.total)
+}
+
+// Interval returns the summary interval
+func (f *Filter) Interval() time.Duration {
+	return f.interval
+}
+
+func (f *Filter) run() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.report()
+		case <-f.done:
+			f.report()
+			return
+		}
+	}
+}
+
+// report passes the summaries collected since the last report to the summary function
+func (f *Filter) report() {
+	f.mu.Lock()
+	dropped := f.dropped
+	f.dropped = make(map[string]*Summary)
+	f.mu.Unlock()
+
+	if len(dropped) == 0 {
+		return
+	}
+	summaries := make([]Summary, 0, len(dropped))
+	for _, s := range dropped {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Service < summaries[j].Service })
+	f.summarize(summaries)
+}
+
+// Close stops the summary reporter after a final report
+func (f *Filter) Close() {
+	f.closeOnce.Do(func() {
+		f.levelsMu.Lock()
+		close(f.done)
+		f.levelsMu.Unlock()
+		f.wg.Wait()
+	})
+}
+
+// Template reduces a message to its constant parts so that messages differing only
+// in IDs, counts or timings share a rate limit: words containing a digit become "#"
+func Template(message string) string {
+	if strings.IndexAny(message, "0123456789") < 0 {
+		return message
+	}
+	var b strings.Builder
+	b.Grow(len(message))
+	for i, word := range strings.Split(message, " ") {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if strings.IndexAny(word, "0123456789") >= 0 {
+			b.WriteByte('#')
+		} else {
+			b.WriteString(word)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,273 @@
+// sdk-go/internal/logfilter/filter_test.go
+package logfilter
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestAllowLevels(t *testing.T) {
+	f := New(types.LogFilterConfig{
+		Levels: map[string]types.LogLevel{"": types.LogWarning, "api": types.LogDebug},
+	}, nil)
+	defer f.Close()
+
+	tests := []struct {
+		service string
+		level   types.LogLevel
+		want    Reason
+	}{
+		{"worker", types.LogError, Allowed},
+		{"worker", types.LogWarning, Allowed},
+		{"worker", types.LogInfo, BelowLevel},
+		{"api", types.LogDebug, Allowed},
+		{"api", types.LogTrace, BelowLevel},
+	}
+	for _, tt := range tests {
+		if got := f.Allow(tt.service, tt.level, "msg"); got != tt.want {
+			t.Errorf("Allow(%q, %v) = %v, want %v", tt.service, tt.level, got, tt.want)
+		}
+	}
+	if got := f.Dropped(); got != 2 {
+		t.Errorf("Dropped = %d, want 2", got)
+	}
+
+	f.SetLevel("worker", types.LogTrace)
+	if got := f.Allow("worker", types.LogTrace, "msg"); got != Allowed {
+		t.Errorf("after SetLevel, Allow = %v, want Allowed", got)
+	}
+}
+
+func TestAllowSampling(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		min, max int
+	}{
+		{name: "never", rate: 0, min: 0, max: 0},
+		{name: "always", rate: 1, min: 1000, max: 1000},
+		{name: "half", rate: 0.5, min: 400, max: 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(types.LogFilterConfig{
+				SampleRates: map[types.LogLevel]float64{types.LogDebug: tt.rate},
+			}, nil)
+			defer f.Close()
+
+			allowed := 0
+			for i := 0; i < 1000; i++ {
+				if f.Allow("svc", types.LogDebug, "msg") == Allowed {
+					allowed++
+				}
+			}
+			if allowed < tt.min || allowed > tt.max {
+				t.Errorf("allowed %d of 1000, want %d to %d", allowed, tt.min, tt.max)
+			}
+			if got := f.Allow("svc", types.LogError, "msg"); got != Allowed {
+				t.Errorf("unsampled level = %v, want Allowed", got)
+			}
+		})
+	}
+}
+
+func TestAllowRateLimit(t *testing.T) {
+	type step struct {
+		advance time.Duration
+		message string
+		want    Reason
+	}
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		steps []step
+	}{
+		{
+			name: "burst then limited",
+			rate: 1, burst: 2,
+			steps: []step{
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: RateLimited},
+			},
+		},
+		{
+			name: "tokens refill over time",
+			rate: 2, burst: 1,
+			steps: []step{
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: RateLimited},
+				{advance: 250 * time.Millisecond, message: "retrying", want: RateLimited},
+				{advance: 250 * time.Millisecond, message: "retrying", want: Allowed},
+			},
+		},
+		{
+			name: "refill capped at burst",
+			rate: 10, burst: 2,
+			steps: []step{
+				{message: "retrying", want: Allowed},
+				{advance: time.Hour, message: "retrying", want: Allowed},
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: RateLimited},
+			},
+		},
+		{
+			name: "burst defaults to the rate",
+			rate: 1.5,
+			steps: []step{
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: Allowed},
+				{message: "retrying", want: RateLimited},
+			},
+		},
+		{
+			name: "messages differing in numbers share a bucket",
+			rate: 1, burst: 1,
+			steps: []step{
+				{message: "request 1 took 20ms", want: Allowed},
+				{message: "request 2 took 35ms", want: RateLimited},
+				{message: "cache cleared", want: Allowed},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(types.LogFilterConfig{RateLimit: tt.rate, RateBurst: tt.burst}, nil)
+			defer f.Close()
+			now := time.Unix(1700000000, 0)
+			f.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				if got := f.Allow("svc", types.LogInfo, s.message); got != s.want {
+					t.Errorf("step %d: Allow(%q) = %v, want %v", i, s.message, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitPerService(t *testing.T) {
+	f := New(types.LogFilterConfig{RateLimit: 1, RateBurst: 1}, nil)
+	defer f.Close()
+
+	if got := f.Allow("a", types.LogInfo, "msg"); got != Allowed {
+		t.Errorf("a = %v, want Allowed", got)
+	}
+	if got := f.Allow("b", types.LogInfo, "msg"); got != Allowed {
+		t.Errorf("b = %v, want Allowed", got)
+	}
+}
+
+func TestSummaries(t *testing.T) {
+	reports := make(chan []Summary, 10)
+	f := New(types.LogFilterConfig{
+		Levels:      map[string]types.LogLevel{"": types.LogInfo},
+		SampleRates: map[types.LogLevel]float64{types.LogWarning: 0},
+		RateLimit:   1,
+		RateBurst:   1,
+	}, func(s []Summary) { reports <- s })
+
+	f.Allow("b", types.LogDebug, "msg")
+	f.Allow("b", types.LogWarning, "msg")
+	f.Allow("a", types.LogInfo, "msg")
+	f.Allow("a", types.LogInfo, "msg")
+	f.Close()
+
+	want := []Summary{
+		{Service: "a", RateLimited: 1},
+		{Service: "b", BelowLevel: 1, Sampled: 1},
+	}
+	select {
+	case got := <-reports:
+		if len(got) != len(want) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("summary %d = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	default:
+		t.Fatal("no summary reported on Close")
+	}
+}
+
+func TestReporterStartsWithDroppingRule(t *testing.T) {
+	before := runtime.NumGoroutine()
+	filters := make([]*Filter, 50)
+	for i := range filters {
+		filters[i] = New(types.LogFilterConfig{
+			Levels:      map[string]types.LogLevel{"svc": types.LogTrace},
+			SampleRates: map[types.LogLevel]float64{types.LogDebug: 1},
+		}, func([]Summary) {})
+	}
+	if started := runtime.NumGoroutine() - before; started >= len(filters) {
+		t.Errorf("%d goroutines started for filters that cannot drop entries", started)
+	}
+
+	reports := make(chan []Summary, 1)
+	f := New(types.LogFilterConfig{}, func(s []Summary) { reports <- s })
+	f.SetLevel("", types.LogError)
+	f.Allow("svc", types.LogInfo, "msg")
+	f.Close()
+	select {
+	case got := <-reports:
+		if len(got) != 1 || got[0].BelowLevel != 1 {
+			t.Errorf("got %+v, want one entry below level", got)
+		}
+	default:
+		t.Error("no summary after a level was set at runtime")
+	}
+
+	for _, f := range filters {
+		f.Close()
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"cache cleared", "cache cleared"},
+		{"request 42 took 20ms", "request # took #"},
+		{"user u123 logged in", "user # logged in"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Template(tt.message); got != tt.want {
+			t.Errorf("Template(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     types.LogFilterConfig
+		wantErr bool
+	}{
+		{name: "empty", cfg: types.LogFilterConfig{}},
+		{name: "valid", cfg: types.LogFilterConfig{
+			Levels:      map[string]types.LogLevel{"": types.LogInfo},
+			SampleRates: map[types.LogLevel]float64{types.LogDebug: 0.1},
+			RateLimit:   10,
+		}},
+		{name: "invalid level", cfg: types.LogFilterConfig{Levels: map[string]types.LogLevel{"": 99}}, wantErr: true},
+		{name: "sample rate above one", cfg: types.LogFilterConfig{SampleRates: map[types.LogLevel]float64{types.LogDebug: 1.5}}, wantErr: true},
+		{name: "negative rate limit", cfg: types.LogFilterConfig{RateLimit: -1}, wantErr: true},
+		{name: "negative burst", cfg: types.LogFilterConfig{RateBurst: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.cfg); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
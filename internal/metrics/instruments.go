@@ -0,0 +1,251 @@
+// sdk-go/internal/metrics/instruments.go
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Kind identifies the type of instrument
+type Kind uint8
+
+const (
+	KindCounter   Kind = 1
+	KindGauge     Kind = 2
+	KindHistogram Kind = 3
+	KindTimer     Kind = 4
+)
+
+// String returns the string representation of Kind
+func (k Kind) String() string {
+	switch k {
+	case KindCounter:
+		return "counter"
+	case KindGauge:
+		return "gauge"
+	case KindHistogram:
+		return "histogram"
+	case KindTimer:
+		return "timer"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultBuckets are the histogram upper bounds used when none are given.
+// They also serve as millisecond bounds for timers.
+var defaultBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// DefaultBuckets returns a copy of the histogram upper bounds used when none are given
+func DefaultBuckets() []float64 {
+	return append([]float64(nil), defaultBuckets...)
+}
+
+// Snapshot is one instrument's aggregate over a flush window
+type Snapshot struct {
+	Kind      Kind
+	Name      string
+	Tags      types.Tags
+	Value     float64 // Sum for counters, histograms and timers; last value for gauges
+	Count     uint64  // Observations in the window
+	Min       float64
+	Max       float64
+	Bounds    []float64 // Histogram bucket upper bounds
+	Buckets   []uint64  // Counts per bucket; the last entry counts values above every bound
+	Timestamp time.Time // End of the window
+	Interval  time.Duration
+}
+
+type instrument interface {
+	snapshot() (Snapshot, bool)
+}
+
+// Counter accumulates a monotonically increasing sum
+type Counter struct {
+	name string
+	tags types.Tags
+
+	mu    sync.Mutex
+	sum   float64
+	count uint64
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds v to the counter; negative values are ignored
+func (c *Counter) Add(v float64) {
+	if c == nil || v < 0 || math.IsNaN(v) {
+		return
+	}
+	c.mu.Lock()
+	c.sum += v
+	c.count++
+	c.mu.Unlock()
+}
+
+func (c *Counter) snapshot() (Snapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.count == 0 {
+		return Snapshot{}, false
+	}
+	s := Snapshot{Kind: KindCounter, Name: c.name, Tags: c.tags, Value: c.sum, Count: c.count}
+	c.sum, c.count = 0, 0
+	return s, true
+}
+
+// Gauge records the latest value of a quantity
+type Gauge struct {
+	name string
+	tags types.Tags
+
+	mu       sync.Mutex
+	last     float64
+	min, max float64
+	count    uint64
+	set      bool
+}
+
+// Set records the current value
+func (g *Gauge) Set(v float64) {
+	if g == nil || math.IsNaN(v) {
+		return
+	}
+	g.mu.Lock()
+	if g.count == 0 {
+		g.min, g.max = v, v
+	}
+	g.last = v
+	g.min = math.Min(g.min, v)
+	g.max = math.Max(g.max, v)
+	g.count++
+	g.set = true
+	g.mu.Unlock()
+}
+
+// snapshot reports the last value every window once the gauge has been set
+func (g *Gauge) snapshot() (Snapshot, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.set {
+		return Snapshot{}, false
+	}
+	s := Snapshot{Kind: KindGauge, Name: g.name, Tags: g.tags, Value: g.last, Count: g.count, Min: g.min, Max: g.max}
+	if g.count == 0 {
+		s.Min, s.Max = g.last, g.last
+	}
+	g.count = 0
+	return s, true
+}
+
+// Histogram records the distribution of observed values
+type Histogram struct {
+	name   string
+	tags   types.Tags
+	kind   Kind
+	bounds []float64
+
+	mu       sync.Mutex
+	buckets  []uint64
+	sum      float64
+	min, max float64
+	count    uint64
+}
+
+func newHistogram(name string, tags types.Tags, kind Kind, bounds []float64) *Histogram {
+	if len(bounds) == 0 {
+		bounds = defaultBuckets
+	}
+	sorted := append([]float64(nil), bounds...)
+	sort.Float64s(sorted)
+
+	return &Histogram{
+		name:    name,
+		tags:    tags,
+		kind:    kind,
+		bounds:  sorted,
+		buckets: make([]uint64, len(sorted)+1),
+	}
+}
+
+// Record adds an observation
+func (h *Histogram) Record(v float64) {
+	if h == nil || math.IsNaN(v) {
+		return
+	}
+	i := sort.SearchFloat64s(h.bounds, v)
+
+	h.mu.Lock()
+	if h.count == 0 {
+		h.min, h.max = v, v
+	}
+	h.buckets[i]++
+	h.sum += v
+	h.min = math.Min(h.min, v)
+	h.max = math.Max(h.max, v)
+	h.count++
+	h.mu.Unlock()
+}
+
+func (h *Histogram) snapshot() (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return Snapshot{}, false
+	}
+	s := Snapshot{
+		Kind:    h.kind,
+		Name:    h.name,
+		Tags:    h.tags,
+		Value:   h.sum,
+		Count:   h.count,
+		Min:     h.min,
+		Max:     h.max,
+		Bounds:  h.bounds,
+		Buckets: h.buckets,
+	}
+	h.buckets = make([]uint64, len(h.bounds)+1)
+	h.sum, h.count = 0, 0
+	return s, true
+}
+
+// Timer records durations in milliseconds
+type Timer struct {
+	hist *Histogram
+}
+
+// Record adds a duration observation
+func (t *Timer) Record(d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.hist.Record(float64(d) / float64(time.Millisecond))
+}
+
+// Start begins timing and returns a function that records the elapsed time
+func (t *Timer) Start() func() {
+	start := time.Now()
+	return func() {
+		t.Record(time.Since(start))
+	}
+}
+
+// Time runs fn and records how long it took
+func (t *Timer) Time(fn func()) {
+	defer t.Start()()
+	fn()
+}
+
+func (t *Timer) snapshot() (Snapshot, bool) {
+	return t.hist.snapshot()
+}
@@ -0,0 +1,231 @@
+// sdk-go/internal/metrics/instruments_test.go
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCounter(t *testing.T) {
+	tests := []struct {
+		name      string
+		adds      []float64
+		wantSum   float64
+		wantCount uint64
+		wantOK    bool
+	}{
+		{name: "no observations", wantOK: false},
+		{name: "sums values", adds: []float64{1, 2.5, 0}, wantSum: 3.5, wantCount: 3, wantOK: true},
+		{name: "ignores negative values", adds: []float64{2, -1}, wantSum: 2, wantCount: 1, wantOK: true},
+		{name: "ignores NaN", adds: []float64{math.NaN(), 1}, wantSum: 1, wantCount: 1, wantOK: true},
+		{name: "only invalid values", adds: []float64{-1, math.NaN()}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Counter{name: "c"}
+			for _, v := range tt.adds {
+				c.Add(v)
+			}
+			s, ok := c.snapshot()
+			if ok != tt.wantOK {
+				t.Fatalf("snapshot ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if s.Kind != KindCounter || s.Value != tt.wantSum || s.Count != tt.wantCount {
+				t.Errorf("snapshot = %+v, want sum %v over %d", s, tt.wantSum, tt.wantCount)
+			}
+			if _, ok := c.snapshot(); ok {
+				t.Error("counter reported again after its window was reset")
+			}
+		})
+	}
+}
+
+func TestGaugeWindows(t *testing.T) {
+	type window struct {
+		sets               []float64
+		wantOK             bool
+		wantLast, min, max float64
+		wantCount          uint64
+	}
+	tests := []struct {
+		name    string
+		windows []window
+	}{
+		{
+			name:    "never set",
+			windows: []window{{wantOK: false}},
+		},
+		{
+			name: "tracks last, min and max",
+			windows: []window{
+				{sets: []float64{5, 1, 9, 4}, wantOK: true, wantLast: 4, min: 1, max: 9, wantCount: 4},
+			},
+		},
+		{
+			name: "min and max reset each window",
+			windows: []window{
+				{sets: []float64{1, 9}, wantOK: true, wantLast: 9, min: 1, max: 9, wantCount: 2},
+				{sets: []float64{5, 6}, wantOK: true, wantLast: 6, min: 5, max: 6, wantCount: 2},
+			},
+		},
+		{
+			name: "idle window repeats the last value",
+			windows: []window{
+				{sets: []float64{1, 3}, wantOK: true, wantLast: 3, min: 1, max: 3, wantCount: 2},
+				{wantOK: true, wantLast: 3, min: 3, max: 3, wantCount: 0},
+			},
+		},
+		{
+			name: "ignores NaN",
+			windows: []window{
+				{sets: []float64{2, math.NaN()}, wantOK: true, wantLast: 2, min: 2, max: 2, wantCount: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Gauge{name: "g"}
+			for i, w := range tt.windows {
+				for _, v := range w.sets {
+					g.Set(v)
+				}
+				s, ok := g.snapshot()
+				if ok != w.wantOK {
+					t.Fatalf("window %d: snapshot ok = %v, want %v", i, ok, w.wantOK)
+				}
+				if !ok {
+					continue
+				}
+				if s.Kind != KindGauge || s.Value != w.wantLast || s.Min != w.min || s.Max != w.max || s.Count != w.wantCount {
+					t.Errorf("window %d: snapshot = %+v, want last %v, min %v, max %v, count %d",
+						i, s, w.wantLast, w.min, w.max, w.wantCount)
+				}
+			}
+		})
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name        string
+		bounds      []float64
+		values      []float64
+		wantBounds  []float64
+		wantBuckets []uint64
+	}{
+		{
+			name:        "values between bounds",
+			bounds:      []float64{1, 10, 100},
+			values:      []float64{0.5, 5, 50},
+			wantBounds:  []float64{1, 10, 100},
+			wantBuckets: []uint64{1, 1, 1, 0},
+		},
+		{
+			name:        "bounds are inclusive upper bounds",
+			bounds:      []float64{1, 10, 100},
+			values:      []float64{1, 10, 100},
+			wantBounds:  []float64{1, 10, 100},
+			wantBuckets: []uint64{1, 1, 1, 0},
+		},
+		{
+			name:        "values above every bound go to the last bucket",
+			bounds:      []float64{1, 10},
+			values:      []float64{10.5, 1e9, math.Inf(1)},
+			wantBounds:  []float64{1, 10},
+			wantBuckets: []uint64{0, 0, 3},
+		},
+		{
+			name:        "negative values go to the first bucket",
+			bounds:      []float64{1, 10},
+			values:      []float64{-5, math.Inf(-1)},
+			wantBounds:  []float64{1, 10},
+			wantBuckets: []uint64{2, 0, 0},
+		},
+		{
+			name:        "bounds are sorted",
+			bounds:      []float64{100, 1, 10},
+			values:      []float64{5},
+			wantBounds:  []float64{1, 10, 100},
+			wantBuckets: []uint64{0, 1, 0, 0},
+		},
+		{
+			name:        "default bounds",
+			values:      []float64{2.5, 20000},
+			wantBounds:  DefaultBuckets(),
+			wantBuckets: []uint64{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHistogram("h", nil, KindHistogram, tt.bounds)
+			for _, v := range tt.values {
+				h.Record(v)
+			}
+			s, ok := h.snapshot()
+			if !ok {
+				t.Fatal("histogram reported no observations")
+			}
+			if !reflect.DeepEqual(s.Bounds, tt.wantBounds) {
+				t.Errorf("Bounds = %v, want %v", s.Bounds, tt.wantBounds)
+			}
+			if !reflect.DeepEqual(s.Buckets, tt.wantBuckets) {
+				t.Errorf("Buckets = %v, want %v", s.Buckets, tt.wantBuckets)
+			}
+		})
+	}
+}
+
+func TestHistogramWindows(t *testing.T) {
+	h := newHistogram("h", nil, KindHistogram, []float64{10})
+	h.Record(math.NaN())
+	if _, ok := h.snapshot(); ok {
+		t.Fatal("NaN was recorded")
+	}
+
+	for _, v := range []float64{4, 12, 2} {
+		h.Record(v)
+	}
+	s, _ := h.snapshot()
+	if s.Value != 18 || s.Count != 3 || s.Min != 2 || s.Max != 12 {
+		t.Errorf("snapshot = %+v, want sum 18, count 3, min 2, max 12", s)
+	}
+
+	h.Record(7)
+	next, _ := h.snapshot()
+	if next.Value != 7 || next.Count != 1 || next.Min != 7 || next.Max != 7 {
+		t.Errorf("next window = %+v, want only the new observation", next)
+	}
+	if !reflect.DeepEqual(s.Buckets, []uint64{2, 1}) {
+		t.Errorf("first window buckets = %v after the next window, want them kept", s.Buckets)
+	}
+}
+
+func TestTimer(t *testing.T) {
+	tm := &Timer{hist: newHistogram("t", nil, KindTimer, nil)}
+	tm.Record(1500 * time.Microsecond)
+	tm.Record(2 * time.Second)
+
+	s, ok := tm.snapshot()
+	if !ok || s.Kind != KindTimer {
+		t.Fatalf("snapshot = %+v, %v", s, ok)
+	}
+	if s.Value != 2001.5 || s.Min != 1.5 || s.Max != 2000 {
+		t.Errorf("snapshot = %+v, want values in milliseconds", s)
+	}
+}
+
+func TestDefaultBucketsIsACopy(t *testing.T) {
+	b := DefaultBuckets()
+	b[0] = -1
+	if DefaultBuckets()[0] != 1 {
+		t.Error("modifying the returned buckets changed the defaults")
+	}
+}
@@ -0,0 +1,250 @@
+// sdk-go/internal/metrics/registry.go
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	defaultInterval = 10 * time.Second
+
+	// DefaultMaxSeries caps distinct name/tag combinations to protect memory from tag explosions
+	DefaultMaxSeries = 10000
+)
+
+// EmitFunc receives the aggregates of each flush window
+type EmitFunc func(context.Context, []Snapshot) error
+
+// Registry owns the instruments and aggregates them in-process over a flush window
+type Registry struct {
+	interval  time.Duration
+	emit      EmitFunc
+	maxSeries int
+
+	mu          sync.Mutex
+	instruments map[string]instrument
+	order       []string
+	windowStart time.Time
+	flushMu     sync.Mutex
+
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewRegistry creates a registry that emits aggregates every interval
+func NewRegistry(interval time.Duration, emit EmitFunc) *Registry {
+	if emit == nil {
+		panic("emit function cannot be nil")
+	}
+
+	if interval <= 0 {
+		logger.Warn("Invalid metrics interval %v, using default %v", interval, defaultInterval)
+		interval = defaultInterval
+	}
+
+	r := &Registry{
+		interval:    interval,
+		emit:        emit,
+		maxSeries:   DefaultMaxSeries,
+		instruments: make(map[string]instrument),
+		windowStart: time.Now(),
+		ticker:      time.NewTicker(interval),
+		done:        make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.periodicFlush()
+
+	return r
+}
+
+func (r *Registry) periodicFlush() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.ticker.C:
+			if err := r.Flush(context.Background()); err != nil {
+				logger.Warn("Periodic metrics flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// Counter returns the counter for name and tags, creating it on first use
+func (r *Registry) Counter(name string, tags types.Tags) *Counter {
+	inst := r.lookup(KindCounter, name, tags, func(t types.Tags) instrument {
+		return &Counter{name: name, tags: t}
+	})
+	c, _ := inst.(*Counter)
+	return c
+}
+
+// Gauge returns the gauge for name and tags, creating it on first use
+func (r *Registry) Gauge(name string, tags types.Tags) *Gauge {
+	inst := r.lookup(KindGauge, name, tags, func(t types.Tags) instrument {
+		return &Gauge{name: name, tags: t}
+	})
+	g, _ := inst.(*Gauge)
+	return g
+}
+
+// Histogram returns the histogram for name and tags, creating it on first use.
+// Bucket bounds only apply on creation; DefaultBuckets is used when none are given.
+func (r *Registry) Histogram(name string, tags types.Tags, bounds ...float64) *Histogram {
+	inst := r.lookup(KindHistogram, name, tags, func(t types.Tags) instrument {
+		return newHistogram(name, t, KindHistogram, bounds)
+	})
+	h, _ := inst.(*Histogram)
+	return h
+}
+
+// Timer returns the timer for name and tags, creating it on first use
+func (r *Registry) Timer(name string, tags types.Tags) *Timer {
+	inst := r.lookup(KindTimer, name, tags, func(t types.Tags) instrument {
+		return &Timer{hist: newHistogram(name, t, KindTimer, nil)}
+	})
+	t, _ := inst.(*Timer)
+	return t
+}
+
+// lookup finds or registers an instrument. Invalid or conflicting requests are logged and
+// get nil, whose methods are no-ops, so instrumentation can never break the caller.
+func (r *Registry) lookup(kind Kind, name string, tags types.Tags, create func(types.Tags) instrument) instrument {
+	if name == "" {
+		logger.Warn("Metric name cannot be empty")
+		return nil
+	}
+
+	key := seriesKey(name, tags)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if inst, ok := r.instruments[key]; ok {
+		if kindOf(inst) != kind {
+			logger.Warn("Metric %q already registered as %s, not %s", name, kindOf(inst), kind)
+			return nil
+		}
+		return inst
+	}
+
+	if len(r.instruments) >= r.maxSeries {
+		logger.Warn("Metric series limit %d reached, dropping %q", r.maxSeries, name)
+		return nil
+	}
+
+	inst := create(copyTags(tags))
+	r.instruments[key] = inst
+	r.order = append(r.order, key)
+	return inst
+}
+
+// Flush emits the aggregates collected since the previous flush
+func (r *Registry) Flush(ctx context.Context) error {
+	// Serialize flushes so windows never overlap
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	r.mu.Lock()
+	now := time.Now()
+	window := now.Sub(r.windowStart)
+	r.windowStart = now
+	instruments := make([]instrument, len(r.order))
+	for i, key := range r.order {
+		instruments[i] = r.instruments[key]
+	}
+	r.mu.Unlock()
+
+	var snapshots []Snapshot
+	for _, inst := range instruments {
+		s, ok := inst.snapshot()
+		if !ok {
+			continue
+		}
+		s.Timestamp = now
+		s.Interval = window
+		snapshots = append(snapshots, s)
+	}
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.emit(ctx, snapshots)
+}
+
+// SeriesCount returns the number of registered series
+func (r *Registry) SeriesCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.instruments)
+}
+
+// Close stops periodic aggregation and emits the final window
+func (r *Registry) Close(ctx context.Context) error {
+	r.once.Do(func() {
+		r.ticker.Stop()
+		close(r.done)
+	})
+	r.wg.Wait()
+	return r.Flush(ctx)
+}
+
+func kindOf(inst instrument) Kind {
+	switch v := inst.(type) {
+	case *Counter:
+		return KindCounter
+	case *Gauge:
+		return KindGauge
+	case *Histogram:
+		return v.kind
+	case *Timer:
+		return KindTimer
+	default:
+		return 0
+	}
+}
+
+// seriesKey builds a stable identity from the name and sorted tags
+func seriesKey(name string, tags types.Tags) string {
+	if len(tags) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteByte('|')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+	return b.String()
+}
+
+func copyTags(tags types.Tags) types.Tags {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make(types.Tags, len(tags))
+	for k, v := range tags {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+// sdk-go/internal/metrics/registry_test.go
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// newTestRegistry returns a registry that only flushes when asked, and a function that
+// flushes it and returns the emitted snapshots
+func newTestRegistry(t *testing.T) (*Registry, func() []Snapshot) {
+	t.Helper()
+	var emitted []Snapshot
+	r := NewRegistry(time.Hour, func(_ context.Context, s []Snapshot) error {
+		emitted = append(emitted, s...)
+		return nil
+	})
+	t.Cleanup(func() { r.Close(context.Background()) })
+
+	return r, func() []Snapshot {
+		t.Helper()
+		emitted = nil
+		if err := r.Flush(context.Background()); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+		return emitted
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r, _ := newTestRegistry(t)
+
+	c := r.Counter("requests", types.Tags{"route": "/a", "method": "GET"})
+	if c == nil {
+		t.Fatal("Counter returned nil")
+	}
+	if again := r.Counter("requests", types.Tags{"method": "GET", "route": "/a"}); again != c {
+		t.Error("same name and tags in a different order returned a new counter")
+	}
+	if other := r.Counter("requests", types.Tags{"route": "/b"}); other == c {
+		t.Error("different tags returned the same counter")
+	}
+
+	tests := []struct {
+		name string
+		get  func() bool // Reports whether the instrument is nil
+	}{
+		{name: "empty name", get: func() bool { return r.Counter("", nil) == nil }},
+		{name: "counter registered as gauge", get: func() bool {
+			return r.Gauge("requests", types.Tags{"route": "/a", "method": "GET"}) == nil
+		}},
+		{name: "counter registered as histogram", get: func() bool {
+			return r.Histogram("requests", types.Tags{"route": "/a", "method": "GET"}) == nil
+		}},
+		{name: "histogram registered as timer", get: func() bool {
+			r.Histogram("latency", nil)
+			return r.Timer("latency", nil) == nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.get() {
+				t.Error("lookup returned an instrument, want nil")
+			}
+		})
+	}
+
+	// Methods on the nil instruments handed out above must be no-ops
+	r.Gauge("requests", types.Tags{"route": "/a", "method": "GET"}).Set(1)
+	r.Timer("latency", nil).Record(time.Second)
+}
+
+func TestRegistryCopiesTags(t *testing.T) {
+	r, flush := newTestRegistry(t)
+	tags := types.Tags{"route": "/a"}
+	r.Counter("requests", tags).Inc()
+	tags["route"] = "/b"
+
+	got := flush()
+	if len(got) != 1 || got[0].Tags["route"] != "/a" {
+		t.Errorf("snapshot tags = %v, want route /a", got)
+	}
+}
+
+func TestRegistryMaxSeries(t *testing.T) {
+	r, _ := newTestRegistry(t)
+
+	for i := 0; i < DefaultMaxSeries; i++ {
+		if r.Counter("requests", types.Tags{"id": fmt.Sprint(i)}) == nil {
+			t.Fatalf("series %d was rejected below the limit", i)
+		}
+	}
+	if r.Counter("requests", types.Tags{"id": "over"}) != nil {
+		t.Error("series over the limit was registered")
+	}
+	if r.Counter("requests", types.Tags{"id": "0"}) == nil {
+		t.Error("existing series was rejected at the limit")
+	}
+	if got := r.SeriesCount(); got != DefaultMaxSeries {
+		t.Errorf("SeriesCount = %d, want %d", got, DefaultMaxSeries)
+	}
+}
+
+func TestRegistryFlush(t *testing.T) {
+	r, flush := newTestRegistry(t)
+	r.Counter("a", nil).Add(2)
+	r.Gauge("b", nil).Set(3)
+	r.Counter("idle", nil)
+
+	got := flush()
+	if len(got) != 2 || got[0].Name != "a" || got[1].Name != "b" {
+		t.Fatalf("flushed %+v, want a and b in registration order", got)
+	}
+	for _, s := range got {
+		if s.Timestamp.IsZero() || s.Interval <= 0 {
+			t.Errorf("%s window = %v/%v, want it set", s.Name, s.Timestamp, s.Interval)
+		}
+	}
+
+	// Only the gauge reports again in an idle window
+	if got := flush(); len(got) != 1 || got[0].Name != "b" {
+		t.Errorf("idle flush = %+v, want only the gauge", got)
+	}
+}
@@ -0,0 +1,274 @@
+// sdk-go/internal/redact/redact.go
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+type rule struct {
+	keys     map[string]struct{}
+	paths    [][]string
+	pattern  *regexp.Regexp
+	action   types.RedactionAction
+	truncate int
+}
+
+// Redactor removes or obscures sensitive values according to a set of rules
+type Redactor struct {
+	rules []rule
+	salt  []byte
+	count int64 // atomic
+}
+
+// New compiles the redaction rules
+func New(cfg types.RedactionConfig) (*Redactor, error) {
+	r := &Redactor{salt: []byte(cfg.Salt)}
+
+	for i, rc := range cfg.Rules {
+		if len(rc.Keys) == 0 && len(rc.Paths) == 0 && rc.Pattern == "" {
+			return nil, types.NewValidationError(fmt.Sprintf("Rules[%d]", i), "needs Keys, Paths or Pattern")
+		}
+		if rc.Action < types.RedactDrop || rc.Action > types.RedactHash {
+			return nil, types.NewValidationError(fmt.Sprintf("Rules[%d].Action", i), "is invalid")
+		}
+		if rc.Action == types.RedactTruncate && rc.TruncateLength <= 0 {
+			return nil, types.NewValidationError(fmt.Sprintf("Rules[%d].TruncateLength", i), "must be positive")
+		}
+
+		compiled := rule{action: rc.Action, truncate: rc.TruncateLength}
+		if len(rc.Keys) > 0 {
+			compiled.keys = make(map[string]struct{}, len(rc.Keys))
+			for _, k := range rc.Keys {
+				compiled.keys[strings.ToLower(k)] = struct{}{}
+			}
+		}
+		for _, p := range rc.Paths {
+			compiled.paths = append(compiled.paths, strings.Split(p, "."))
+		}
+		if rc.Pattern != "" {
+			re, err := regexp.Compile(rc.Pattern)
+			if err != nil {
+				return nil, types.NewValidationError(fmt.Sprintf("Rules[%d].Pattern", i), err.Error())
+			}
+			compiled.pattern = re
+		}
+		r.rules = append(r.rules, compiled)
+	}
+
+	return r, nil
+}
+
+// Count returns the number of values redacted so far
+func (r *Redactor) Count() int64 {
+	return atomic.LoadInt64(&r.count)
+}
+
+// Properties returns props with all matching values redacted.
+// The input is never modified; a copy is returned only if something changed.
+func (r *Redactor) Properties(props types.Properties) types.Properties {
+	if len(props) == 0 {
+		return props
+	}
+	if out, changed := r.walkMap(props, nil); changed {
+		return types.Properties(out)
+	}
+	return props
+}
+
+// Map is like Properties for plain maps such as log Data
+func (r *Redactor) Map(data map[string]interface{}) map[string]interface{} {
+	if len(data) == 0 {
+		return data
+	}
+	if out, changed := r.walkMap(data, nil); changed {
+		return out
+	}
+	return data
+}
+
+// String applies pattern rules to a free-form string such as a log message.
+// A string matched by a drop rule is replaced by the placeholder rather than removed.
+func (r *Redactor) String(s string) string {
+	out, drop, _ := r.redactString(s)
+	if drop {
+		return types.RedactedPlaceholder
+	}
+	return out.(string)
+}
+
+func (r *Redactor) walkMap(m map[string]interface{}, path []string) (map[string]interface{}, bool) {
+	var out map[string]interface{}
+
+	for k, v := range m {
+		childPath := append(path[:len(path):len(path)], k)
+
+		newValue, drop, changed := r.walkValue(k, v, childPath)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(m))
+			for ck, cv := range m {
+				out[ck] = cv
+			}
+		}
+		if drop {
+			delete(out, k)
+		} else {
+			out[k] = newValue
+		}
+	}
+
+	if out == nil {
+		return m, false
+	}
+	return out, true
+}
+
+// walkValue redacts a single value reached through key at path
+func (r *Redactor) walkValue(key string, v interface{}, path []string) (interface{}, bool, bool) {
+	for i := range r.rules {
+		if r.rules[i].matchesKey(key, path) {
+			atomic.AddInt64(&r.count, 1)
+			out, drop := r.apply(&r.rules[i], v)
+			return out, drop, true
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		return r.redactString(val)
+	case map[string]interface{}:
+		out, changed := r.walkMap(val, path)
+		return out, false, changed
+	case types.Properties:
+		out, changed := r.walkMap(val, path)
+		return out, false, changed
+	case []interface{}:
+		out, changed := r.walkSlice(val, path)
+		return out, false, changed
+	default:
+		return v, false, false
+	}
+}
+
+func (r *Redactor) walkSlice(s []interface{}, path []string) ([]interface{}, bool) {
+	out := make([]interface{}, 0, len(s))
+	changed := false
+
+	// Array elements share their parent's path, so key and path rules match the array itself
+	for _, item := range s {
+		var (
+			newItem     = item
+			drop, dirty bool
+		)
+		switch val := item.(type) {
+		case string:
+			newItem, drop, dirty = r.redactString(val)
+		case map[string]interface{}:
+			newItem, dirty = r.walkMap(val, path)
+		case types.Properties:
+			newItem, dirty = r.walkMap(val, path)
+		case []interface{}:
+			newItem, dirty = r.walkSlice(val, path)
+		}
+		changed = changed || dirty
+		if !drop {
+			out = append(out, newItem)
+		}
+	}
+
+	if !changed {
+		return s, false
+	}
+	return out, true
+}
+
+// redactString applies pattern rules in order, returning the new value and whether to drop it
+func (r *Redactor) redactString(s string) (interface{}, bool, bool) {
+	changed := false
+	for i := range r.rules {
+		rl := &r.rules[i]
+		if rl.pattern == nil {
+			continue
+		}
+		locs := rl.pattern.FindAllStringIndex(s, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		atomic.AddInt64(&r.count, int64(len(locs)))
+		if rl.action == types.RedactDrop {
+			return nil, true, true
+		}
+		s = rl.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			out, _ := r.apply(rl, match)
+			return out.(string)
+		})
+		changed = true
+	}
+	return s, false, changed
+}
+
+// apply performs a rule's action on a whole value
+func (r *Redactor) apply(rl *rule, v interface{}) (interface{}, bool) {
+	switch rl.action {
+	case types.RedactDrop:
+		return nil, true
+	case types.RedactMask:
+		return types.RedactedPlaceholder, false
+	case types.RedactTruncate:
+		s := stringify(v)
+		runes := []rune(s)
+		if len(runes) > rl.truncate {
+			return string(runes[:rl.truncate]), false
+		}
+		return s, false
+	case types.RedactHash:
+		h := sha256.New()
+		h.Write(r.salt)
+		h.Write([]byte(stringify(v)))
+		return "sha256:" + hex.EncodeToString(h.Sum(nil)), false
+	default:
+		return v, false
+	}
+}
+
+func (rl *rule) matchesKey(key string, path []string) bool {
+	if rl.keys != nil {
+		if _, ok := rl.keys[strings.ToLower(key)]; ok {
+			return true
+		}
+	}
+	for _, p := range rl.paths {
+		if matchPath(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i, seg := range pattern {
+		if seg != "*" && seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringify(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,227 @@
+// sdk-go/internal/redact/redact_test.go
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func hashOf(salt, value string) string {
+	sum := sha256.Sum256([]byte(salt + value))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []types.RedactionRule
+		salt  string
+		in    map[string]interface{}
+		want  map[string]interface{}
+		count int64
+	}{
+		{
+			name:  "drop by key, case-insensitive at any depth",
+			rules: []types.RedactionRule{{Keys: []string{"password"}, Action: types.RedactDrop}},
+			in: map[string]interface{}{
+				"Password": "hunter2",
+				"user":     map[string]interface{}{"password": "x", "name": "ann"},
+			},
+			want: map[string]interface{}{
+				"user": map[string]interface{}{"name": "ann"},
+			},
+			count: 2,
+		},
+		{
+			name:  "mask by key",
+			rules: []types.RedactionRule{{Keys: []string{"token"}, Action: types.RedactMask}},
+			in:    map[string]interface{}{"token": 12345, "plan": "pro"},
+			want:  map[string]interface{}{"token": types.RedactedPlaceholder, "plan": "pro"},
+			count: 1,
+		},
+		{
+			name:  "truncate counts characters, not bytes",
+			rules: []types.RedactionRule{{Keys: []string{"note"}, Action: types.RedactTruncate, TruncateLength: 3}},
+			in:    map[string]interface{}{"note": "ærøskøbing", "short": "ok"},
+			want:  map[string]interface{}{"note": "ærø", "short": "ok"},
+			count: 1,
+		},
+		{
+			name:  "salted hash",
+			rules: []types.RedactionRule{{Keys: []string{"email"}, Action: types.RedactHash}},
+			salt:  "pepper",
+			in:    map[string]interface{}{"email": "a@example.com"},
+			want:  map[string]interface{}{"email": hashOf("pepper", "a@example.com")},
+			count: 1,
+		},
+		{
+			name:  "path with wildcard only matches at that depth",
+			rules: []types.RedactionRule{{Paths: []string{"user.*.street"}, Action: types.RedactMask}},
+			in: map[string]interface{}{
+				"street": "top",
+				"user": map[string]interface{}{
+					"home": map[string]interface{}{"street": "Main St 1", "city": "Oslo"},
+					"work": map[string]interface{}{"street": "Side St 2"},
+				},
+			},
+			want: map[string]interface{}{
+				"street": "top",
+				"user": map[string]interface{}{
+					"home": map[string]interface{}{"street": types.RedactedPlaceholder, "city": "Oslo"},
+					"work": map[string]interface{}{"street": types.RedactedPlaceholder},
+				},
+			},
+			count: 2,
+		},
+		{
+			name:  "array elements share their parent's path",
+			rules: []types.RedactionRule{{Paths: []string{"cards.number"}, Action: types.RedactDrop}},
+			in: map[string]interface{}{"cards": []interface{}{
+				map[string]interface{}{"number": "4111", "brand": "visa"},
+			}},
+			want: map[string]interface{}{"cards": []interface{}{
+				map[string]interface{}{"brand": "visa"},
+			}},
+			count: 1,
+		},
+		{
+			name:  "pattern masks only the matched substring",
+			rules: []types.RedactionRule{{Pattern: types.RedactPatternEmail, Action: types.RedactMask}},
+			in:    map[string]interface{}{"msg": "contact a@example.com or b@example.org", "n": 1},
+			want:  map[string]interface{}{"msg": "contact [REDACTED] or [REDACTED]", "n": 1},
+			count: 2,
+		},
+		{
+			name:  "pattern drop removes the value and array elements",
+			rules: []types.RedactionRule{{Pattern: types.RedactPatternIPv4, Action: types.RedactDrop}},
+			in:    map[string]interface{}{"ip": "10.0.0.1", "hops": []interface{}{"gw", "192.168.1.1"}},
+			want:  map[string]interface{}{"hops": []interface{}{"gw"}},
+			count: 2,
+		},
+		{
+			name: "first matching key rule wins",
+			rules: []types.RedactionRule{
+				{Keys: []string{"ssn"}, Action: types.RedactDrop},
+				{Keys: []string{"ssn"}, Action: types.RedactMask},
+			},
+			in:    map[string]interface{}{"ssn": "123-45-6789"},
+			want:  map[string]interface{}{},
+			count: 1,
+		},
+		{
+			name:  "nothing matches",
+			rules: []types.RedactionRule{{Keys: []string{"password"}, Action: types.RedactDrop}},
+			in:    map[string]interface{}{"plan": "pro"},
+			want:  map[string]interface{}{"plan": "pro"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(types.RedactionConfig{Rules: tt.rules, Salt: tt.salt})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			got := r.Map(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Map = %v, want %v", got, tt.want)
+			}
+			if r.Count() != tt.count {
+				t.Errorf("Count = %d, want %d", r.Count(), tt.count)
+			}
+		})
+	}
+}
+
+func TestPropertiesDoesNotModifyInput(t *testing.T) {
+	r, err := New(types.RedactionConfig{Rules: []types.RedactionRule{{Keys: []string{"email"}, Action: types.RedactMask}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	nested := map[string]interface{}{"email": "a@example.com"}
+	props := types.Properties{"user": nested, "plan": "pro"}
+	out := r.Properties(props)
+
+	if nested["email"] != "a@example.com" {
+		t.Error("Properties modified a nested input map")
+	}
+	if out["user"].(map[string]interface{})["email"] != types.RedactedPlaceholder {
+		t.Errorf("Properties = %v, want the nested email masked", out)
+	}
+
+	clean := types.Properties{"plan": "pro"}
+	if got := r.Properties(clean); reflect.ValueOf(got).Pointer() != reflect.ValueOf(clean).Pointer() {
+		t.Error("Properties copied a map with nothing to redact")
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []types.RedactionRule
+		in    string
+		want  string
+	}{
+		{
+			name:  "mask pattern",
+			rules: []types.RedactionRule{{Pattern: types.RedactPatternEmail, Action: types.RedactMask}},
+			in:    "login failed for a@example.com",
+			want:  "login failed for [REDACTED]",
+		},
+		{
+			name:  "drop pattern replaces the whole message",
+			rules: []types.RedactionRule{{Pattern: types.RedactPatternJWT, Action: types.RedactDrop}},
+			in:    "token eyJhbGciOi.eyJzdWIiOi.sig",
+			want:  types.RedactedPlaceholder,
+		},
+		{
+			name:  "truncate pattern shortens each match",
+			rules: []types.RedactionRule{{Pattern: `\d{4}-\d{4}`, Action: types.RedactTruncate, TruncateLength: 4}},
+			in:    "card 1234-5678 used",
+			want:  "card 1234 used",
+		},
+		{
+			name:  "key rules do not apply to messages",
+			rules: []types.RedactionRule{{Keys: []string{"email"}, Action: types.RedactDrop}},
+			in:    "email a@example.com",
+			want:  "email a@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(types.RedactionConfig{Rules: tt.rules})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if got := r.String(tt.in); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		rule types.RedactionRule
+	}{
+		{name: "no selector", rule: types.RedactionRule{Action: types.RedactMask}},
+		{name: "invalid action", rule: types.RedactionRule{Keys: []string{"a"}, Action: 0}},
+		{name: "truncate without length", rule: types.RedactionRule{Keys: []string{"a"}, Action: types.RedactTruncate}},
+		{name: "bad pattern", rule: types.RedactionRule{Pattern: "(", Action: types.RedactMask}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(types.RedactionConfig{Rules: []types.RedactionRule{tt.rule}}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// sdk-go/internal/runtimestats/collector.go
+package runtimestats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/internal/logger"
+	"github.com/usercanal/sdk-go/types"
+)
+
+// DefaultInterval is the time between samples
+const DefaultInterval = 30 * time.Second
+
+// ReadingKind tells the sink how to record a reading
+type ReadingKind uint8
+
+const (
+	// ReadingGauge is a point-in-time value
+	ReadingGauge ReadingKind = 1
+	// ReadingDelta is the increase of a cumulative value since the previous sample
+	ReadingDelta ReadingKind = 2
+)
+
+// Reading is one value derived from a runtime metric sample
+type Reading struct {
+	Name  string // Dotted name, e.g. "go.sched.goroutines"
+	Kind  ReadingKind
+	Value float64
+}
+
+// EmitFunc receives the readings of one sample
+type EmitFunc func(context.Context, []Reading) error
+
+// Collector samples runtime/metrics on an interval
+type Collector struct {
+	samples    []metrics.Sample
+	names      []string
+	cumulative []bool
+	emit       EmitFunc
+
+	// Previous cumulative values, to report deltas
+	prevScalar map[string]float64
+	prevHist   map[string][]uint64
+
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// Validate checks that every name is a metric supported by the running Go version
+func Validate(names []string) error {
+	supported := make(map[string]metrics.ValueKind)
+	for _, d := range metrics.All() {
+		supported[d.Name] = d.Kind
+	}
+	for i, name := range names {
+		kind, ok := supported[name]
+		if !ok {
+			return types.NewValidationError(fmt.Sprintf("Metrics[%d]", i), fmt.Sprintf("unsupported runtime metric %q", name))
+		}
+		if kind == metrics.KindBad {
+			return types.NewValidationError(fmt.Sprintf("Metrics[%d]", i), fmt.Sprintf("runtime metric %q cannot be sampled", name))
+		}
+	}
+	return nil
+}
+
+// NewCollector starts sampling the named metrics in the background.
+// Names must have been checked with Validate.
+func NewCollector(interval time.Duration, names []string, emit EmitFunc) *Collector {
+	if emit == nil {
+		panic("emit function cannot be nil")
+	}
+
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	c := &Collector{
+		samples:    make([]metrics.Sample, len(names)),
+		names:      make([]string, len(names)),
+		cumulative: make([]bool, len(names)),
+		emit:       emit,
+		prevScalar: make(map[string]float64),
+		prevHist:   make(map[string][]uint64),
+		ticker:     time.NewTicker(interval),
+		done:       make(chan struct{}),
+	}
+	descriptions := make(map[string]metrics.Description)
+	for _, d := range metrics.All() {
+		descriptions[d.Name] = d
+	}
+	for i, name := range names {
+		c.samples[i].Name = name
+		c.names[i] = readingName(name)
+		c.cumulative[i] = descriptions[name].Cumulative
+	}
+
+	// Prime cumulative values so the first report covers one interval rather than the whole process lifetime
+	c.sample()
+
+	c.wg.Add(1)
+	go c.run()
+
+	return c
+}
+
+func (c *Collector) run() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.ticker.C:
+			readings := c.sample()
+			if len(readings) == 0 {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := c.emit(ctx, readings); err != nil {
+				logger.Warn("Runtime metrics report failed: %v", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// sample reads all metrics and converts them to readings
+func (c *Collector) sample() []Reading {
+	metrics.Read(c.samples)
+
+	readings := make([]Reading, 0, len(c.samples))
+	for i, s := range c.samples {
+		name := c.names[i]
+		switch s.Value.Kind() {
+		case metrics.KindUint64:
+			readings = c.scalar(readings, s.Name, name, c.cumulative[i], float64(s.Value.Uint64()))
+		case metrics.KindFloat64:
+			readings = c.scalar(readings, s.Name, name, c.cumulative[i], s.Value.Float64())
+		case metrics.KindFloat64Histogram:
+			readings = c.histogram(readings, s.Name, name, s.Value.Float64Histogram())
+		}
+	}
+	return readings
+}
+
+func (c *Collector) scalar(readings []Reading, key, name string, cumulative bool, v float64) []Reading {
+	if !cumulative {
+		return append(readings, Reading{Name: name, Kind: ReadingGauge, Value: v})
+	}
+
+	prev, seen := c.prevScalar[key]
+	c.prevScalar[key] = v
+	if !seen || v < prev {
+		return readings
+	}
+	return append(readings, Reading{Name: name, Kind: ReadingDelta, Value: v - prev})
+}
+
+// histogram summarises the observations since the previous sample as p50, p99 and max gauges plus a count
+func (c *Collector) histogram(readings []Reading, key, name string, h *metrics.Float64Histogram) []Reading {
+	prev := c.prevHist[key]
+	current := append([]uint64(nil), h.Counts...)
+	c.prevHist[key] = current
+	if len(prev) != len(current) {
+		return readings
+	}
+
+	delta := make([]uint64, len(current))
+	var total uint64
+	for i := range current {
+		if current[i] >= prev[i] {
+			delta[i] = current[i] - prev[i]
+		}
+		total += delta[i]
+	}
+	if total == 0 {
+		return readings
+	}
+
+	return append(readings,
+		Reading{Name: name + ".p50", Kind: ReadingGauge, Value: quantile(h.Buckets, delta, total, 0.5)},
+		Reading{Name: name + ".p99", Kind: ReadingGauge, Value: quantile(h.Buckets, delta, total, 0.99)},
+		Reading{Name: name + ".max", Kind: ReadingGauge, Value: quantile(h.Buckets, delta, total, 1)},
+		Reading{Name: name + ".count", Kind: ReadingDelta, Value: float64(total)},
+	)
+}
+
+// Close stops sampling
+func (c *Collector) Close() {
+	c.once.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+	})
+	c.wg.Wait()
+}
+
+// quantile returns the upper bound of the bucket containing the q-th observation.
+// Buckets has one more entry than counts; an infinite upper bound falls back to the lower one.
+// An empty histogram has no quantiles and reports zero.
+func quantile(buckets []float64, counts []uint64, total uint64, q float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(q * float64(total)))
+	if target == 0 {
+		target = 1
+	}
+
+	var seen uint64
+	for i, n := range counts {
+		seen += n
+		if seen >= target {
+			if upper := buckets[i+1]; !math.IsInf(upper, 1) {
+				return upper
+			}
+			return buckets[i]
+		}
+	}
+	return buckets[len(buckets)-1]
+}
+
+// readingName turns "/gc/heap/allocs:bytes" into "go.gc.heap.allocs.bytes".
+// The unit is dropped when it repeats the last path segment, as in "/sched/goroutines:goroutines".
+func readingName(name string) string {
+	path, unit, _ := strings.Cut(strings.TrimPrefix(name, "/"), ":")
+	segments := strings.Split(path, "/")
+	if unit != "" && unit != segments[len(segments)-1] {
+		segments = append(segments, unit)
+	}
+	return "go." + strings.Join(segments, ".")
+}
@@ -0,0 +1,170 @@
+// sdk-go/internal/runtimestats/collector_test.go
+package runtimestats
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"runtime/metrics"
+	"testing"
+	"time"
+)
+
+func TestQuantile(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name    string
+		buckets []float64
+		counts  []uint64
+		q       float64
+		want    float64
+	}{
+		{name: "median", buckets: []float64{0, 1, 2}, counts: []uint64{2, 2}, q: 0.5, want: 1},
+		{name: "p99 rounds up to the last observation", buckets: []float64{0, 1, 2}, counts: []uint64{2, 2}, q: 0.99, want: 2},
+		{name: "q=0 takes the first observation", buckets: []float64{0, 1, 2}, counts: []uint64{0, 3}, q: 0, want: 2},
+		{name: "skips empty buckets", buckets: []float64{0, 1, 2, 3}, counts: []uint64{0, 0, 5}, q: 0.5, want: 3},
+		{name: "first bucket from -Inf", buckets: []float64{math.Inf(-1), 0, 10}, counts: []uint64{1, 0}, q: 0.5, want: 0},
+		{name: "q=1 in the last bucket", buckets: []float64{0, 1, 2}, counts: []uint64{1, 1}, q: 1, want: 2},
+		{name: "q=1 in a last bucket up to +Inf", buckets: []float64{0, 1, 2, inf}, counts: []uint64{1, 0, 1}, q: 1, want: 2},
+		{name: "empty histogram", buckets: []float64{0, 1, inf}, counts: []uint64{0, 0}, q: 0.5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total uint64
+			for _, n := range tt.counts {
+				total += n
+			}
+			if got := quantile(tt.buckets, tt.counts, total, tt.q); got != tt.want {
+				t.Errorf("quantile = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadingName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"/gc/heap/allocs:bytes", "go.gc.heap.allocs.bytes"},
+		{"/sched/goroutines:goroutines", "go.sched.goroutines"},
+		{"/sched/latencies:seconds", "go.sched.latencies.seconds"},
+		{"/memory/classes/total:bytes", "go.memory.classes.total.bytes"},
+		{"/gc/cycles/total:gc-cycles", "go.gc.cycles.total.gc-cycles"},
+		{"/cpu/classes/idle", "go.cpu.classes.idle"},
+	}
+	for _, tt := range tests {
+		if got := readingName(tt.name); got != tt.want {
+			t.Errorf("readingName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func newTestCollector() *Collector {
+	return &Collector{prevScalar: make(map[string]float64), prevHist: make(map[string][]uint64)}
+}
+
+func TestScalarReadings(t *testing.T) {
+	tests := []struct {
+		name       string
+		cumulative bool
+		values     []float64
+		want       [][]Reading // Readings after each value
+	}{
+		{
+			name:   "gauges report every value",
+			values: []float64{3, 1},
+			want: [][]Reading{
+				{{Name: "n", Kind: ReadingGauge, Value: 3}},
+				{{Name: "n", Kind: ReadingGauge, Value: 1}},
+			},
+		},
+		{
+			name:       "counters report the increase between reads",
+			cumulative: true,
+			values:     []float64{10, 15, 15},
+			want: [][]Reading{
+				nil,
+				{{Name: "n", Kind: ReadingDelta, Value: 5}},
+				{{Name: "n", Kind: ReadingDelta, Value: 0}},
+			},
+		},
+		{
+			name:       "a counter that goes backwards restarts",
+			cumulative: true,
+			values:     []float64{10, 4, 6},
+			want: [][]Reading{
+				nil,
+				nil,
+				{{Name: "n", Kind: ReadingDelta, Value: 2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector()
+			for i, v := range tt.values {
+				got := c.scalar(nil, "/n", "n", tt.cumulative, v)
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("read %d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHistogramReadings(t *testing.T) {
+	c := newTestCollector()
+	buckets := []float64{math.Inf(-1), 1, 2, 4, math.Inf(1)}
+	read := func(counts ...uint64) []Reading {
+		return c.histogram(nil, "/h", "h", &metrics.Float64Histogram{Counts: counts, Buckets: buckets})
+	}
+
+	if got := read(1, 1, 1, 1); got != nil {
+		t.Errorf("first read = %v, want nothing until there is a previous read", got)
+	}
+	if got := read(1, 1, 1, 1); got != nil {
+		t.Errorf("read without new observations = %v, want nothing", got)
+	}
+
+	want := []Reading{
+		{Name: "h.p50", Kind: ReadingGauge, Value: 2},
+		{Name: "h.p99", Kind: ReadingGauge, Value: 4},
+		{Name: "h.max", Kind: ReadingGauge, Value: 4},
+		{Name: "h.count", Kind: ReadingDelta, Value: 4},
+	}
+	if got := read(1, 3, 2, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("read = %v, want %v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate([]string{"/sched/goroutines:goroutines", "/gc/heap/allocs:bytes"}); err != nil {
+		t.Errorf("Validate supported metrics = %v", err)
+	}
+	if err := Validate([]string{"/sched/goroutines:goroutines", "/no/such:metric"}); err == nil {
+		t.Error("Validate accepted an unknown metric")
+	}
+}
+
+func TestCollectorEmits(t *testing.T) {
+	emitted := make(chan []Reading, 1)
+	c := NewCollector(10*time.Millisecond, []string{"/sched/goroutines:goroutines"}, func(_ context.Context, r []Reading) error {
+		select {
+		case emitted <- r:
+		default:
+		}
+		return nil
+	})
+	defer c.Close()
+
+	select {
+	case got := <-emitted:
+		if len(got) != 1 || got[0].Name != "go.sched.goroutines" || got[0].Kind != ReadingGauge || got[0].Value < 1 {
+			t.Errorf("readings = %v, want the goroutine count", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no readings emitted")
+	}
+}
@@ -14,6 +14,7 @@ const (
 	SchemaTypeLOG       SchemaType = 2
 	SchemaTypeMETRIC    SchemaType = 3
 	SchemaTypeINVENTORY SchemaType = 4
+	SchemaTypeSPAN      SchemaType = 5
 )
 
 var EnumNamesSchemaType = map[SchemaType]string{
@@ -22,6 +23,7 @@ var EnumNamesSchemaType = map[SchemaType]string{
 	SchemaTypeLOG:       "LOG",
 	SchemaTypeMETRIC:    "METRIC",
 	SchemaTypeINVENTORY: "INVENTORY",
+	SchemaTypeSPAN:      "SPAN",
 }
 
 var EnumValuesSchemaType = map[string]SchemaType{
@@ -30,6 +32,7 @@ var EnumValuesSchemaType = map[string]SchemaType{
 	"LOG":       SchemaTypeLOG,
 	"METRIC":    SchemaTypeMETRIC,
 	"INVENTORY": SchemaTypeINVENTORY,
+	"SPAN":      SchemaTypeSPAN,
 }
 
 func (v SchemaType) String() string {
@@ -17,6 +17,7 @@ const (
 	EventTypeALIAS    EventType = 4
 	EventTypeENRICH   EventType = 5
 	EventTypeCONTEXT  EventType = 6
+	EventTypePRIVACY  EventType = 7
 )
 
 var EnumNamesEventType = map[EventType]string{
@@ -27,6 +28,7 @@ var EnumNamesEventType = map[EventType]string{
 	EventTypeALIAS:    "ALIAS",
 	EventTypeENRICH:   "ENRICH",
 	EventTypeCONTEXT:  "CONTEXT",
+	EventTypePRIVACY:  "PRIVACY",
 }
 
 var EnumValuesEventType = map[string]EventType{
@@ -37,6 +39,7 @@ var EnumValuesEventType = map[string]EventType{
 	"ALIAS":    EventTypeALIAS,
 	"ENRICH":   EventTypeENRICH,
 	"CONTEXT":  EventTypeCONTEXT,
+	"PRIVACY":  EventTypePRIVACY,
 }
 
 func (v EventType) String() string {
@@ -0,0 +1,103 @@
+// sdk-go/internal/superprops/superprops.go
+package superprops
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Registry holds properties added to every event and log entry. Writers replace
+// an immutable snapshot, so merging on the hot path never takes a lock.
+type Registry struct {
+	mu    sync.Mutex // Serializes writers
+	props atomic.Pointer[map[string]interface{}]
+}
+
+// New creates a registry holding a copy of initial
+func New(initial map[string]interface{}) *Registry {
+	r := &Registry{}
+	r.Register(initial)
+	return r
+}
+
+// Register sets the given properties, replacing registered values under the same keys
+func (r *Registry) Register(props map[string]interface{}) {
+	r.update(func(next map[string]interface{}) {
+		for k, v := range props {
+			next[k] = v
+		}
+	})
+}
+
+// RegisterOnce sets only the properties whose keys are not registered yet
+func (r *Registry) RegisterOnce(props map[string]interface{}) {
+	r.update(func(next map[string]interface{}) {
+		for k, v := range props {
+			if _, ok := next[k]; !ok {
+				next[k] = v
+			}
+		}
+	})
+}
+
+// Unregister removes the given keys
+func (r *Registry) Unregister(keys ...string) {
+	r.update(func(next map[string]interface{}) {
+		for _, k := range keys {
+			delete(next, k)
+		}
+	})
+}
+
+// Properties returns a copy of the registered properties
+func (r *Registry) Properties() map[string]interface{} {
+	current := r.snapshot()
+	out := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		out[k] = v
+	}
+	return out
+}
+
+// Merge returns props over the registered properties, so per-call values win.
+// props is returned unchanged when nothing is registered and never modified.
+func (r *Registry) Merge(props map[string]interface{}) map[string]interface{} {
+	return Merge(r.snapshot(), props)
+}
+
+// Merge returns a new map of over laid on base, or over itself when base is empty
+func Merge(base, over map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 {
+		return over
+	}
+	out := make(map[string]interface{}, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		out[k] = v
+	}
+	return out
+}
+
+// snapshot returns the current properties, which must not be modified
+func (r *Registry) snapshot() map[string]interface{} {
+	if p := r.props.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// update applies fn to a copy of the current properties and publishes the result
+func (r *Registry) update(fn func(next map[string]interface{})) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.snapshot()
+	next := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		next[k] = v
+	}
+	fn(next)
+	r.props.Store(&next)
+}
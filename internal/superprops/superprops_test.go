@@ -0,0 +1,218 @@
+// sdk-go/internal/superprops/superprops_test.go
+package superprops
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	type op struct {
+		register     map[string]interface{}
+		registerOnce map[string]interface{}
+		unregister   []string
+	}
+	tests := []struct {
+		name    string
+		initial map[string]interface{}
+		ops     []op
+		want    map[string]interface{}
+	}{
+		{
+			name: "empty",
+			want: map[string]interface{}{},
+		},
+		{
+			name:    "initial properties",
+			initial: map[string]interface{}{"env": "prod"},
+			want:    map[string]interface{}{"env": "prod"},
+		},
+		{
+			name:    "register replaces existing values",
+			initial: map[string]interface{}{"env": "prod", "region": "eu"},
+			ops:     []op{{register: map[string]interface{}{"region": "us", "tier": 2}}},
+			want:    map[string]interface{}{"env": "prod", "region": "us", "tier": 2},
+		},
+		{
+			name:    "register once keeps existing values",
+			initial: map[string]interface{}{"region": "eu"},
+			ops:     []op{{registerOnce: map[string]interface{}{"region": "us", "tier": 2}}},
+			want:    map[string]interface{}{"region": "eu", "tier": 2},
+		},
+		{
+			name:    "register once after unregister",
+			initial: map[string]interface{}{"region": "eu"},
+			ops: []op{
+				{unregister: []string{"region"}},
+				{registerOnce: map[string]interface{}{"region": "us"}},
+			},
+			want: map[string]interface{}{"region": "us"},
+		},
+		{
+			name:    "unregister ignores unknown keys",
+			initial: map[string]interface{}{"env": "prod", "region": "eu"},
+			ops:     []op{{unregister: []string{"region", "missing"}}},
+			want:    map[string]interface{}{"env": "prod"},
+		},
+		{
+			name:    "nil value is registered",
+			initial: map[string]interface{}{"env": "prod"},
+			ops:     []op{{register: map[string]interface{}{"env": nil}}},
+			want:    map[string]interface{}{"env": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.initial)
+			for _, o := range tt.ops {
+				switch {
+				case o.register != nil:
+					r.Register(o.register)
+				case o.registerOnce != nil:
+					r.RegisterOnce(o.registerOnce)
+				default:
+					r.Unregister(o.unregister...)
+				}
+			}
+			if got := r.Properties(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Properties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryCopies(t *testing.T) {
+	initial := map[string]interface{}{"env": "prod"}
+	r := New(initial)
+	initial["env"] = "dev"
+
+	props := map[string]interface{}{"region": "eu"}
+	r.Register(props)
+	props["region"] = "us"
+
+	got := r.Properties()
+	got["env"] = "changed"
+
+	want := map[string]interface{}{"env": "prod", "region": "eu"}
+	if again := r.Properties(); !reflect.DeepEqual(again, want) {
+		t.Errorf("Properties() = %v, want %v unaffected by caller changes", again, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		base map[string]interface{}
+		over map[string]interface{}
+		want map[string]interface{}
+	}{
+		{name: "both empty", want: nil},
+		{name: "empty base", over: map[string]interface{}{"a": 1}, want: map[string]interface{}{"a": 1}},
+		{name: "empty over", base: map[string]interface{}{"a": 1}, want: map[string]interface{}{"a": 1}},
+		{
+			name: "over wins",
+			base: map[string]interface{}{"a": 1, "b": 2},
+			over: map[string]interface{}{"b": 3, "c": 4},
+			want: map[string]interface{}{"a": 1, "b": 3, "c": 4},
+		},
+		{
+			name: "nil in over replaces base",
+			base: map[string]interface{}{"a": 1},
+			over: map[string]interface{}{"a": nil},
+			want: map[string]interface{}{"a": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, over := clone(tt.base), clone(tt.over)
+			got := Merge(base, over)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(base, tt.base) || !reflect.DeepEqual(over, tt.over) {
+				t.Errorf("Merge modified its inputs: base %v, over %v", base, over)
+			}
+
+			// A merged copy must not share storage with either input
+			if len(tt.base) > 0 && got != nil {
+				got["added"] = true
+				if _, ok := base["added"]; ok {
+					t.Error("result shares storage with base")
+				}
+				if _, ok := over["added"]; ok {
+					t.Error("result shares storage with over")
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryMerge(t *testing.T) {
+	over := map[string]interface{}{"a": 1}
+	if got := New(nil).Merge(over); reflect.ValueOf(got).Pointer() != reflect.ValueOf(over).Pointer() {
+		t.Error("Merge with nothing registered copied props")
+	}
+
+	r := New(map[string]interface{}{"a": 0, "b": 2})
+	got := r.Merge(over)
+	if want := (map[string]interface{}{"a": 1, "b": 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+	got["b"] = 3
+	if r.Properties()["b"] != 2 {
+		t.Error("changing a merged map changed the registry")
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := New(map[string]interface{}{"env": "prod"})
+	over := map[string]interface{}{"call": true}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("k%d", i%10)
+				r.Register(map[string]interface{}{key: i})
+				r.RegisterOnce(map[string]interface{}{"once": w})
+				r.Unregister(key)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				merged := r.Merge(over)
+				if merged["env"] != "prod" || merged["call"] != true {
+					t.Errorf("Merge() = %v, lost a property", merged)
+					return
+				}
+				_ = r.Properties()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(over) != 1 {
+		t.Errorf("per-call props = %v, want them unchanged", over)
+	}
+	if _, ok := r.Properties()["once"]; !ok {
+		t.Error("RegisterOnce value missing")
+	}
+}
+
+func clone(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
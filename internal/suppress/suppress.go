@@ -0,0 +1,112 @@
+// sdk-go/internal/suppress/suppress.go
+package suppress
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxUsers bounds the number of suppressed users held in memory
+	DefaultMaxUsers = 10000
+
+	// DefaultTTL is how long a user stays suppressed after the last request
+	DefaultTTL = 24 * time.Hour
+)
+
+type entry struct {
+	userID string
+	added  time.Time
+}
+
+// List holds users whose events are dropped locally after a deletion or
+// suppression request. It lives in memory only: entries expire after the TTL,
+// the least recently suppressed user is evicted when the list is full, and
+// everything is lost when the process exits. The collector enforces the
+// request itself; the list only stops events already in flight in this process.
+type List struct {
+	maxUsers int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	order *list.List // Oldest first
+	users map[string]*list.Element
+}
+
+// New creates a list holding at most maxUsers users for ttl each
+func New(maxUsers int, ttl time.Duration) *List {
+	if maxUsers <= 0 {
+		maxUsers = DefaultMaxUsers
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &List{
+		maxUsers: maxUsers,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		users:    make(map[string]*list.Element),
+	}
+}
+
+// Add suppresses userID, restarting its TTL if it is already suppressed
+func (l *List) Add(userID string) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.users[userID]; ok {
+		el.Value.(*entry).added = now
+		l.order.MoveToBack(el)
+		return
+	}
+
+	l.expire(now)
+	if l.order.Len() >= l.maxUsers {
+		l.remove(l.order.Front())
+	}
+	l.users[userID] = l.order.PushBack(&entry{userID: userID, added: now})
+}
+
+// Contains reports whether userID is suppressed
+func (l *List) Contains(userID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.users[userID]
+	if !ok {
+		return false
+	}
+	if l.now().Sub(el.Value.(*entry).added) >= l.ttl {
+		l.remove(el)
+		return false
+	}
+	return true
+}
+
+// Len returns the number of users held, including any not yet expired by a lookup
+func (l *List) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// expire drops entries older than the TTL. Must be called with l.mu held.
+func (l *List) expire(now time.Time) {
+	for el := l.order.Front(); el != nil; el = l.order.Front() {
+		if now.Sub(el.Value.(*entry).added) < l.ttl {
+			return
+		}
+		l.remove(el)
+	}
+}
+
+// remove drops el from the list. Must be called with l.mu held.
+func (l *List) remove(el *list.Element) {
+	delete(l.users, el.Value.(*entry).userID)
+	l.order.Remove(el)
+}
@@ -0,0 +1,121 @@
+// sdk-go/internal/suppress/suppress_test.go
+package suppress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestList(t *testing.T) {
+	type step struct {
+		advance time.Duration
+		add     string
+		check   string
+		want    bool
+	}
+	tests := []struct {
+		name    string
+		max     int
+		steps   []step
+		wantLen int
+	}{
+		{
+			name: "added user is suppressed",
+			max:  10,
+			steps: []step{
+				{add: "a"},
+				{check: "a", want: true},
+				{check: "b", want: false},
+			},
+			wantLen: 1,
+		},
+		{
+			name: "entry expires after the TTL",
+			max:  10,
+			steps: []step{
+				{add: "a"},
+				{advance: 59 * time.Minute, check: "a", want: true},
+				{advance: time.Minute, check: "a", want: false},
+			},
+			wantLen: 0,
+		},
+		{
+			name: "adding again restarts the TTL",
+			max:  10,
+			steps: []step{
+				{add: "a"},
+				{advance: 50 * time.Minute, add: "a"},
+				{advance: 50 * time.Minute, check: "a", want: true},
+			},
+			wantLen: 1,
+		},
+		{
+			name: "oldest user is evicted when full",
+			max:  2,
+			steps: []step{
+				{add: "a"},
+				{add: "b"},
+				{add: "c"},
+				{check: "a", want: false},
+				{check: "b", want: true},
+				{check: "c", want: true},
+			},
+			wantLen: 2,
+		},
+		{
+			name: "re-added user moves to the back",
+			max:  2,
+			steps: []step{
+				{add: "a"},
+				{add: "b"},
+				{add: "a"},
+				{add: "c"},
+				{check: "a", want: true},
+				{check: "b", want: false},
+			},
+			wantLen: 2,
+		},
+		{
+			name: "expired entries are dropped before evicting",
+			max:  2,
+			steps: []step{
+				{add: "a"},
+				{advance: 30 * time.Minute, add: "b"},
+				{advance: 30 * time.Minute, add: "c"},
+				{check: "b", want: true},
+				{check: "c", want: true},
+			},
+			wantLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			l := New(tt.max, time.Hour)
+			l.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				if s.add != "" {
+					l.Add(s.add)
+				}
+				if s.check != "" {
+					if got := l.Contains(s.check); got != s.want {
+						t.Errorf("step %d: Contains(%q) = %v, want %v", i, s.check, got, s.want)
+					}
+				}
+			}
+			if got := l.Len(); got != tt.wantLen {
+				t.Errorf("Len = %d, want %d", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	l := New(0, 0)
+	if l.maxUsers != DefaultMaxUsers || l.ttl != DefaultTTL {
+		t.Errorf("New(0, 0) = (%d, %v), want (%d, %v)", l.maxUsers, l.ttl, DefaultMaxUsers, DefaultTTL)
+	}
+}
@@ -0,0 +1,45 @@
+// sdk-go/internal/trace/context.go
+package trace
+
+import (
+	"context"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the active span in ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext returns a copy of ctx whose next span continues a trace
+// started in another process
+func ContextWithRemoteSpanContext(ctx context.Context, sc types.SpanContext) context.Context {
+	sc.Remote = true
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the context of the active span, falling back to a remote parent
+func SpanContextFromContext(ctx context.Context) types.SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	if ctx == nil {
+		return types.SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteKey{}).(types.SpanContext)
+	return sc
+}
@@ -0,0 +1,98 @@
+// sdk-go/internal/trace/propagation.go
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// TraceparentHeader is the W3C Trace Context header name
+const TraceparentHeader = "traceparent"
+
+const (
+	traceparentVersion = "00"
+	traceparentLength  = 55 // "00-" + 32 + "-" + 16 + "-" + 2
+	flagSampled        = 0x01
+)
+
+// Inject writes the traceparent header for the active span in ctx.
+// Nothing is written when ctx carries no span.
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	header.Set(TraceparentHeader, FormatTraceparent(sc))
+}
+
+// Extract reads the traceparent header and returns a context whose next span
+// continues the remote trace. An absent or malformed header leaves ctx unchanged.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, ok := ParseTraceparent(header.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// FormatTraceparent encodes sc as a version 00 traceparent value
+func FormatTraceparent(sc types.SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return traceparentVersion + "-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent decodes a traceparent value. Versions newer than 00 are accepted
+// as long as their prefix has the version 00 layout, as the specification requires.
+func ParseTraceparent(value string) (types.SpanContext, bool) {
+	var sc types.SpanContext
+
+	if len(value) < traceparentLength {
+		return sc, false
+	}
+	version := value[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return sc, false
+	}
+	if version == traceparentVersion && len(value) != traceparentLength {
+		return sc, false
+	}
+	if len(value) > traceparentLength && value[traceparentLength] != '-' {
+		return sc, false
+	}
+	if value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return sc, false
+	}
+
+	traceHex, spanHex, flagsHex := value[3:35], value[36:52], value[53:55]
+	if !isLowerHex(traceHex) || !isLowerHex(spanHex) || !isLowerHex(flagsHex) {
+		return sc, false
+	}
+
+	hex.Decode(sc.TraceID[:], []byte(traceHex))
+	hex.Decode(sc.SpanID[:], []byte(spanHex))
+	var flags [1]byte
+	hex.Decode(flags[:], []byte(flagsHex))
+
+	if !sc.IsValid() {
+		return types.SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&flagSampled != 0
+	sc.Remote = true
+	return sc, true
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
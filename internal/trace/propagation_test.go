@@ -0,0 +1,105 @@
+// sdk-go/internal/trace/propagation_test.go
+package trace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantOK      bool
+		wantSampled bool
+	}{
+		{name: "sampled", value: "00-" + testTraceID + "-" + testSpanID + "-01", wantOK: true, wantSampled: true},
+		{name: "not sampled", value: "00-" + testTraceID + "-" + testSpanID + "-00", wantOK: true},
+		{name: "unknown flags keep the sampled bit", value: "00-" + testTraceID + "-" + testSpanID + "-03", wantOK: true, wantSampled: true},
+		{name: "future version with extra fields", value: "01-" + testTraceID + "-" + testSpanID + "-01-extra", wantOK: true, wantSampled: true},
+		{name: "empty", value: ""},
+		{name: "too short", value: "00-" + testTraceID + "-" + testSpanID},
+		{name: "version 00 with trailing data", value: "00-" + testTraceID + "-" + testSpanID + "-01-extra"},
+		{name: "future version without separator", value: "01-" + testTraceID + "-" + testSpanID + "-01x"},
+		{name: "forbidden version ff", value: "ff-" + testTraceID + "-" + testSpanID + "-01"},
+		{name: "uppercase hex", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + testSpanID + "-01"},
+		{name: "zero trace ID", value: "00-00000000000000000000000000000000-" + testSpanID + "-01"},
+		{name: "zero span ID", value: "00-" + testTraceID + "-0000000000000000-01"},
+		{name: "wrong separator", value: "00_" + testTraceID + "-" + testSpanID + "-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseTraceparent(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if !ok {
+				if sc != (types.SpanContext{}) {
+					t.Errorf("rejected value returned %+v, want zero", sc)
+				}
+				return
+			}
+			if sc.TraceID.String() != testTraceID || sc.SpanID.String() != testSpanID {
+				t.Errorf("IDs = (%s, %s), want (%s, %s)", sc.TraceID, sc.SpanID, testTraceID, testSpanID)
+			}
+			if sc.Sampled != tt.wantSampled {
+				t.Errorf("Sampled = %v, want %v", sc.Sampled, tt.wantSampled)
+			}
+			if !sc.Remote {
+				t.Error("parsed context is not marked remote")
+			}
+		})
+	}
+}
+
+func TestFormatTraceparentRoundTrip(t *testing.T) {
+	for _, sampled := range []bool{true, false} {
+		sc, _ := ParseTraceparent("00-" + testTraceID + "-" + testSpanID + "-00")
+		sc.Sampled = sampled
+
+		value := FormatTraceparent(sc)
+		got, ok := ParseTraceparent(value)
+		if !ok || got != sc {
+			t.Errorf("ParseTraceparent(FormatTraceparent(%+v)) = %+v, %v", sc, got, ok)
+		}
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	tracer := NewTracer("svc", func(Record) {})
+
+	// Extract on the server side, start a span, inject into an outgoing request
+	incoming := http.Header{}
+	incoming.Set(TraceparentHeader, "00-"+testTraceID+"-"+testSpanID+"-01")
+	ctx := Extract(context.Background(), incoming)
+	ctx, span := tracer.Start(ctx, "handler")
+
+	outgoing := http.Header{}
+	Inject(ctx, outgoing)
+	sc, ok := ParseTraceparent(outgoing.Get(TraceparentHeader))
+	if !ok {
+		t.Fatalf("injected header %q does not parse", outgoing.Get(TraceparentHeader))
+	}
+	if sc.TraceID.String() != testTraceID {
+		t.Errorf("trace ID = %s, want %s", sc.TraceID, testTraceID)
+	}
+	if sc.SpanID != span.SpanContext().SpanID {
+		t.Errorf("span ID = %s, want the local span %s", sc.SpanID, span.SpanContext().SpanID)
+	}
+
+	// Without a span or a valid header nothing is injected
+	empty := http.Header{}
+	Inject(Extract(context.Background(), http.Header{TraceparentHeader: {"garbage"}}), empty)
+	if len(empty) != 0 {
+		t.Errorf("Inject wrote %v without a span context", empty)
+	}
+}
@@ -0,0 +1,249 @@
+// sdk-go/internal/trace/span.go
+package trace
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+// Record is the immutable view of a finished span handed to the exporter
+type Record struct {
+	Context       types.SpanContext
+	Parent        types.SpanID
+	Name          string
+	Service       string
+	Kind          types.SpanKind
+	Status        types.SpanStatus
+	StatusMessage string
+	Start         time.Time
+	End           time.Time
+	Attributes    types.Properties
+}
+
+// FinishFunc receives every sampled span when it ends
+type FinishFunc func(Record)
+
+// Tracer starts spans for one service
+type Tracer struct {
+	service string
+	finish  FinishFunc
+}
+
+// NewTracer creates a tracer whose finished spans are passed to finish
+func NewTracer(service string, finish FinishFunc) *Tracer {
+	if finish == nil {
+		panic("finish function cannot be nil")
+	}
+	return &Tracer{service: service, finish: finish}
+}
+
+// StartOption configures a span at start
+type StartOption func(*Span)
+
+// WithSpanKind sets the role of the span; the default is types.SpanInternal
+func WithSpanKind(kind types.SpanKind) StartOption {
+	return func(s *Span) {
+		if kind != 0 {
+			s.kind = kind
+		}
+	}
+}
+
+// WithAttributes sets initial attributes
+func WithAttributes(attrs types.Properties) StartOption {
+	return func(s *Span) {
+		for k, v := range attrs {
+			s.attributes[k] = v
+		}
+	}
+}
+
+// WithStartTime overrides the start time, e.g. for work that began before the span was created
+func WithStartTime(t time.Time) StartOption {
+	return func(s *Span) {
+		if !t.IsZero() {
+			s.start = t
+		}
+	}
+}
+
+// Start begins a span as a child of the span in ctx, or of a remote parent extracted
+// from incoming headers, and returns a context carrying the new span.
+func (t *Tracer) Start(ctx context.Context, name string, opts ...StartOption) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	s := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       types.SpanInternal,
+		start:      time.Now(),
+		attributes: make(types.Properties),
+	}
+
+	if parent.IsValid() {
+		s.ctx.TraceID = parent.TraceID
+		s.ctx.Sampled = parent.Sampled
+		s.parent = parent.SpanID
+	} else {
+		s.ctx.TraceID = newTraceID()
+		s.ctx.Sampled = true
+	}
+	s.ctx.SpanID = newSpanID()
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return ContextWithSpan(ctx, s), s
+}
+
+// Span is an operation being timed. All methods are safe for concurrent use and
+// are no-ops on a nil or ended span.
+type Span struct {
+	tracer *Tracer
+	ctx    types.SpanContext
+	parent types.SpanID
+	name   string
+	kind   types.SpanKind
+	start  time.Time
+
+	mu            sync.Mutex
+	attributes    types.Properties
+	status        types.SpanStatus
+	statusMessage string
+	ended         bool
+}
+
+// SpanContext returns the IDs that identify the span
+func (s *Span) SpanContext() types.SpanContext {
+	if s == nil {
+		return types.SpanContext{}
+	}
+	return s.ctx
+}
+
+// SetName replaces the operation name
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.name = name
+	}
+}
+
+// SetAttribute records a single attribute
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.attributes[key] = value
+	}
+}
+
+// SetAttributes records several attributes
+func (s *Span) SetAttributes(attrs types.Properties) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		for k, v := range attrs {
+			s.attributes[k] = v
+		}
+	}
+}
+
+// SetStatus records the outcome; the message is only kept for errors
+func (s *Span) SetStatus(status types.SpanStatus, message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.status = status
+	if status == types.SpanStatusError {
+		s.statusMessage = message
+	} else {
+		s.statusMessage = ""
+	}
+}
+
+// RecordError marks the span as failed and records err as an attribute
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.attributes["error.message"] = err.Error()
+	s.status = types.SpanStatusError
+	s.statusMessage = err.Error()
+}
+
+// End finishes the span. Only the first call has any effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	end := time.Now()
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	record := Record{
+		Context:       s.ctx,
+		Parent:        s.parent,
+		Name:          s.name,
+		Service:       s.tracer.service,
+		Kind:          s.kind,
+		Status:        s.status,
+		StatusMessage: s.statusMessage,
+		Start:         s.start,
+		End:           end,
+		Attributes:    s.attributes,
+	}
+	s.mu.Unlock()
+
+	// Unsampled spans still propagate their context but are never exported
+	if s.ctx.Sampled {
+		s.tracer.finish(record)
+	}
+}
+
+func newTraceID() types.TraceID {
+	var id types.TraceID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+	return id
+}
+
+func newSpanID() types.SpanID {
+	var id types.SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return id
+}
@@ -0,0 +1,108 @@
+// sdk-go/internal/trace/span_test.go
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/usercanal/sdk-go/types"
+)
+
+func TestStartParents(t *testing.T) {
+	remote, _ := ParseTraceparent("00-" + testTraceID + "-" + testSpanID + "-00")
+
+	tests := []struct {
+		name        string
+		ctx         func(*Tracer) context.Context
+		wantTrace   string // "" for a new trace
+		wantParent  string
+		wantSampled bool
+	}{
+		{
+			name:        "root span starts a sampled trace",
+			ctx:         func(*Tracer) context.Context { return context.Background() },
+			wantSampled: true,
+		},
+		{
+			name: "remote parent",
+			ctx: func(*Tracer) context.Context {
+				return ContextWithRemoteSpanContext(context.Background(), remote)
+			},
+			wantTrace:  testTraceID,
+			wantParent: testSpanID,
+		},
+		{
+			name: "local span wins over a remote parent",
+			ctx: func(tr *Tracer) context.Context {
+				ctx := ContextWithRemoteSpanContext(context.Background(), remote)
+				ctx, _ = tr.Start(ctx, "parent")
+				return ctx
+			},
+			wantTrace: testTraceID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := NewTracer("svc", func(Record) {})
+			parentCtx := tt.ctx(tracer)
+			_, span := tracer.Start(parentCtx, "child")
+			sc := span.SpanContext()
+
+			if !sc.IsValid() {
+				t.Fatal("span context is not valid")
+			}
+			if tt.wantTrace != "" && sc.TraceID.String() != tt.wantTrace {
+				t.Errorf("trace ID = %s, want %s", sc.TraceID, tt.wantTrace)
+			}
+			wantParent := tt.wantParent
+			if local := SpanFromContext(parentCtx); local != nil {
+				wantParent = local.SpanContext().SpanID.String()
+			}
+			if wantParent != "" && span.parent.String() != wantParent {
+				t.Errorf("parent = %s, want %s", span.parent, wantParent)
+			}
+			if wantParent == "" && span.parent.IsValid() {
+				t.Errorf("root span has parent %s", span.parent)
+			}
+			if tt.wantSampled && !sc.Sampled {
+				t.Error("span is not sampled")
+			}
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	var records []Record
+	tracer := NewTracer("svc", func(r Record) { records = append(records, r) })
+
+	_, span := tracer.Start(context.Background(), "op", WithSpanKind(types.SpanClient), WithAttributes(types.Properties{"a": 1}))
+	span.SetAttribute("b", 2)
+	span.RecordError(errors.New("boom"))
+	span.End()
+	span.End()
+	span.SetAttribute("late", true)
+
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.Name != "op" || r.Service != "svc" || r.Kind != types.SpanClient {
+		t.Errorf("record = (%s, %s, %d), want (op, svc, %d)", r.Name, r.Service, r.Kind, types.SpanClient)
+	}
+	if r.Status != types.SpanStatusError || r.StatusMessage != "boom" {
+		t.Errorf("status = (%d, %q), want (%d, boom)", r.Status, r.StatusMessage, types.SpanStatusError)
+	}
+	if r.Attributes["a"] != 1 || r.Attributes["b"] != 2 || r.Attributes["late"] != nil {
+		t.Errorf("attributes = %v", r.Attributes)
+	}
+
+	// Unsampled spans are never exported
+	remote, _ := ParseTraceparent("00-" + testTraceID + "-" + testSpanID + "-00")
+	_, unsampled := tracer.Start(ContextWithRemoteSpanContext(context.Background(), remote), "op")
+	unsampled.End()
+	if len(records) != 1 {
+		t.Errorf("unsampled span was exported")
+	}
+}
@@ -21,6 +21,10 @@ const (
 	MaxEventSize  = 1 * 1024 * 1024  // 1MB max event
 	MaxLogSize    = 1 * 1024 * 1024  // 1MB max log
 	MaxBatchItems = 1000             // Max items per batch
+
+	// MaxBatchBytes is the encoded size at which batchers flush. It stays below
+	// MaxBatchSize to leave room for framing that EncodedSize does not count.
+	MaxBatchBytes = MaxBatchSize * 9 / 10
 )
 
 var ErrConnectionClosed = types.NewValidationError("connection", "is closed")
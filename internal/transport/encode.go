@@ -0,0 +1,59 @@
+// sdk-go/internal/transport/encode.go
+package transport
+
+import (
+	"net"
+	"sync"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+const (
+	// initialBuilderSize covers a typical batch without the builder having to grow
+	initialBuilderSize = 64 << 10
+
+	// maxPooledBuilderSize keeps builders grown by a rare large batch out of the
+	// pool, so idle encoders do not each pin memory up to the frame limit
+	maxPooledBuilderSize = 1 << 20
+)
+
+// encoder holds the buffers reused to encode and frame one batch
+type encoder struct {
+	builder *flatbuffers.Builder
+	offsets []flatbuffers.UOffsetT
+	prefix  [4]byte
+	frame   net.Buffers
+	pending net.Buffers // Copy of frame consumed by the write
+}
+
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		return &encoder{builder: flatbuffers.NewBuilder(initialBuilderSize)}
+	},
+}
+
+// getEncoder returns a reset encoder from the pool
+func getEncoder() *encoder {
+	enc := encoderPool.Get().(*encoder)
+	enc.builder.Reset()
+	return enc
+}
+
+// putEncoder returns enc to the pool. Nothing encoded with it may be used afterwards.
+func putEncoder(enc *encoder) {
+	if cap(enc.builder.Bytes) > maxPooledBuilderSize {
+		return
+	}
+	clear(enc.frame[:cap(enc.frame)])
+	enc.frame = enc.frame[:0]
+	enc.pending = nil
+	encoderPool.Put(enc)
+}
+
+// tableOffsets returns a scratch slice for n table offsets
+func (enc *encoder) tableOffsets(n int) []flatbuffers.UOffsetT {
+	if cap(enc.offsets) < n {
+		enc.offsets = make([]flatbuffers.UOffsetT, n)
+	}
+	return enc.offsets[:n]
+}
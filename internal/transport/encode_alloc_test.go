@@ -0,0 +1,35 @@
+// sdk-go/internal/transport/encode_alloc_test.go
+
+// The race detector makes sync.Pool drop items at random, so allocations are
+// only counted without it.
+//go:build !race
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// A pooled encoder is reused whole, so encoding and writing a batch allocates
+// nothing once the pool is warm. The context has a deadline, so SendEvents does
+// not add its default timeout.
+func TestSendEventsAllocs(t *testing.T) {
+	s := discardSender(t)
+	events := make([]*Event, 100)
+	for i := range events {
+		events[i] = &Event{Timestamp: uint64(i + 1), EventName: "Feature Used", Payload: []byte(`{"n":1}`)}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := s.SendEvents(ctx, events); err != nil {
+			t.Fatalf("SendEvents: %v", err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("SendEvents allocated %.1f times per batch, want 0", allocs)
+	}
+}
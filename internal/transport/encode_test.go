@@ -0,0 +1,297 @@
+// sdk-go/internal/transport/encode_test.go
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
+	event_schema "github.com/usercanal/sdk-go/internal/schema/event"
+	log_schema "github.com/usercanal/sdk-go/internal/schema/log"
+)
+
+const testAPIKey = "000102030405060708090a0b0c0d0e0f"
+
+type staticKey string
+
+func (k staticKey) APIKey(context.Context) (string, error) { return string(k), nil }
+
+// discardSender returns a sender connected to a server that discards everything
+func discardSender(b testing.TB) *Sender {
+	b.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatalf("listen: %v", err)
+	}
+	b.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(io.Discard, conn)
+		}
+	}()
+
+	s, err := NewSender(staticKey(testAPIKey), ln.Addr().String())
+	if err != nil {
+		b.Fatalf("NewSender: %v", err)
+	}
+	b.Cleanup(func() { s.Close() })
+	return s
+}
+
+// frameSender returns a sender connected to a server that passes each frame it
+// reads, without the length prefix, to the returned channel
+func frameSender(t *testing.T) (*Sender, <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	frames := make(chan []byte, 16)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var prefix [4]byte
+		for {
+			if _, err := io.ReadFull(conn, prefix[:]); err != nil {
+				return
+			}
+			frame := make([]byte, binary.BigEndian.Uint32(prefix[:]))
+			if _, err := io.ReadFull(conn, frame); err != nil {
+				return
+			}
+			frames <- frame
+		}
+	}()
+
+	s, err := NewSender(staticKey(testAPIKey), ln.Addr().String())
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, frames
+}
+
+// readBatch decodes the next frame and checks the Batch envelope
+func readBatch(t *testing.T, frames <-chan []byte, want schema_common.SchemaType) []byte {
+	t.Helper()
+	var frame []byte
+	select {
+	case frame = <-frames:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no frame received")
+	}
+
+	batch := schema_common.GetRootAsBatch(frame, 0)
+	if got := batch.SchemaType(); got != want {
+		t.Fatalf("SchemaType = %v, want %v", got, want)
+	}
+	if got := batch.Version(); got != ProtocolVersionCurrent {
+		t.Errorf("Version = %d, want %d", got, ProtocolVersionCurrent)
+	}
+	wantKey, _ := hex.DecodeString(testAPIKey)
+	if got := batch.ApiKeyBytes(); !bytes.Equal(got, wantKey) {
+		t.Errorf("ApiKey = %x, want %x", got, wantKey)
+	}
+	return batch.DataBytes()
+}
+
+func TestSendEventsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []*Event
+	}{
+		{
+			name: "single event with all fields",
+			events: []*Event{{
+				Timestamp: 1700000000000,
+				EventType: event_schema.EventTypeTRACK,
+				EventName: "Feature Used",
+				DeviceID:  bytes.Repeat([]byte{1}, 16),
+				SessionID: bytes.Repeat([]byte{2}, 16),
+				Payload:   []byte(`{"feature_name":"export"}`),
+			}},
+		},
+		{
+			name: "optional fields omitted",
+			events: []*Event{{
+				Timestamp: 1700000000001,
+				EventType: event_schema.EventTypeIDENTIFY,
+				Payload:   []byte(`{"email":"a@example.com"}`),
+			}},
+		},
+		{
+			name: "several events keep their order",
+			events: []*Event{
+				{Timestamp: 1, EventType: event_schema.EventTypeTRACK, EventName: "a", Payload: []byte(`{"n":1}`)},
+				{Timestamp: 2, EventType: event_schema.EventTypeTRACK, EventName: "b", Payload: []byte(`{"n":22}`)},
+				{Timestamp: 3, EventType: event_schema.EventTypeGROUP, EventName: "c", Payload: []byte(`{"n":333}`)},
+			},
+		},
+	}
+
+	s, frames := frameSender(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SendEvents(context.Background(), tt.events); err != nil {
+				t.Fatalf("SendEvents: %v", err)
+			}
+
+			data := event_schema.GetRootAsEventData(readBatch(t, frames, schema_common.SchemaTypeEVENT), 0)
+			if got := data.EventsLength(); got != len(tt.events) {
+				t.Fatalf("EventsLength = %d, want %d", got, len(tt.events))
+			}
+			var got event_schema.Event
+			for i, want := range tt.events {
+				data.Events(&got, i)
+				if got.Timestamp() != want.Timestamp || got.EventType() != want.EventType {
+					t.Errorf("event[%d] = (%d, %v), want (%d, %v)", i, got.Timestamp(), got.EventType(), want.Timestamp, want.EventType)
+				}
+				if string(got.EventName()) != want.EventName {
+					t.Errorf("event[%d] name = %q, want %q", i, got.EventName(), want.EventName)
+				}
+				if !bytes.Equal(got.DeviceIdBytes(), want.DeviceID) || !bytes.Equal(got.SessionIdBytes(), want.SessionID) {
+					t.Errorf("event[%d] ids = (%x, %x), want (%x, %x)", i, got.DeviceIdBytes(), got.SessionIdBytes(), want.DeviceID, want.SessionID)
+				}
+				if !bytes.Equal(got.PayloadBytes(), want.Payload) {
+					t.Errorf("event[%d] payload = %s, want %s", i, got.PayloadBytes(), want.Payload)
+				}
+			}
+		})
+	}
+}
+
+func TestSendLogsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		logs []*Log
+	}{
+		{
+			name: "single log",
+			logs: []*Log{{
+				EventType: log_schema.LogEventTypeLOG,
+				SessionID: bytes.Repeat([]byte{3}, 16),
+				Level:     log_schema.LogLevelERROR,
+				Timestamp: 1700000000000,
+				Source:    "host-1",
+				Service:   "api",
+				Payload:   []byte(`{"message":"boom"}`),
+			}},
+		},
+		{
+			name: "several logs keep their order",
+			logs: []*Log{
+				{Level: log_schema.LogLevelINFO, Timestamp: 1, Source: "a", Service: "svc", Payload: []byte(`{"message":"one"}`)},
+				{Level: log_schema.LogLevelDEBUG, Timestamp: 2, Source: "b", Service: "svc", Payload: []byte(`{"message":"two"}`)},
+			},
+		},
+	}
+
+	s, frames := frameSender(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SendLogs(context.Background(), tt.logs); err != nil {
+				t.Fatalf("SendLogs: %v", err)
+			}
+
+			data := log_schema.GetRootAsLogData(readBatch(t, frames, schema_common.SchemaTypeLOG), 0)
+			if got := data.LogsLength(); got != len(tt.logs) {
+				t.Fatalf("LogsLength = %d, want %d", got, len(tt.logs))
+			}
+			var got log_schema.LogEntry
+			for i, want := range tt.logs {
+				data.Logs(&got, i)
+				if got.Timestamp() != want.Timestamp || got.Level() != want.Level {
+					t.Errorf("log[%d] = (%d, %v), want (%d, %v)", i, got.Timestamp(), got.Level(), want.Timestamp, want.Level)
+				}
+				if string(got.Source()) != want.Source || string(got.Service()) != want.Service {
+					t.Errorf("log[%d] origin = (%s, %s), want (%s, %s)", i, got.Source(), got.Service(), want.Source, want.Service)
+				}
+				if !bytes.Equal(got.SessionIdBytes(), want.SessionID) {
+					t.Errorf("log[%d] session = %x, want %x", i, got.SessionIdBytes(), want.SessionID)
+				}
+				if !bytes.Equal(got.PayloadBytes(), want.Payload) {
+					t.Errorf("log[%d] payload = %s, want %s", i, got.PayloadBytes(), want.Payload)
+				}
+			}
+		})
+	}
+}
+
+var batchSizes = []int{10, 100, MaxBatchItems}
+
+func BenchmarkSendEvents(b *testing.B) {
+	payload := []byte(`{"feature_name":"export","duration_ms":1500}`)
+	for _, n := range batchSizes {
+		b.Run(fmt.Sprintf("batch=%d", n), func(b *testing.B) {
+			s := discardSender(b)
+			events := make([]*Event, n)
+			for i := range events {
+				events[i] = &Event{
+					Timestamp: 1700000000000 + uint64(i),
+					EventType: event_schema.EventTypeTRACK,
+					EventName: "Feature Used",
+					DeviceID:  make([]byte, 16),
+					SessionID: make([]byte, 16),
+					Payload:   payload,
+				}
+			}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := s.SendEvents(ctx, events); err != nil {
+					b.Fatalf("SendEvents: %v", err)
+				}
+			}
+			b.ReportMetric(float64(b.N*n)/b.Elapsed().Seconds(), "events/s")
+		})
+	}
+}
+
+func BenchmarkSendLogs(b *testing.B) {
+	payload := []byte(`{"message":"request handled","status":200,"duration_ms":12}`)
+	for _, n := range batchSizes {
+		b.Run(fmt.Sprintf("batch=%d", n), func(b *testing.B) {
+			s := discardSender(b)
+			logs := make([]*Log, n)
+			for i := range logs {
+				logs[i] = &Log{
+					EventType: log_schema.LogEventTypeLOG,
+					SessionID: make([]byte, 16),
+					Level:     log_schema.LogLevelINFO,
+					Timestamp: 1700000000000 + uint64(i),
+					Source:    "host-1",
+					Service:   "api",
+					Payload:   payload,
+				}
+			}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := s.SendLogs(ctx, logs); err != nil {
+					b.Fatalf("SendLogs: %v", err)
+				}
+			}
+			b.ReportMetric(float64(b.N*n)/b.Elapsed().Seconds(), "logs/s")
+		})
+	}
+}
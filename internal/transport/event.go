@@ -57,10 +57,12 @@ func (s *Sender) SendEvents(ctx context.Context, events []*Event) error {
 	default:
 	}
 
-	builder := flatbuffers.NewBuilder(1024 * len(events))
+	enc := getEncoder()
+	defer putEncoder(enc)
+	builder := enc.builder
 
 	// Create events vector
-	eventOffsets := make([]flatbuffers.UOffsetT, len(events))
+	eventOffsets := enc.tableOffsets(len(events))
 	for i := len(events) - 1; i >= 0; i-- {
 		evt := events[i]
 
@@ -101,11 +103,8 @@ func (s *Sender) SendEvents(ctx context.Context, events []*Event) error {
 	event_collector.EventDataAddEvents(builder, eventsVec)
 	eventDataEnd := event_collector.EventDataEnd(builder)
 
-	builder.Finish(eventDataEnd)
-	eventDataBytes := builder.FinishedBytes()
-
 	// Send as batch
-	err := s.sendBatch(ctx, schema_common.SchemaTypeEVENT, eventDataBytes)
+	err := s.sendBatch(ctx, schema_common.SchemaTypeEVENT, enc, eventDataEnd)
 	if err == nil {
 		s.recordEventSuccess(len(events))
 	}
@@ -0,0 +1,104 @@
+// sdk-go/internal/transport/inventory.go
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
+	schema_inventory "github.com/usercanal/sdk-go/internal/schema/inventory"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func (s *Sender) SendInventory(ctx context.Context, entries []*Inventory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Add default timeout if none exists
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	if len(entries) > MaxBatchItems {
+		return types.NewValidationError("inventory", fmt.Sprintf("batch too large (max %d entries)", MaxBatchItems))
+	}
+
+	totalSize := 0
+	for i, e := range entries {
+		if e.Timestamp == 0 {
+			return types.NewValidationError("Timestamp", fmt.Sprintf("inventory[%d] timestamp is required", i))
+		}
+		if e.Service == "" {
+			return types.NewValidationError("Service", fmt.Sprintf("inventory[%d] service is required", i))
+		}
+		if len(e.InstanceID) > 0 && len(e.InstanceID) != 16 {
+			return types.NewValidationError("InstanceID", fmt.Sprintf("inventory[%d] instance ID must be 16 bytes", i))
+		}
+		if len(e.Payload) > MaxEventSize {
+			return types.NewValidationError("payload", fmt.Sprintf("inventory[%d] payload too large (max %d bytes)", i, MaxEventSize))
+		}
+		totalSize += len(e.Payload) + len(e.Source) + len(e.Service)
+	}
+
+	if totalSize > MaxBatchSize {
+		return types.NewValidationError("batch", fmt.Sprintf("total payload size %d exceeds limit %d", totalSize, MaxBatchSize))
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return types.NewValidationError("sender", "is shutting down")
+	default:
+	}
+
+	enc := getEncoder()
+	defer putEncoder(enc)
+	builder := enc.builder
+
+	// Create entries vector
+	entryOffsets := enc.tableOffsets(len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+
+		var instanceOffset, payloadOffset flatbuffers.UOffsetT
+		if len(e.InstanceID) > 0 {
+			instanceOffset = builder.CreateByteVector(e.InstanceID)
+		}
+		sourceOffset := builder.CreateString(e.Source)
+		serviceOffset := builder.CreateString(e.Service)
+		if len(e.Payload) > 0 {
+			payloadOffset = builder.CreateByteVector(e.Payload)
+		}
+
+		schema_inventory.InventoryEntryStart(builder)
+		schema_inventory.InventoryEntryAddInventoryType(builder, e.InventoryType)
+		schema_inventory.InventoryEntryAddTimestamp(builder, e.Timestamp)
+		if len(e.InstanceID) > 0 {
+			schema_inventory.InventoryEntryAddInstanceId(builder, instanceOffset)
+		}
+		schema_inventory.InventoryEntryAddSource(builder, sourceOffset)
+		schema_inventory.InventoryEntryAddService(builder, serviceOffset)
+		if len(e.Payload) > 0 {
+			schema_inventory.InventoryEntryAddPayload(builder, payloadOffset)
+		}
+		entryOffsets[i] = schema_inventory.InventoryEntryEnd(builder)
+	}
+
+	entriesVec := builder.CreateVectorOfTables(entryOffsets)
+
+	// Create InventoryData
+	schema_inventory.InventoryDataStart(builder)
+	schema_inventory.InventoryDataAddEntries(builder, entriesVec)
+	inventoryDataEnd := schema_inventory.InventoryDataEnd(builder)
+
+	// Send as batch
+	err := s.sendBatch(ctx, schema_common.SchemaTypeINVENTORY, enc, inventoryDataEnd)
+	if err == nil {
+		s.recordInventorySuccess(len(entries))
+	}
+	return err
+}
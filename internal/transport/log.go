@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"time"
 
-	flatbuffers "github.com/google/flatbuffers/go"
 	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
 	schema_log "github.com/usercanal/sdk-go/internal/schema/log"
 	"github.com/usercanal/sdk-go/types"
@@ -62,10 +61,12 @@ func (s *Sender) SendLogs(ctx context.Context, logs []*Log) error {
 	default:
 	}
 
-	builder := flatbuffers.NewBuilder(1024 * len(logs))
+	enc := getEncoder()
+	defer putEncoder(enc)
+	builder := enc.builder
 
 	// Create logs vector
-	logOffsets := make([]flatbuffers.UOffsetT, len(logs))
+	logOffsets := enc.tableOffsets(len(logs))
 	for i := len(logs) - 1; i >= 0; i-- {
 		log := logs[i]
 
@@ -92,13 +93,10 @@ func (s *Sender) SendLogs(ctx context.Context, logs []*Log) error {
 	schema_log.LogDataAddLogs(builder, logsVec)
 	logDataEnd := schema_log.LogDataEnd(builder)
 
-	builder.Finish(logDataEnd)
-	logDataBytes := builder.FinishedBytes()
-
 	// logger.Debug("About to send batch with SchemaTypeLOG = %d", int(schema_common.SchemaTypeLOG))
 
 	// Send as batch
-	err := s.sendBatch(ctx, schema_common.SchemaTypeLOG, logDataBytes)
+	err := s.sendBatch(ctx, schema_common.SchemaTypeLOG, enc, logDataEnd)
 	if err == nil {
 		s.recordLogSuccess(len(logs))
 	}
@@ -0,0 +1,100 @@
+// sdk-go/internal/transport/metric.go
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
+	schema_metric "github.com/usercanal/sdk-go/internal/schema/metric"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func (s *Sender) SendMetrics(ctx context.Context, metrics []*Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	// Add default timeout if none exists
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	// Size and count validation for critical environments
+	if len(metrics) > MaxBatchItems {
+		return types.NewValidationError("metrics", fmt.Sprintf("batch too large (max %d metrics)", MaxBatchItems))
+	}
+
+	totalSize := 0
+	for i, m := range metrics {
+		// Validate required fields
+		if m.Timestamp == 0 {
+			return types.NewValidationError("Timestamp", fmt.Sprintf("metric[%d] timestamp is required", i))
+		}
+		if m.Name == "" {
+			return types.NewValidationError("Name", fmt.Sprintf("metric[%d] name is required", i))
+		}
+
+		// Size validation
+		if len(m.Payload) > MaxEventSize {
+			return types.NewValidationError("payload", fmt.Sprintf("metric[%d] payload too large (max %d bytes)", i, MaxEventSize))
+		}
+		totalSize += len(m.Payload) + len(m.Name)
+	}
+
+	if totalSize > MaxBatchSize {
+		return types.NewValidationError("batch", fmt.Sprintf("total payload size %d exceeds limit %d", totalSize, MaxBatchSize))
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return types.NewValidationError("sender", "is shutting down")
+	default:
+	}
+
+	enc := getEncoder()
+	defer putEncoder(enc)
+	builder := enc.builder
+
+	// Create metrics vector
+	metricOffsets := enc.tableOffsets(len(metrics))
+	for i := len(metrics) - 1; i >= 0; i-- {
+		m := metrics[i]
+
+		nameOffset := builder.CreateString(m.Name)
+		var payloadOffset flatbuffers.UOffsetT
+		if len(m.Payload) > 0 {
+			payloadOffset = builder.CreateByteVector(m.Payload)
+		}
+
+		schema_metric.MetricEntryStart(builder)
+		schema_metric.MetricEntryAddMetricType(builder, m.MetricType)
+		schema_metric.MetricEntryAddTimestamp(builder, m.Timestamp)
+		schema_metric.MetricEntryAddName(builder, nameOffset)
+		schema_metric.MetricEntryAddValue(builder, m.Value)
+		schema_metric.MetricEntryAddCount(builder, m.Count)
+		schema_metric.MetricEntryAddIntervalMs(builder, m.IntervalMs)
+		if len(m.Payload) > 0 {
+			schema_metric.MetricEntryAddPayload(builder, payloadOffset)
+		}
+		metricOffsets[i] = schema_metric.MetricEntryEnd(builder)
+	}
+
+	metricsVec := builder.CreateVectorOfTables(metricOffsets)
+
+	// Create MetricData
+	schema_metric.MetricDataStart(builder)
+	schema_metric.MetricDataAddMetrics(builder, metricsVec)
+	metricDataEnd := schema_metric.MetricDataEnd(builder)
+
+	// Send as batch
+	err := s.sendBatch(ctx, schema_common.SchemaTypeMETRIC, enc, metricDataEnd)
+	if err == nil {
+		s.recordMetricSuccess(len(metrics))
+	}
+	return err
+}
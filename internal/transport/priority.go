@@ -0,0 +1,56 @@
+// sdk-go/internal/transport/priority.go
+package transport
+
+import (
+	"context"
+	"sync"
+)
+
+type priorityKey struct{}
+
+// WithPriority marks sends made with ctx as priority traffic. Priority frames are
+// written ahead of bulk frames waiting for the connection.
+func WithPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, priorityKey{}, true)
+}
+
+func isPriority(ctx context.Context) bool {
+	priority, _ := ctx.Value(priorityKey{}).(bool)
+	return priority
+}
+
+// writeGate serialises frame writes. While a priority writer is waiting, bulk
+// writers are held back so that critical data is not queued behind large batches.
+type writeGate struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	writing  bool
+	priority int // Priority writers waiting for the gate
+}
+
+func newWriteGate() *writeGate {
+	g := &writeGate{}
+	g.cond = sync.NewCond(&g.mu)
+	return g
+}
+
+func (g *writeGate) acquire(priority bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if priority {
+		g.priority++
+		defer func() { g.priority-- }()
+	}
+	for g.writing || (!priority && g.priority > 0) {
+		g.cond.Wait()
+	}
+	g.writing = true
+}
+
+func (g *writeGate) release() {
+	g.mu.Lock()
+	g.writing = false
+	g.mu.Unlock()
+	g.cond.Broadcast()
+}
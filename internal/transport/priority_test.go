@@ -0,0 +1,94 @@
+// sdk-go/internal/transport/priority_test.go
+package transport
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithPriority(t *testing.T) {
+	if isPriority(context.Background()) {
+		t.Error("plain context is priority")
+	}
+	if !isPriority(WithPriority(context.Background())) {
+		t.Error("WithPriority context is not priority")
+	}
+}
+
+func TestWriteGatePriorityFirst(t *testing.T) {
+	g := newWriteGate()
+	g.acquire(false)
+
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	write := func(name string, priority bool) {
+		defer wg.Done()
+		g.acquire(priority)
+		mu.Lock()
+		order = append(order, name)
+		mu.Unlock()
+		g.release()
+	}
+
+	wg.Add(3)
+	go write("bulk-1", false)
+	go write("bulk-2", false)
+	time.Sleep(10 * time.Millisecond) // Let the bulk writers start waiting first
+	go write("priority", true)
+
+	// Wait until the priority writer is registered as waiting
+	for {
+		g.mu.Lock()
+		waiting := g.priority
+		g.mu.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	g.release()
+	wg.Wait()
+
+	if len(order) != 3 || order[0] != "priority" {
+		t.Errorf("write order = %v, want the priority writer first", order)
+	}
+	if g.writing || g.priority != 0 {
+		t.Errorf("gate left writing=%v, priority=%d", g.writing, g.priority)
+	}
+}
+
+func TestWriteGateSerialises(t *testing.T) {
+	g := newWriteGate()
+	var (
+		active, peak int
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(priority bool) {
+			defer wg.Done()
+			g.acquire(priority)
+			mu.Lock()
+			active++
+			peak = max(peak, active)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+			g.release()
+		}(i%3 == 0)
+	}
+	wg.Wait()
+
+	if peak != 1 {
+		t.Errorf("%d writers held the gate at once, want 1", peak)
+	}
+}
@@ -23,11 +23,17 @@ const (
 
 // Sender handles data sending and metrics
 type Sender struct {
-	connMgr   *ConnManager
-	apiKey    []byte
-	startTime time.Time
-	metrics   types.TransportMetrics
-	mu        sync.RWMutex
+	connMgr     *ConnManager
+	credentials types.CredentialProvider
+	startTime   time.Time
+	metrics     types.TransportMetrics
+	mu          sync.RWMutex
+	gate        *writeGate
+
+	// Last API key seen from the provider and its decoded form
+	keyMu    sync.Mutex
+	keyHex   string
+	keyBytes []byte
 
 	// Lifecycle
 	ctx    context.Context
@@ -36,26 +42,20 @@ type Sender struct {
 }
 
 func generateBatchID() uint64 {
-	var id uint64
-	binary.Read(rand.Reader, binary.BigEndian, &id)
-	return id
+	var id [8]byte
+	rand.Read(id[:])
+	return binary.BigEndian.Uint64(id[:])
 }
 
-func NewSender(apiKey, endpoint string) (*Sender, error) {
-	if apiKey == "" {
-		return nil, types.NewValidationError("apiKey", "cannot be empty")
+func NewSender(credentials types.CredentialProvider, endpoint string) (*Sender, error) {
+	if credentials == nil {
+		return nil, types.NewValidationError("credentials", "cannot be nil")
 	}
 
 	if endpoint == "" {
 		return nil, types.NewValidationError("endpoint", "cannot be empty")
 	}
 
-	// Convert hex API key to bytes
-	apiKeyBytes, err := hex.DecodeString(apiKey)
-	if err != nil {
-		return nil, types.NewValidationError("apiKey", "invalid format")
-	}
-
 	logger.Debug("Creating new sender for endpoint: %s", endpoint)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -64,11 +64,18 @@ func NewSender(apiKey, endpoint string) (*Sender, error) {
 	connMgr := NewConnManager(endpoint)
 
 	s := &Sender{
-		connMgr:   connMgr,
-		apiKey:    apiKeyBytes,
-		startTime: time.Now(),
-		ctx:       ctx,
-		cancel:    cancel,
+		connMgr:     connMgr,
+		credentials: credentials,
+		startTime:   time.Now(),
+		gate:        newWriteGate(),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	// Resolve the key up front so a bad key fails client creation
+	if _, err := s.resolveAPIKey(ctx); err != nil {
+		cancel()
+		return nil, err
 	}
 
 	// Attempt initial connection
@@ -103,17 +110,62 @@ func (s *Sender) monitorStateChanges() {
 	}
 }
 
-func (s *Sender) sendBatch(ctx context.Context, schemaType schema_common.SchemaType, data []byte) error {
+// resolveAPIKey returns the decoded API key for the next batch.
+// A new key from the provider is validated before use; if the provider fails or
+// hands out an invalid key, the last good key is kept so queued data still flows.
+func (s *Sender) resolveAPIKey(ctx context.Context) ([]byte, error) {
+	key, err := s.credentials.APIKey(ctx)
+
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+
+	if err == nil && key == s.keyHex {
+		return s.keyBytes, nil
+	}
+
+	if err == nil {
+		err = types.ValidateAPIKey(key)
+	}
+	if err != nil {
+		if s.keyBytes == nil {
+			return nil, err
+		}
+		logger.Warn("Credential provider failed, keeping previous API key: %v", err)
+		return s.keyBytes, nil
+	}
+
+	keyBytes, _ := hex.DecodeString(key)
+	if s.keyBytes != nil {
+		logger.Debug("API key rotated")
+	}
+	s.keyHex = key
+	s.keyBytes = keyBytes
+	return keyBytes, nil
+}
+
+// sendBatch wraps the schema data rooted at root in a Batch and sends it. The data
+// is finished in place and becomes the Batch's data vector, so it is never copied.
+func (s *Sender) sendBatch(ctx context.Context, schemaType schema_common.SchemaType, enc *encoder, root flatbuffers.UOffsetT) error {
+	builder := enc.builder
+	builder.Finish(root)
+	dataLen := len(builder.FinishedBytes())
+
 	// Size validation for critical environments
-	if len(data) > MaxBatchSize {
-		return types.NewValidationError("batch", fmt.Sprintf("batch size %d exceeds limit %d", len(data), MaxBatchSize))
+	if dataLen > MaxBatchSize {
+		return types.NewValidationError("batch", fmt.Sprintf("batch size %d exceeds limit %d", dataLen, MaxBatchSize))
 	}
 
-	builder := flatbuffers.NewBuilder(1024)
+	apiKey, err := s.resolveAPIKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A finished buffer is aligned for use as a vector body; only the length is missing
+	builder.StartVector(1, 0, 1)
+	dataOffset := builder.EndVector(dataLen)
 
 	batchID := generateBatchID()
-	apiKeyOffset := builder.CreateByteVector(s.apiKey)
-	dataOffset := builder.CreateByteVector(data)
+	apiKeyOffset := builder.CreateByteVector(apiKey)
 
 	schema_common.BatchStart(builder)
 	schema_common.BatchAddApiKey(builder, apiKeyOffset)
@@ -124,19 +176,15 @@ func (s *Sender) sendBatch(ctx context.Context, schemaType schema_common.SchemaT
 	batchOffset := schema_common.BatchEnd(builder)
 
 	builder.Finish(batchOffset)
-	finalData := builder.FinishedBytes()
 
-	return s.sendFrame(ctx, finalData)
+	return s.sendFrame(ctx, enc, builder.FinishedBytes())
 }
 
-func (s *Sender) sendFrame(ctx context.Context, data []byte) error {
-	// Send length-prefixed message
-	lenBuf := make([]byte, 4)
-	binary.BigEndian.PutUint32(lenBuf, uint32(len(data)))
-
-	frame := make([]byte, len(lenBuf)+len(data))
-	copy(frame, lenBuf)
-	copy(frame[len(lenBuf):], data)
+// sendFrame writes data with its length prefix in one vectored write
+func (s *Sender) sendFrame(ctx context.Context, enc *encoder, data []byte) error {
+	binary.BigEndian.PutUint32(enc.prefix[:], uint32(len(data)))
+	enc.frame = append(enc.frame[:0], enc.prefix[:], data)
+	frameLen := len(enc.prefix) + len(data)
 
 	// Get connection and send with graceful retry
 	conn := s.connMgr.GetConn()
@@ -160,11 +208,16 @@ func (s *Sender) sendFrame(ctx context.Context, data []byte) error {
 		}
 	}
 
+	// One frame at a time, priority frames first; the deadline applies to this write only
+	s.gate.acquire(isPriority(ctx))
 	if deadline, ok := ctx.Deadline(); ok {
 		conn.SetWriteDeadline(deadline)
 	}
-
-	_, err := conn.Write(frame)
+	// WriteTo consumes the buffers it writes, so write from a copy and keep the
+	// pooled frame's backing array for the next batch
+	enc.pending = enc.frame
+	_, err := enc.pending.WriteTo(conn)
+	s.gate.release()
 	if err != nil {
 		s.recordFailure()
 		// Signal retry for connection issues
@@ -176,7 +229,7 @@ func (s *Sender) sendFrame(ctx context.Context, data []byte) error {
 	}
 
 	// Record bytes sent for metrics
-	s.recordBytesSent(len(frame))
+	s.recordBytesSent(frameLen)
 	return nil
 }
 
@@ -214,6 +267,42 @@ func (s *Sender) recordLogSuccess(logCount int) {
 	}
 }
 
+func (s *Sender) recordMetricSuccess(metricCount int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics.MetricsSent += int64(metricCount)
+	s.metrics.MetricBatchesSent++
+	s.metrics.TotalBatchesSent++
+	s.metrics.LastSendTime = time.Now()
+	s.metrics.ConnectionUptime = s.Uptime()
+	s.metrics.ReconnectCount = s.connMgr.GetReconnectCount()
+}
+
+func (s *Sender) recordInventorySuccess(entryCount int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics.InventorySent += int64(entryCount)
+	s.metrics.InventoryBatchesSent++
+	s.metrics.TotalBatchesSent++
+	s.metrics.LastSendTime = time.Now()
+	s.metrics.ConnectionUptime = s.Uptime()
+	s.metrics.ReconnectCount = s.connMgr.GetReconnectCount()
+}
+
+func (s *Sender) recordSpanSuccess(spanCount int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics.SpansSent += int64(spanCount)
+	s.metrics.SpanBatchesSent++
+	s.metrics.TotalBatchesSent++
+	s.metrics.LastSendTime = time.Now()
+	s.metrics.ConnectionUptime = s.Uptime()
+	s.metrics.ReconnectCount = s.connMgr.GetReconnectCount()
+}
+
 func (s *Sender) recordBytesSent(bytes int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,97 @@
+// sdk-go/internal/transport/sender_test.go
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// keySequence hands out one result per call, repeating the last one
+type keySequence struct {
+	keys []string
+	errs []error
+	n    int
+}
+
+func (k *keySequence) APIKey(context.Context) (string, error) {
+	i := min(k.n, len(k.keys)-1)
+	k.n++
+	return k.keys[i], k.errs[i]
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	const (
+		keyA = "000102030405060708090a0b0c0d0e0f"
+		keyB = "0f0e0d0c0b0a09080706050403020100"
+	)
+	errProvider := errors.New("provider down")
+
+	tests := []struct {
+		name string
+		keys []string
+		errs []error
+		want []string // key expected from each call, "" for an error
+	}{
+		{
+			name: "static key",
+			keys: []string{keyA},
+			errs: []error{nil},
+			want: []string{keyA, keyA},
+		},
+		{
+			name: "rotation picks up the new key",
+			keys: []string{keyA, keyB},
+			errs: []error{nil, nil},
+			want: []string{keyA, keyB, keyB},
+		},
+		{
+			name: "provider error keeps the last good key",
+			keys: []string{keyA, "", keyB},
+			errs: []error{nil, errProvider, nil},
+			want: []string{keyA, keyA, keyB},
+		},
+		{
+			name: "invalid rotated key keeps the last good key",
+			keys: []string{keyA, "not-hex"},
+			errs: []error{nil, nil},
+			want: []string{keyA, keyA},
+		},
+		{
+			name: "provider error with no previous key",
+			keys: []string{""},
+			errs: []error{errProvider},
+			want: []string{""},
+		},
+		{
+			name: "invalid first key",
+			keys: []string{"abcd"},
+			errs: []error{nil},
+			want: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sender{credentials: &keySequence{keys: tt.keys, errs: tt.errs}}
+			for i, want := range tt.want {
+				got, err := s.resolveAPIKey(context.Background())
+				if want == "" {
+					if err == nil {
+						t.Fatalf("call %d: expected error, got key %x", i, got)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("call %d: %v", i, err)
+				}
+				wantBytes, _ := hex.DecodeString(want)
+				if !bytes.Equal(got, wantBytes) {
+					t.Errorf("call %d: key = %x, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// sdk-go/internal/transport/span.go
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
+	schema_span "github.com/usercanal/sdk-go/internal/schema/span"
+	"github.com/usercanal/sdk-go/types"
+)
+
+func (s *Sender) SendSpans(ctx context.Context, spans []*Span) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// Add default timeout if none exists
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
+
+	if len(spans) > MaxBatchItems {
+		return types.NewValidationError("spans", fmt.Sprintf("batch too large (max %d spans)", MaxBatchItems))
+	}
+
+	totalSize := 0
+	for i, sp := range spans {
+		// Validate required fields
+		if len(sp.TraceID) != 16 {
+			return types.NewValidationError("TraceID", fmt.Sprintf("span[%d] trace ID must be 16 bytes", i))
+		}
+		if len(sp.SpanID) != 8 {
+			return types.NewValidationError("SpanID", fmt.Sprintf("span[%d] span ID must be 8 bytes", i))
+		}
+		if len(sp.ParentSpanID) > 0 && len(sp.ParentSpanID) != 8 {
+			return types.NewValidationError("ParentSpanID", fmt.Sprintf("span[%d] parent span ID must be 8 bytes", i))
+		}
+		if sp.StartTime == 0 || sp.EndTime < sp.StartTime {
+			return types.NewValidationError("EndTime", fmt.Sprintf("span[%d] must end after it starts", i))
+		}
+		if sp.Name == "" {
+			return types.NewValidationError("Name", fmt.Sprintf("span[%d] name is required", i))
+		}
+
+		// Size validation
+		if len(sp.Payload) > MaxEventSize {
+			return types.NewValidationError("payload", fmt.Sprintf("span[%d] payload too large (max %d bytes)", i, MaxEventSize))
+		}
+		totalSize += len(sp.Payload) + len(sp.Name) + len(sp.Service)
+	}
+
+	if totalSize > MaxBatchSize {
+		return types.NewValidationError("batch", fmt.Sprintf("total payload size %d exceeds limit %d", totalSize, MaxBatchSize))
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return types.NewValidationError("sender", "is shutting down")
+	default:
+	}
+
+	enc := getEncoder()
+	defer putEncoder(enc)
+	builder := enc.builder
+
+	// Create spans vector
+	spanOffsets := enc.tableOffsets(len(spans))
+	for i := len(spans) - 1; i >= 0; i-- {
+		sp := spans[i]
+
+		traceIDOffset := builder.CreateByteVector(sp.TraceID)
+		spanIDOffset := builder.CreateByteVector(sp.SpanID)
+		var parentOffset, payloadOffset flatbuffers.UOffsetT
+		if len(sp.ParentSpanID) > 0 {
+			parentOffset = builder.CreateByteVector(sp.ParentSpanID)
+		}
+		nameOffset := builder.CreateString(sp.Name)
+		serviceOffset := builder.CreateString(sp.Service)
+		if len(sp.Payload) > 0 {
+			payloadOffset = builder.CreateByteVector(sp.Payload)
+		}
+
+		schema_span.SpanStart(builder)
+		schema_span.SpanAddTraceId(builder, traceIDOffset)
+		schema_span.SpanAddSpanId(builder, spanIDOffset)
+		if len(sp.ParentSpanID) > 0 {
+			schema_span.SpanAddParentSpanId(builder, parentOffset)
+		}
+		schema_span.SpanAddKind(builder, sp.Kind)
+		schema_span.SpanAddStatus(builder, sp.Status)
+		schema_span.SpanAddStartTime(builder, sp.StartTime)
+		schema_span.SpanAddEndTime(builder, sp.EndTime)
+		schema_span.SpanAddName(builder, nameOffset)
+		schema_span.SpanAddService(builder, serviceOffset)
+		if len(sp.Payload) > 0 {
+			schema_span.SpanAddPayload(builder, payloadOffset)
+		}
+		spanOffsets[i] = schema_span.SpanEnd(builder)
+	}
+
+	spansVec := builder.CreateVectorOfTables(spanOffsets)
+
+	// Create SpanData
+	schema_span.SpanDataStart(builder)
+	schema_span.SpanDataAddSpans(builder, spansVec)
+	spanDataEnd := schema_span.SpanDataEnd(builder)
+
+	// Send as batch
+	err := s.sendBatch(ctx, schema_common.SchemaTypeSPAN, enc, spanDataEnd)
+	if err == nil {
+		s.recordSpanSuccess(len(spans))
+	}
+	return err
+}
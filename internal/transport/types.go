@@ -3,7 +3,10 @@ package transport
 
 import (
 	event_schema "github.com/usercanal/sdk-go/internal/schema/event"
+	inventory_schema "github.com/usercanal/sdk-go/internal/schema/inventory"
 	log_schema "github.com/usercanal/sdk-go/internal/schema/log"
+	metric_schema "github.com/usercanal/sdk-go/internal/schema/metric"
+	span_schema "github.com/usercanal/sdk-go/internal/schema/span"
 )
 
 // Event represents an internal event structure for transport
@@ -14,6 +17,10 @@ type Event struct {
 	DeviceID  []byte
 	SessionID []byte
 	Payload   []byte
+
+	// UserID is kept in-process only (never serialized) so queued events
+	// can be found again, e.g. to drop them on a deletion request
+	UserID string
 }
 
 // Log represents an internal log structure for transport
@@ -26,3 +33,51 @@ type Log struct {
 	Service   string
 	Payload   []byte
 }
+
+// Metric represents an internal aggregated metric structure for transport
+type Metric struct {
+	MetricType metric_schema.MetricType
+	Timestamp  uint64
+	Name       string
+	Value      float64
+	Count      uint64
+	IntervalMs uint32
+	Payload    []byte
+}
+
+// Inventory represents an internal inventory snapshot structure for transport
+type Inventory struct {
+	InventoryType inventory_schema.InventoryType
+	Timestamp     uint64
+	InstanceID    []byte
+	Source        string
+	Service       string
+	Payload       []byte
+}
+
+// Span represents an internal finished span structure for transport
+type Span struct {
+	TraceID      []byte
+	SpanID       []byte
+	ParentSpanID []byte
+	Kind         span_schema.SpanKind
+	Status       span_schema.SpanStatus
+	StartTime    uint64
+	EndTime      uint64
+	Name         string
+	Service      string
+	Payload      []byte
+}
+
+// entryOverhead approximates the FlatBuffers table, vtable and vector headers of one entry
+const entryOverhead = 64
+
+// EncodedSize estimates the bytes the event adds to an encoded batch
+func (e *Event) EncodedSize() int {
+	return entryOverhead + len(e.EventName) + len(e.DeviceID) + len(e.SessionID) + len(e.Payload)
+}
+
+// EncodedSize estimates the bytes the log adds to an encoded batch
+func (l *Log) EncodedSize() int {
+	return entryOverhead + len(l.SessionID) + len(l.Source) + len(l.Service) + len(l.Payload)
+}
@@ -0,0 +1,72 @@
+// sdk-go/types/batch.go
+package types
+
+import "time"
+
+// OverflowPolicy decides what happens when an item is added to a full queue
+type OverflowPolicy int
+
+const (
+	OverflowDropOldest OverflowPolicy = iota // Discard the oldest queued items to make room
+	OverflowDropNewest                       // Reject the new item with ErrQueueFull
+	OverflowBlock                            // Wait for room until the context is done
+)
+
+// String returns the policy name
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// BatchConfig tunes the queue of one stream. A batch is sent when it reaches Size
+// items or MaxBatchBytes, or FlushInterval passes, whichever comes first. Zero values
+// inherit the client's BatchSize and FlushInterval, and leave the queue unbounded.
+type BatchConfig struct {
+	Size          int            // Items per batch
+	FlushInterval time.Duration  // Max time between sends
+	MaxBatchBytes int            // Encoded bytes per batch; defaults to and is capped by the transport limit
+	MaxQueueSize  int            // Items held while waiting to be sent
+	MaxQueueBytes int            // Encoded bytes held while waiting to be sent
+	Overflow      OverflowPolicy // Applied when either queue limit is reached
+
+	// Jitter randomly shortens or lengthens each flush interval by up to this fraction
+	// (0-1), so instances started together do not flush in lockstep
+	Jitter float64
+
+	// Adaptive tunes Size and FlushInterval at runtime; nil keeps them fixed
+	Adaptive *AdaptiveBatching
+}
+
+// AdaptiveBatching bounds a stream's self-tuning. Batches grow additively while
+// the queue backs up and halve when sends fail or are slower than TargetLatency.
+// The interval shortens while traffic is light and backs off on failures.
+// Zero values use the defaults.
+type AdaptiveBatching struct {
+	MinSize       int           // Default 10; cannot exceed the transport's items per batch
+	MaxSize       int           // Default and upper limit: the transport's items per batch
+	MinInterval   time.Duration // Default 250ms
+	MaxInterval   time.Duration // Default 30s
+	TargetLatency time.Duration // Default 250ms
+}
+
+// StreamStats describes the queue and delivery of one stream
+type StreamStats struct {
+	InQueue       int64
+	QueueBytes    int64
+	Sent          int64
+	Failed        int64 // Items in failed flushes, including chunks the flush did not reach; retried items count once per attempt
+	Dropped       int64 // Items discarded by the overflow policy
+	BatchSize     int
+	BatchBytes    int
+	FlushInterval time.Duration
+	Adaptive      bool          // BatchSize and FlushInterval are the current tuned values
+	SendLatency   time.Duration // Smoothed time taken by one batch send
+}
@@ -6,12 +6,16 @@ import "fmt"
 // Properties represents a map of property values
 type Properties map[string]interface{}
 
+// Tags are the dimensions attached to a metric series
+type Tags map[string]string
+
 // Common error types
 var (
 	ErrInvalidInput   = fmt.Errorf("invalid input")
 	ErrNetworkFailure = fmt.Errorf("network failure")
 	ErrTimeout        = fmt.Errorf("operation timed out")
 	ErrNotConnected   = fmt.Errorf("not connected")
+	ErrQueueFull      = fmt.Errorf("queue full")
 )
 
 // Error constructors for consistent error handling patterns
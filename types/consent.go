@@ -0,0 +1,54 @@
+// sdk-go/types/consent.go
+package types
+
+import "context"
+
+// ConsentCategory is a purpose a user can consent to or opt out of
+type ConsentCategory string
+
+const (
+	ConsentAnalytics ConsentCategory = "analytics"
+	ConsentMarketing ConsentCategory = "marketing"
+	ConsentLogs      ConsentCategory = "logs"
+)
+
+// ConsentMode determines what happens to events from users who have not consented
+type ConsentMode uint8
+
+const (
+	// ConsentDrop discards the event
+	ConsentDrop ConsentMode = 1
+	// ConsentAnonymize strips user, device and session identifiers; identify and group events are dropped
+	ConsentAnonymize ConsentMode = 2
+)
+
+// AnonymousUserID replaces the user ID of anonymized events
+const AnonymousUserID = "anonymous"
+
+// ConsentStore reports whether a user has consented to a category.
+// Implementations must be safe for concurrent use; they are consulted on every event.
+type ConsentStore interface {
+	HasConsent(ctx context.Context, userID string, category ConsentCategory) (bool, error)
+}
+
+// ConsentWriter is implemented by stores that can record consent changes
+type ConsentWriter interface {
+	SetConsent(ctx context.Context, userID string, category ConsentCategory, granted bool) error
+}
+
+// ConsentConfig enables consent enforcement for event tracking
+type ConsentConfig struct {
+	Store           ConsentStore
+	Mode            ConsentMode                   // Defaults to ConsentDrop
+	DefaultCategory ConsentCategory               // Category for events not in EventCategories; defaults to ConsentAnalytics
+	EventCategories map[EventName]ConsentCategory // Per-event overrides, e.g. EmailSent -> ConsentMarketing
+	FailOpen        bool                          // Allow events when the store returns an error (default: treat as opted out)
+}
+
+// PrivacyAction is a data-subject request sent to the collector
+type PrivacyAction string
+
+const (
+	PrivacyDelete   PrivacyAction = "delete"   // Erase all data held for the user
+	PrivacySuppress PrivacyAction = "suppress" // Stop processing new data for the user
+)
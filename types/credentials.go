@@ -0,0 +1,14 @@
+// sdk-go/types/credentials.go
+package types
+
+import "context"
+
+// APIKeyLength is the size in bytes of a decoded API key
+const APIKeyLength = 16
+
+// CredentialProvider supplies the hex-encoded API key used to authenticate batches.
+// The sender asks for the key before every batch, so implementations must be
+// safe for concurrent use and should return quickly (cache if the source is slow).
+type CredentialProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
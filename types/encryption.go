@@ -0,0 +1,11 @@
+// sdk-go/types/encryption.go
+package types
+
+// EncryptionConfig enables AES-GCM encryption of selected fields before they leave the process.
+// Paths are dot-separated and relative to event Properties, identity traits and log Data
+// (e.g. "email" or "address.street"); "*" matches any key and arrays are traversed element-wise.
+type EncryptionConfig struct {
+	KeyID  string            // ID of the key used to encrypt new values
+	Keys   map[string][]byte // Key ID -> AES key (16, 24 or 32 bytes); keep retired keys for decryption
+	Fields []string          // Property paths to encrypt
+}
@@ -0,0 +1,17 @@
+// sdk-go/types/error_capture.go
+package types
+
+import "time"
+
+// ErrorCaptureConfig tunes how CaptureError and Recover report errors
+type ErrorCaptureConfig struct {
+	Service    string        // Log service for captures; defaults to the client's service name
+	Source     string        // Log source for captures; defaults to the hostname
+	RateLimit  int           // Captures sent per fingerprint per RateWindow; defaults to 10
+	RateWindow time.Duration // Defaults to one minute
+	MaxFrames  int           // Stack frames kept per capture; defaults to 32
+	Repanic    bool          // Recover re-panics after capturing, once pending logs are flushed
+}
+
+// ErrorDataKey is the LogEntry.Data key holding the captured error details
+const ErrorDataKey = "error"
@@ -38,6 +38,9 @@ func (e EventName) IsStandardEvent() bool {
 	case EmailSent, EmailOpened, EmailClicked, EmailBounced, EmailUnsubscribed,
 		SupportTicketCreated, SupportTicketResolved:
 		return true
+	// Privacy
+	case ConsentUpdated, DeletionRequested, SuppressionRequested:
+		return true
 	}
 	return false
 }
@@ -114,6 +117,13 @@ const (
 	SupportTicketResolved   EventName = "Support Ticket Resolved"
 )
 
+// Privacy Events
+const (
+	ConsentUpdated       EventName = "Consent Updated"
+	DeletionRequested    EventName = "Deletion Requested"
+	SuppressionRequested EventName = "Suppression Requested"
+)
+
 // Authentication Methods
 type AuthMethod string
 
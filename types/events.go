@@ -3,10 +3,15 @@ package types
 
 import "time"
 
+// EventIDKey is the payload key carrying a caller-supplied event ID, which the
+// schema has no field for
+const EventIDKey = "event_id"
+
 // Event represents a tracking event
 type Event struct {
-	ID         string
+	ID         string // Optional event ID, e.g. for deduplication downstream
 	UserId     string
+	DeviceID   []byte // Optional device ID override (16-byte binary)
 	SessionID  []byte // Optional session ID override (16-byte binary)
 	Name       EventName
 	Properties Properties
@@ -15,29 +20,49 @@ type Event struct {
 
 // Identity represents a user identification event
 type Identity struct {
+	ID         string // Optional event ID
 	UserId     string
+	DeviceID   []byte // Optional device ID override (16-byte binary)
 	SessionID  []byte // Optional session ID override (16-byte binary)
 	Properties Properties
+	Timestamp  time.Time // Optional; defaults to the time it is queued
 }
 
 // GroupInfo represents a group event
 type GroupInfo struct {
+	ID         string // Optional event ID
 	UserId     string
 	GroupId    string
+	DeviceID   []byte // Optional device ID override (16-byte binary)
+	SessionID  []byte // Optional session ID override (16-byte binary)
+	Properties Properties
+	Timestamp  time.Time // Optional; defaults to the time it is queued
+}
+
+// Alias links a previous identifier, such as an anonymous ID, to a user
+type Alias struct {
+	ID         string // Optional event ID
+	PreviousId string
+	UserId     string
+	DeviceID   []byte // Optional device ID override (16-byte binary)
 	SessionID  []byte // Optional session ID override (16-byte binary)
 	Properties Properties
+	Timestamp  time.Time // Optional; defaults to the time it is queued
 }
 
 // Revenue represents a revenue event
 type Revenue struct {
+	ID         string // Optional event ID
 	UserID     string
 	OrderID    string
+	DeviceID   []byte // Optional device ID override (16-byte binary)
 	SessionID  []byte // Optional session ID override (16-byte binary)
 	Amount     float64
 	Currency   Currency
 	Type       RevenueType
 	Products   []Product
 	Properties Properties
+	Timestamp  time.Time // Optional; defaults to the time it is queued
 }
 
 // EventAdvanced represents an advanced tracking event with optional overrides
@@ -0,0 +1,59 @@
+// sdk-go/types/inventory.go
+package types
+
+import "time"
+
+// InventoryKind identifies what an inventory record describes
+type InventoryKind uint8
+
+const (
+	InventoryService InventoryKind = 1 // A running service instance
+	InventoryAsset   InventoryKind = 2 // Any other asset reported by the application
+)
+
+// Inventory is a snapshot of a service or asset for the live catalogue
+type Inventory struct {
+	Kind         InventoryKind // Defaults to InventoryService
+	Service      string
+	Hostname     string    // Defaults to the local hostname
+	Timestamp    time.Time // Defaults to now
+	Runtime      *RuntimeInfo
+	Build        *BuildInfo
+	Dependencies []Dependency
+	Attributes   Properties // Free-form details, e.g. region or deployment
+}
+
+// RuntimeInfo describes the Go runtime a service runs on
+type RuntimeInfo struct {
+	GoVersion  string    `json:"go_version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	NumCPU     int       `json:"num_cpu"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	PID        int       `json:"pid"`
+	StartTime  time.Time `json:"start_time"`
+}
+
+// BuildInfo describes the main module a binary was built from
+type BuildInfo struct {
+	Path      string            `json:"path"`
+	Version   string            `json:"version"`
+	GoVersion string            `json:"go_version"`
+	Settings  map[string]string `json:"settings,omitempty"` // e.g. vcs.revision, vcs.time, -tags
+}
+
+// Dependency is a module linked into the binary
+type Dependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	Replace string `json:"replace,omitempty"` // Replacement module path@version, if any
+}
+
+// InventoryConfig enables automatic inventory reporting
+type InventoryConfig struct {
+	Service             string        // Required
+	Interval            time.Duration // Time between reports after the initial one; defaults to one hour
+	Attributes          Properties    // Added to every report
+	IncludeDependencies bool          // Report module dependencies from the build info
+}
@@ -0,0 +1,20 @@
+// sdk-go/types/log_dedupe.go
+package types
+
+import "time"
+
+// LogDedupeConfig collapses repeated log entries. The first entry of a kind is sent
+// as usual; identical entries within the window are folded into a single entry sent
+// when the window closes, carrying the repeat count and first and last times seen.
+// Entries are identical when service, level, message and the keys of Data match.
+type LogDedupeConfig struct {
+	Window  time.Duration // Defaults to 5 seconds
+	MaxKeys int           // Distinct entries tracked per window; defaults to 10000
+}
+
+// Data keys added to folded log entries
+const (
+	RepeatCountKey = "repeat_count" // Duplicates folded, not counting the entry sent first
+	FirstSeenKey   = "first_seen"
+	LastSeenKey    = "last_seen"
+)
@@ -0,0 +1,26 @@
+// sdk-go/types/log_filter.go
+package types
+
+import "time"
+
+// LogFilterConfig drops logs on the client before they are encoded or queued
+type LogFilterConfig struct {
+	// Levels maps a service to the least severe level it sends; the "" key applies
+	// to services not listed. Services without a level send everything.
+	// Levels can be changed at runtime with SetLogLevel.
+	Levels map[string]LogLevel
+
+	// SampleRates maps a level to the fraction of its entries kept, from 0 to 1.
+	// Levels not listed are always kept.
+	SampleRates map[LogLevel]float64
+
+	// RateLimit is the sustained number of entries per second allowed for each
+	// service and message template; zero disables rate limiting.
+	// Numbers and other digit-bearing words are ignored when comparing messages.
+	RateLimit float64
+	RateBurst int // Defaults to RateLimit rounded up
+
+	// SummaryInterval is how often a NOTICE entry reporting dropped logs is sent
+	// for each affected service; defaults to one minute
+	SummaryInterval time.Duration
+}
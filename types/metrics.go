@@ -4,10 +4,16 @@ import "time"
 
 type TransportMetrics struct {
 	// Separate counters
-	EventsSent       int64
-	EventBatchesSent int64
-	LogsSent         int64
-	LogBatchesSent   int64
+	EventsSent           int64
+	EventBatchesSent     int64
+	LogsSent             int64
+	LogBatchesSent       int64
+	MetricsSent          int64
+	MetricBatchesSent    int64
+	InventorySent        int64
+	InventoryBatchesSent int64
+	SpansSent            int64
+	SpanBatchesSent      int64
 
 	// Combined totals
 	TotalBatchesSent int64
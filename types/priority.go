@@ -0,0 +1,15 @@
+// sdk-go/types/priority.go
+package types
+
+import "time"
+
+// PriorityConfig routes critical logs and chosen events through their own queue,
+// which flushes within Window instead of FlushInterval and whose batches are
+// written ahead of bulk traffic when the connection is busy
+type PriorityConfig struct {
+	Disabled  bool          // Send everything through the bulk queues
+	LogLevels []LogLevel    // Defaults to EMERGENCY, ALERT and CRITICAL
+	Events    []EventName   // Events sent through the priority lane; none by default
+	Window    time.Duration // Defaults to 100ms
+	Immediate bool          // Send each item from the calling goroutine without batching
+}
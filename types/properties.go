@@ -0,0 +1,477 @@
+// sdk-go/types/properties.go
+package types
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PropertyTag is the struct tag that names a field in properties, as in
+// `usercanal:"plan_name,omitempty"`. Fields without it fall back to their json
+// tag name, then to the field name; "-" skips the field.
+const PropertyTag = "usercanal"
+
+// maxPropertyDepth bounds how deeply values may nest, so that cyclic values fail
+// with an error instead of overflowing the stack
+const maxPropertyDepth = 100
+
+// convertFunc turns a value of one type, nested depth levels deep, into a basic
+// value, map[string]interface{} or []interface{}
+type convertFunc func(v reflect.Value, depth int) (interface{}, error)
+
+var (
+	plans   sync.Map // reflect.Type -> convertFunc
+	plansMu sync.Mutex
+
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+)
+
+// NormalizeProperties converts structs, typed slices and maps, and values with
+// marshaling methods into plain values, so that redaction, encryption and the
+// payload encoder see ordinary maps. Types implementing json.Marshaler are kept
+// for the encoder; encoding.TextMarshaler and fmt.Stringer values become strings,
+// in that order of preference. props is returned as is if nothing needs converting.
+func NormalizeProperties(props Properties) (Properties, error) {
+	out, _, err := normalizeMap(props, 0)
+	return out, err
+}
+
+// errTooDeep is returned for values nested deeper than maxPropertyDepth
+var errTooDeep = NewValidationError("PropertyValue", fmt.Sprintf("nested more than %d levels deep, possibly a cycle", maxPropertyDepth))
+
+// checkDepth rejects values nested deeper than maxPropertyDepth, as happens with cycles
+func checkDepth(depth int) error {
+	if depth > maxPropertyDepth {
+		return errTooDeep
+	}
+	return nil
+}
+
+// atPath prefixes err with where it occurred. Depth errors are passed through
+// below the top level, since their path would repeat the cycle.
+func atPath(err error, depth int, format string, arg interface{}) error {
+	if depth > 0 && errors.Is(err, errTooDeep) {
+		return err
+	}
+	return fmt.Errorf(format+": %w", arg, err)
+}
+
+// normalizeMap converts the values of m, copying it only if a value changes
+func normalizeMap(m map[string]interface{}, depth int) (map[string]interface{}, bool, error) {
+	if err := checkDepth(depth); err != nil {
+		return nil, false, err
+	}
+	var out map[string]interface{}
+	for key, value := range m {
+		converted, changed, err := normalizeValue(value, depth+1)
+		if err != nil {
+			return nil, false, atPath(err, depth, "property '%s'", key)
+		}
+		if changed && out == nil {
+			out = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				out[k] = v
+			}
+		}
+		if out != nil {
+			out[key] = converted
+		}
+	}
+	if out == nil {
+		return m, false, nil
+	}
+	return out, true, nil
+}
+
+// normalizeValue converts a single value and reports whether it changed
+func normalizeValue(value interface{}, depth int) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case nil, string, bool, time.Time,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64,
+		EventName, Currency, RevenueType, AuthMethod, PaymentMethod:
+		return value, false, nil
+	case Properties:
+		return normalizeMap(v, depth)
+	case map[string]interface{}:
+		return normalizeMap(v, depth)
+	case []interface{}:
+		return normalizeSlice(v, depth)
+	}
+
+	rv := reflect.ValueOf(value)
+	convert, err := planFor(rv.Type())
+	if err != nil {
+		return nil, false, err
+	}
+	converted, err := convert(rv, depth)
+	return converted, true, err
+}
+
+// normalizeSlice converts the items of s, copying it only if an item changes
+func normalizeSlice(s []interface{}, depth int) ([]interface{}, bool, error) {
+	if err := checkDepth(depth); err != nil {
+		return nil, false, err
+	}
+	var out []interface{}
+	for i, item := range s {
+		converted, changed, err := normalizeValue(item, depth+1)
+		if err != nil {
+			return nil, false, atPath(err, depth, "item[%d]", i)
+		}
+		if changed && out == nil {
+			out = make([]interface{}, len(s))
+			copy(out, s)
+		}
+		if out != nil {
+			out[i] = converted
+		}
+	}
+	if out == nil {
+		return s, false, nil
+	}
+	return out, true, nil
+}
+
+// planFor returns the cached conversion for t, building it on first use
+func planFor(t reflect.Type) (convertFunc, error) {
+	if convert, ok := plans.Load(t); ok {
+		return convert.(convertFunc), nil
+	}
+
+	plansMu.Lock()
+	defer plansMu.Unlock()
+
+	// Plans are published only once every type they reference has been built
+	building := make(map[reflect.Type]*convertFunc)
+	convert, err := buildPlan(t, building)
+	if err != nil {
+		return nil, err
+	}
+	for bt, c := range building {
+		plans.Store(bt, *c)
+	}
+	return convert, nil
+}
+
+// buildPlan builds the conversion for t. Types already being built are referenced
+// indirectly so that recursive types terminate. Must be called with plansMu held.
+func buildPlan(t reflect.Type, building map[reflect.Type]*convertFunc) (convertFunc, error) {
+	if convert, ok := plans.Load(t); ok {
+		return convert.(convertFunc), nil
+	}
+	if pending, ok := building[t]; ok {
+		return func(v reflect.Value, depth int) (interface{}, error) { return (*pending)(v, depth) }, nil
+	}
+
+	pending := new(convertFunc)
+	building[t] = pending
+	convert, err := makePlan(t, building)
+	if err != nil {
+		return nil, err
+	}
+	*pending = convert
+	return convert, nil
+}
+
+func makePlan(t reflect.Type, building map[reflect.Type]*convertFunc) (convertFunc, error) {
+	// Pointer and interface types can implement the interfaces below with a nil value
+	nilable := t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface
+
+	switch {
+	case t.Implements(jsonMarshalerType):
+		return func(v reflect.Value, _ int) (interface{}, error) {
+			if nilable && v.IsNil() {
+				return nil, nil
+			}
+			return v.Interface(), nil
+		}, nil
+
+	case t.Implements(textMarshalerType):
+		return func(v reflect.Value, _ int) (interface{}, error) {
+			if nilable && v.IsNil() {
+				return nil, nil
+			}
+			text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, NewValidationError("PropertyValue", fmt.Sprintf("%s: %v", t, err))
+			}
+			return string(text), nil
+		}, nil
+
+	case t.Implements(stringerType):
+		return func(v reflect.Value, _ int) (interface{}, error) {
+			if nilable && v.IsNil() {
+				return nil, nil
+			}
+			return v.Interface().(fmt.Stringer).String(), nil
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(v reflect.Value, _ int) (interface{}, error) { return v.Bool(), nil }, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(v reflect.Value, _ int) (interface{}, error) { return v.Int(), nil }, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(v reflect.Value, _ int) (interface{}, error) { return v.Uint(), nil }, nil
+	case reflect.Float32, reflect.Float64:
+		return func(v reflect.Value, _ int) (interface{}, error) { return v.Float(), nil }, nil
+	case reflect.String:
+		return func(v reflect.Value, _ int) (interface{}, error) { return v.String(), nil }, nil
+
+	case reflect.Interface:
+		return func(v reflect.Value, depth int) (interface{}, error) {
+			if v.IsNil() {
+				return nil, nil
+			}
+			converted, _, err := normalizeValue(v.Elem().Interface(), depth)
+			return converted, err
+		}, nil
+
+	case reflect.Pointer:
+		elem, err := buildPlan(t.Elem(), building)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value, depth int) (interface{}, error) {
+			if v.IsNil() {
+				return nil, nil
+			}
+			if err := checkDepth(depth); err != nil {
+				return nil, err
+			}
+			return elem(v.Elem(), depth+1)
+		}, nil
+
+	case reflect.Slice, reflect.Array:
+		return slicePlan(t, building)
+	case reflect.Map:
+		return mapPlan(t, building)
+	case reflect.Struct:
+		return structPlan(t, building)
+	}
+
+	return nil, NewValidationError("PropertyValue", fmt.Sprintf("unsupported type: %s", t))
+}
+
+func slicePlan(t reflect.Type, building map[reflect.Type]*convertFunc) (convertFunc, error) {
+	// Byte slices are left to the encoder, which sends them as base64
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return func(v reflect.Value, _ int) (interface{}, error) {
+			if v.IsNil() {
+				return nil, nil
+			}
+			return v.Bytes(), nil
+		}, nil
+	}
+
+	elem, err := buildPlan(t.Elem(), building)
+	if err != nil {
+		return nil, err
+	}
+	isSlice := t.Kind() == reflect.Slice
+	return func(v reflect.Value, depth int) (interface{}, error) {
+		if isSlice && v.IsNil() {
+			return nil, nil
+		}
+		if err := checkDepth(depth); err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			item, err := elem(v.Index(i), depth+1)
+			if err != nil {
+				return nil, atPath(err, depth, "item[%d]", i)
+			}
+			out[i] = item
+		}
+		return out, nil
+	}, nil
+}
+
+func mapPlan(t reflect.Type, building map[reflect.Type]*convertFunc) (convertFunc, error) {
+	key, err := mapKeyPlan(t.Key())
+	if err != nil {
+		return nil, err
+	}
+	elem, err := buildPlan(t.Elem(), building)
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value, depth int) (interface{}, error) {
+		if v.IsNil() {
+			return nil, nil
+		}
+		if err := checkDepth(depth); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			name, err := key(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := elem(iter.Value(), depth+1)
+			if err != nil {
+				return nil, atPath(err, depth, "property '%s'", name)
+			}
+			out[name] = value
+		}
+		return out, nil
+	}, nil
+}
+
+// mapKeyPlan returns how map keys of type t become strings, as encoding/json does
+func mapKeyPlan(t reflect.Type) (func(reflect.Value) (string, error), error) {
+	switch {
+	case t.Kind() == reflect.String:
+		return func(v reflect.Value) (string, error) { return v.String(), nil }, nil
+	case t.Implements(textMarshalerType):
+		return func(v reflect.Value) (string, error) {
+			text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+			return string(text), err
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(v reflect.Value) (string, error) { return strconv.FormatInt(v.Int(), 10), nil }, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(v reflect.Value) (string, error) { return strconv.FormatUint(v.Uint(), 10), nil }, nil
+	}
+	return nil, NewValidationError("PropertyValue", fmt.Sprintf("unsupported map key type: %s", t))
+}
+
+// structField is one property taken from a struct
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	convert   convertFunc
+}
+
+func structPlan(t reflect.Type, building map[reflect.Type]*convertFunc) (convertFunc, error) {
+	fields, err := structFields(t, nil, building, make(map[string]bool), map[reflect.Type]bool{t: true})
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value, depth int) (interface{}, error) {
+		if err := checkDepth(depth); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				continue // Field of a nil embedded pointer
+			}
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			value, err := f.convert(fv, depth+1)
+			if err != nil {
+				return nil, atPath(err, depth, "property '%s'", f.name)
+			}
+			out[f.name] = value
+		}
+		return out, nil
+	}, nil
+}
+
+// structFields lists the properties of t. Untagged embedded structs are flattened,
+// with fields of the outer struct taking precedence over promoted ones; embedding
+// that would recurse into a struct already on the path is ignored.
+func structFields(t reflect.Type, index []int, building map[reflect.Type]*convertFunc, seen map[string]bool, path map[reflect.Type]bool) ([]structField, error) {
+	var fields []structField
+	var embedded []reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, omitEmpty, skip := fieldName(sf)
+		if skip {
+			continue
+		}
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		convert, err := buildPlan(sf.Type, building)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		fields = append(fields, structField{
+			name:      name,
+			index:     append(append([]int(nil), index...), i),
+			omitEmpty: omitEmpty,
+			convert:   convert,
+		})
+	}
+
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if path[ft] {
+			continue
+		}
+		path[ft] = true
+		promoted, err := structFields(ft, append(append([]int(nil), index...), sf.Index...), building, seen, path)
+		delete(path, ft)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, promoted...)
+	}
+	return fields, nil
+}
+
+// fieldName reads the property name and options of a struct field
+func fieldName(sf reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := sf.Tag.Lookup(PropertyTag)
+	if !ok {
+		tag = sf.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
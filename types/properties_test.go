@@ -0,0 +1,239 @@
+// sdk-go/types/properties_test.go
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type plan struct {
+	Name     string `usercanal:"plan_name"`
+	Seats    int    `json:"seats"`
+	Trial    bool   `usercanal:"trial,omitempty"`
+	Internal string `usercanal:"-"`
+	hidden   string
+	Renews   *time.Time
+}
+
+type base struct {
+	ID   string `usercanal:"id"`
+	Kind string `usercanal:"kind"`
+}
+
+type account struct {
+	base
+	Kind  string `usercanal:"kind"` // Shadows base.Kind
+	Plans []plan `usercanal:"plans"`
+}
+
+type node struct {
+	Value int   `usercanal:"value"`
+	Next  *node `usercanal:"next,omitempty"`
+}
+
+type tier int
+
+func (t tier) String() string { return [...]string{"free", "pro"}[t] }
+
+type rawJSON struct{}
+
+func (rawJSON) MarshalJSON() ([]byte, error) { return []byte(`"raw"`), nil }
+
+func TestNormalizeProperties(t *testing.T) {
+	renews := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "basic value", value: "v", want: "v"},
+		{
+			name:  "struct with tags, json marshaler field kept",
+			value: plan{Name: "pro", Seats: 5, Internal: "x", hidden: "y", Renews: &renews},
+			want:  map[string]interface{}{"plan_name": "pro", "seats": int64(5), "Renews": &renews},
+		},
+		{
+			name:  "omitempty and nil pointer",
+			value: plan{Name: "free", Trial: true},
+			want:  map[string]interface{}{"plan_name": "free", "seats": int64(0), "trial": true, "Renews": nil},
+		},
+		{
+			name:  "embedded struct flattened, outer field wins",
+			value: account{base: base{ID: "a1", Kind: "inner"}, Kind: "outer"},
+			want:  map[string]interface{}{"id": "a1", "kind": "outer", "plans": nil},
+		},
+		{
+			name:  "typed slice",
+			value: []int{1, 2},
+			want:  []interface{}{int64(1), int64(2)},
+		},
+		{
+			name:  "array",
+			value: [2]string{"a", "b"},
+			want:  []interface{}{"a", "b"},
+		},
+		{
+			name:  "typed map with integer keys",
+			value: map[int]float32{7: 1.5},
+			want:  map[string]interface{}{"7": float64(1.5)},
+		},
+		{
+			name:  "stringer",
+			value: tier(1),
+			want:  "pro",
+		},
+		{
+			name:  "text marshaler",
+			value: net.IPv4(10, 0, 0, 1),
+			want:  "10.0.0.1",
+		},
+		{
+			name:  "json marshaler kept for the encoder",
+			value: rawJSON{},
+			want:  rawJSON{},
+		},
+		{
+			name:  "byte slice kept for the encoder",
+			value: []byte("hi"),
+			want:  []byte("hi"),
+		},
+		{
+			name:  "recursive type",
+			value: &node{Value: 1, Next: &node{Value: 2}},
+			want: map[string]interface{}{
+				"value": int64(1),
+				"next":  map[string]interface{}{"value": int64(2)},
+			},
+		},
+		{
+			name:  "structs nested in plain maps and slices",
+			value: map[string]interface{}{"items": []interface{}{tier(0), "x"}},
+			want:  map[string]interface{}{"items": []interface{}{"free", "x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeProperties(Properties{"p": tt.value})
+			if err != nil {
+				t.Fatalf("NormalizeProperties: %v", err)
+			}
+			if !reflect.DeepEqual(got["p"], tt.want) {
+				t.Errorf("got %#v, want %#v", got["p"], tt.want)
+			}
+		})
+	}
+}
+
+// Plain properties are returned without copying
+func TestNormalizePropertiesUnchanged(t *testing.T) {
+	props := Properties{"s": "v", "n": 1, "nested": map[string]interface{}{"b": true}}
+	got, err := NormalizeProperties(props)
+	if err != nil {
+		t.Fatalf("NormalizeProperties: %v", err)
+	}
+	if reflect.ValueOf(got).Pointer() != reflect.ValueOf(props).Pointer() {
+		t.Error("unchanged properties were copied")
+	}
+
+	props["plan"] = plan{Name: "pro"}
+	got, err = NormalizeProperties(props)
+	if err != nil {
+		t.Fatalf("NormalizeProperties: %v", err)
+	}
+	if _, ok := props["plan"].(plan); !ok {
+		t.Error("caller's properties were modified")
+	}
+	if _, ok := got["plan"].(map[string]interface{}); !ok {
+		t.Errorf("plan = %T, want a map", got["plan"])
+	}
+}
+
+func TestNormalizePropertiesErrors(t *testing.T) {
+	cyclicMap := map[string]interface{}{}
+	cyclicMap["self"] = cyclicMap
+	cyclicSlice := []interface{}{nil}
+	cyclicSlice[0] = cyclicSlice
+	cyclicNode := &node{Value: 1}
+	cyclicNode.Next = cyclicNode
+	var deep interface{} = "leaf"
+	for i := 0; i < maxPropertyDepth+1; i++ {
+		deep = []interface{}{deep}
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		tooDeep bool
+	}{
+		{name: "self-containing map", value: cyclicMap, tooDeep: true},
+		{name: "self-containing slice", value: cyclicSlice, tooDeep: true},
+		{name: "cyclic pointers", value: cyclicNode, tooDeep: true},
+		{name: "too deep", value: deep, tooDeep: true},
+		{name: "unsupported type", value: make(chan int)},
+		{name: "unsupported map key", value: map[[2]int]string{{1, 2}: "x"}},
+		{name: "unsupported field", value: struct{ F func() }{}},
+		{name: "unsupported value in interface", value: []interface{}{struct{ C interface{} }{C: make(chan int)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeProperties(Properties{"p": tt.value})
+			if err == nil {
+				t.Fatal("no error")
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Errorf("error %v is not a ValidationError", err)
+			}
+			if errors.Is(err, errTooDeep) != tt.tooDeep {
+				t.Errorf("error %v, too deep = %v", err, tt.tooDeep)
+			}
+		})
+	}
+}
+
+// Plans are cached per type and stay usable from concurrent callers
+func TestPlanForIsCached(t *testing.T) {
+	first, err := planFor(reflect.TypeFor[account]())
+	if err != nil {
+		t.Fatalf("planFor: %v", err)
+	}
+	second, _ := planFor(reflect.TypeFor[account]())
+	if reflect.ValueOf(first).Pointer() != reflect.ValueOf(second).Pointer() {
+		t.Error("plan rebuilt for a cached type")
+	}
+
+	done := make(chan error)
+	for i := 0; i < 8; i++ {
+		go func() {
+			_, err := NormalizeProperties(Properties{"n": &node{Value: 1, Next: &node{Value: 2}}})
+			done <- err
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("NormalizeProperties: %v", err)
+		}
+	}
+}
+
+// Converted values encode the same way as the originals would have
+func TestNormalizedJSON(t *testing.T) {
+	props, err := NormalizeProperties(Properties{"plan": plan{Name: "pro", Seats: 2}, "raw": rawJSON{}})
+	if err != nil {
+		t.Fatalf("NormalizeProperties: %v", err)
+	}
+	got, err := json.Marshal(props)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"plan":{"Renews":null,"plan_name":"pro","seats":2},"raw":"raw"}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
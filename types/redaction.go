@@ -0,0 +1,41 @@
+// sdk-go/types/redaction.go
+package types
+
+// RedactionAction determines what happens to a value matched by a redaction rule
+type RedactionAction uint8
+
+const (
+	RedactDrop     RedactionAction = 1 // Remove the key (or array element) entirely
+	RedactMask     RedactionAction = 2 // Replace the value, or the matched substring, with RedactedPlaceholder
+	RedactTruncate RedactionAction = 3 // Keep only the first TruncateLength characters
+	RedactHash     RedactionAction = 4 // Replace with a salted SHA-256 hash ("sha256:<hex>")
+)
+
+// RedactedPlaceholder replaces masked values
+const RedactedPlaceholder = "[REDACTED]"
+
+// Built-in value patterns for common PII and secrets
+const (
+	RedactPatternEmail      = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`
+	RedactPatternCreditCard = `\b(?:\d[ \-]?){12,18}\d\b`
+	RedactPatternJWT        = `\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`
+	RedactPatternIPv4       = `\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`
+	RedactPatternIPv6       = `\b(?:[0-9A-Fa-f]{1,4}:){7}[0-9A-Fa-f]{1,4}\b`
+)
+
+// RedactionRule selects values by key name, JSON path or value pattern.
+// A rule matches when any of its selectors match; Keys and Paths act on the whole
+// value, Pattern acts on string values (Mask, Truncate and Hash apply to the matched substring).
+type RedactionRule struct {
+	Keys           []string // Property names, matched case-insensitively at any depth
+	Paths          []string // Dot-separated paths from the root ("user.address.*"), "*" matches any key
+	Pattern        string   // Regular expression applied to string values and log messages
+	Action         RedactionAction
+	TruncateLength int // Characters kept by RedactTruncate
+}
+
+// RedactionConfig holds the redaction rules applied to properties and log data before conversion
+type RedactionConfig struct {
+	Rules []RedactionRule
+	Salt  string // Salt mixed into RedactHash digests
+}
@@ -0,0 +1,38 @@
+// sdk-go/types/runtime_metrics.go
+package types
+
+import "time"
+
+// RuntimeMetricsOutput selects how sampled runtime metrics are sent
+type RuntimeMetricsOutput uint8
+
+const (
+	// RuntimeMetricsAsMetrics records gauges and counters in the metrics registry
+	RuntimeMetricsAsMetrics RuntimeMetricsOutput = 1
+	// RuntimeMetricsAsLogs sends one structured log entry per sample
+	RuntimeMetricsAsLogs RuntimeMetricsOutput = 2
+)
+
+// DefaultRuntimeMetrics are the runtime/metrics names sampled when none are configured:
+// goroutines, heap, GC activity, GC pauses and scheduler latency.
+var DefaultRuntimeMetrics = []string{
+	"/sched/goroutines:goroutines",
+	"/sched/gomaxprocs:threads",
+	"/memory/classes/total:bytes",
+	"/memory/classes/heap/objects:bytes",
+	"/gc/heap/goal:bytes",
+	"/gc/heap/allocs:bytes",
+	"/gc/cycles/total:gc-cycles",
+	"/sched/pauses/total/gc:seconds",
+	"/sched/latencies:seconds",
+}
+
+// RuntimeMetricsConfig enables periodic sampling of Go runtime metrics
+type RuntimeMetricsConfig struct {
+	Interval time.Duration        // Time between samples; defaults to 30s. Longer intervals lower the overhead
+	Metrics  []string             // runtime/metrics names to sample; defaults to DefaultRuntimeMetrics
+	Output   RuntimeMetricsOutput // Defaults to RuntimeMetricsAsMetrics
+	Tags     Tags                 // Added to every series when sent as metrics
+	Service  string               // Log service when sent as logs; defaults to "go-runtime"
+	Source   string               // Log source when sent as logs; defaults to the hostname
+}
@@ -5,13 +5,33 @@ import "time"
 
 type Stats struct {
 	// Client queue state (from batch managers)
-	EventsInQueue int64
-	LogsInQueue   int64
+	EventsInQueue  int64
+	LogsInQueue    int64
+	MetricsInQueue int64
 
 	// Summary counters (from transport metrics)
 	EventsSent   int64
 	LogsSent     int64
-	EventsFailed int64
+	MetricsSent  int64
+	SpansSent    int64
+	EventsFailed int64 // Events in failed send attempts
+	LogsFailed   int64 // Logs in failed send attempts
+
+	// Per-stream queue and delivery detail
+	Events StreamStats
+	Logs   StreamStats
+
+	// Privacy pipeline counters
+	Redactions       int64 // Values dropped, masked, truncated or hashed by redaction rules
+	EventsSuppressed int64 // Events dropped because the user had not consented or was suppressed
+	EventsAnonymized int64 // Events sent without user identifiers because the user had not consented
+
+	// Error capture
+	ErrorsRateLimited int64 // Captures dropped by per-fingerprint rate limiting
+
+	// Log filtering
+	LogsFiltered     int64 // Logs dropped by level, sampling or rate limits
+	LogsDeduplicated int64 // Logs folded into a repeated entry
 
 	// Client connection view
 	ConnectionState  string
@@ -0,0 +1,69 @@
+// sdk-go/types/trace.go
+package types
+
+import "encoding/hex"
+
+// TraceID identifies a trace across services (W3C Trace Context)
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace (W3C Trace Context)
+type SpanID [8]byte
+
+// IsValid reports whether the ID is non-zero
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String returns the lowercase hex encoding used in traceparent headers
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid reports whether the ID is non-zero
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String returns the lowercase hex encoding used in traceparent headers
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the part of a span that propagates across process boundaries
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool // Extracted from an incoming request rather than started locally
+}
+
+// IsValid reports whether both IDs are set
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// SpanKind describes the role of a span in its trace
+type SpanKind uint8
+
+const (
+	SpanInternal SpanKind = 1 // Operation within a service (default)
+	SpanServer   SpanKind = 2 // Handling of an incoming request
+	SpanClient   SpanKind = 3 // Outgoing request to another service
+	SpanProducer SpanKind = 4 // Message sent to a queue or stream
+	SpanConsumer SpanKind = 5 // Message received from a queue or stream
+)
+
+// SpanStatus is the outcome of the operation a span covers
+type SpanStatus uint8
+
+const (
+	SpanStatusUnset SpanStatus = 0
+	SpanStatusOK    SpanStatus = 1
+	SpanStatusError SpanStatus = 2
+)
+
+// Property keys added to logs and events emitted inside a span
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
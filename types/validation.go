@@ -2,7 +2,9 @@
 package types
 
 import (
+	"encoding/hex"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/usercanal/sdk-go/internal/logger"
@@ -19,6 +21,9 @@ func (e *Event) Validate() error {
 	if !e.Name.IsStandardEvent() {
 		logger.Warn("Non-standard event name used: %s", e.Name)
 	}
+	if err := validateIDOverrides(e.DeviceID, e.SessionID); err != nil {
+		return err
+	}
 	if err := validateProperties(e.Properties); err != nil {
 		return fmt.Errorf("properties validation failed: %w", err)
 	}
@@ -58,6 +63,9 @@ func (i *Identity) Validate() error {
 	if i.UserId == "" {
 		return NewValidationError("UserId", "is required")
 	}
+	if err := validateIDOverrides(i.DeviceID, i.SessionID); err != nil {
+		return err
+	}
 	if err := validateProperties(i.Properties); err != nil {
 		return fmt.Errorf("properties validation failed: %w", err)
 	}
@@ -72,12 +80,35 @@ func (g *GroupInfo) Validate() error {
 	if g.GroupId == "" {
 		return NewValidationError("GroupId", "is required")
 	}
+	if err := validateIDOverrides(g.DeviceID, g.SessionID); err != nil {
+		return err
+	}
 	if err := validateProperties(g.Properties); err != nil {
 		return fmt.Errorf("properties validation failed: %w", err)
 	}
 	return nil
 }
 
+// Alias validation
+func (a *Alias) Validate() error {
+	if a.PreviousId == "" {
+		return NewValidationError("PreviousId", "is required")
+	}
+	if a.UserId == "" {
+		return NewValidationError("UserId", "is required")
+	}
+	if a.PreviousId == a.UserId {
+		return NewValidationError("PreviousId", "must differ from UserId")
+	}
+	if err := validateIDOverrides(a.DeviceID, a.SessionID); err != nil {
+		return err
+	}
+	if err := validateProperties(a.Properties); err != nil {
+		return fmt.Errorf("properties validation failed: %w", err)
+	}
+	return nil
+}
+
 // Revenue validation
 func (r *Revenue) Validate() error {
 	if r.UserID == "" {
@@ -95,6 +126,9 @@ func (r *Revenue) Validate() error {
 	if string(r.Type) == "" {
 		return NewValidationError("Type", "is required")
 	}
+	if err := validateIDOverrides(r.DeviceID, r.SessionID); err != nil {
+		return err
+	}
 
 	for i, p := range r.Products {
 		if err := p.Validate(); err != nil {
@@ -142,31 +176,63 @@ func (l *LogEntry) Validate() error {
 	return nil
 }
 
+// Inventory validation
+func (i *Inventory) Validate() error {
+	if i.Kind < InventoryService || i.Kind > InventoryAsset {
+		return NewValidationError("Kind", "invalid inventory kind")
+	}
+	if i.Service == "" {
+		return NewValidationError("Service", "is required")
+	}
+	if i.Hostname == "" {
+		return NewValidationError("Hostname", "is required")
+	}
+	if err := validateProperties(i.Attributes); err != nil {
+		return fmt.Errorf("attributes validation failed: %w", err)
+	}
+	return nil
+}
+
 // Helper validation functions
-func validateProperties(props Properties) error {
-	if props == nil {
-		return nil
+func validateIDOverrides(deviceID, sessionID []byte) error {
+	if deviceID != nil && len(deviceID) != 16 {
+		return NewValidationError("DeviceID", "must be exactly 16 bytes when provided")
 	}
+	if sessionID != nil && len(sessionID) != 16 {
+		return NewValidationError("SessionID", "must be exactly 16 bytes when provided")
+	}
+	return nil
+}
+
+// validateProperties checks property keys and value types without converting
+// anything; conversion happens once, when the properties are prepared for sending
+func validateProperties(props Properties) error {
+	return validatePropertyMap(props, 0)
+}
 
+func validatePropertyMap(props map[string]interface{}, depth int) error {
+	if err := checkDepth(depth); err != nil {
+		return err
+	}
 	for key, value := range props {
 		if key == "" {
 			return NewValidationError("PropertyKey", "cannot be empty")
 		}
 
-		if err := validatePropertyValue(value); err != nil {
-			return fmt.Errorf("property '%s' validation failed: %w", key, err)
+		if err := validatePropertyValue(value, depth+1); err != nil {
+			return atPath(err, depth, "property '%s' validation failed", key)
 		}
 	}
 	return nil
 }
 
-func validatePropertyValue(value interface{}) error {
+func validatePropertyValue(value interface{}, depth int) error {
 	switch v := value.(type) {
 	case nil:
 		return nil
 	case string:
 		return nil
-	case int, int32, int64, float32, float64:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		return nil
 	case bool:
 		return nil
@@ -183,15 +249,37 @@ func validatePropertyValue(value interface{}) error {
 	case PaymentMethod:
 		return nil
 	case []interface{}:
+		if err := checkDepth(depth); err != nil {
+			return err
+		}
 		for i, item := range v {
-			if err := validatePropertyValue(item); err != nil {
-				return fmt.Errorf("array item[%d] validation failed: %w", i, err)
+			if err := validatePropertyValue(item, depth+1); err != nil {
+				return atPath(err, depth, "array item[%d] validation failed", i)
 			}
 		}
 		return nil
+	case Properties:
+		return validatePropertyMap(v, depth)
 	case map[string]interface{}:
-		return validateProperties(Properties(v))
+		return validatePropertyMap(v, depth)
 	default:
-		return NewValidationError("PropertyValue", fmt.Sprintf("unsupported type: %T", value))
+		// Structs, typed collections and values with marshaling methods are
+		// accepted if their type can be converted
+		_, err := planFor(reflect.TypeOf(value))
+		return err
+	}
+}
+
+// ValidateAPIKey checks that key is a hex-encoded 16-byte API key
+func ValidateAPIKey(key string) error {
+	if key == "" {
+		return NewValidationError("apiKey", "is required")
+	}
+	if len(key) != APIKeyLength*2 {
+		return NewValidationError("apiKey", fmt.Sprintf("must be %d hex characters (%d bytes)", APIKeyLength*2, APIKeyLength))
 	}
+	if _, err := hex.DecodeString(key); err != nil {
+		return NewValidationError("apiKey", "must be valid hex")
+	}
+	return nil
 }
@@ -0,0 +1,96 @@
+// sdk-go/types/validation_test.go
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateProperties(t *testing.T) {
+	cyclic := map[string]interface{}{}
+	cyclic["self"] = cyclic
+	cyclicSlice := []interface{}{nil}
+	cyclicSlice[0] = cyclicSlice
+
+	tests := []struct {
+		name    string
+		props   Properties
+		wantErr bool
+		tooDeep bool
+	}{
+		{name: "nil"},
+		{name: "basic values", props: Properties{"s": "v", "n": 1, "f": 1.5, "b": true, "c": CurrencyUSD}},
+		{name: "nested", props: Properties{"m": map[string]interface{}{"a": []interface{}{1, "x"}}, "p": Properties{"k": "v"}}},
+		{name: "struct", props: Properties{"plan": plan{Name: "pro"}}},
+		{name: "empty key", props: Properties{"": "v"}, wantErr: true},
+		{name: "empty nested key", props: Properties{"m": map[string]interface{}{"": 1}}, wantErr: true},
+		{name: "unsupported type", props: Properties{"c": make(chan int)}, wantErr: true},
+		{name: "unsupported nested type", props: Properties{"a": []interface{}{func() {}}}, wantErr: true},
+		{name: "self-containing map", props: Properties{"m": cyclic}, wantErr: true, tooDeep: true},
+		{name: "self-containing slice", props: Properties{"a": cyclicSlice}, wantErr: true, tooDeep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{UserId: "u1", Name: FeatureUsed, Properties: tt.props}
+			err := event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, errTooDeep) != tt.tooDeep {
+				t.Errorf("Validate = %v, too deep = %v", err, tt.tooDeep)
+			}
+		})
+	}
+}
+
+// Validation only inspects types; values are converted once, later
+func TestValidatePropertiesDoesNotConvert(t *testing.T) {
+	props := Properties{"plan": plan{Name: "pro"}, "tier": tier(1)}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := validateProperties(props); err != nil {
+			t.Fatalf("validateProperties: %v", err)
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("validateProperties allocated %.1f times, want 0", allocs)
+	}
+}
+
+func TestInventoryValidate(t *testing.T) {
+	valid := func() Inventory {
+		return Inventory{Kind: InventoryService, Service: "api", Hostname: "host-1"}
+	}
+	tests := []struct {
+		name      string
+		edit      func(*Inventory)
+		wantField string // "" when valid
+	}{
+		{name: "valid", edit: func(*Inventory) {}},
+		{name: "asset", edit: func(i *Inventory) { i.Kind = InventoryAsset }},
+		{name: "with attributes", edit: func(i *Inventory) { i.Attributes = Properties{"region": "eu"} }},
+		{name: "zero kind", edit: func(i *Inventory) { i.Kind = 0 }, wantField: "Kind"},
+		{name: "unknown kind", edit: func(i *Inventory) { i.Kind = InventoryAsset + 1 }, wantField: "Kind"},
+		{name: "missing service", edit: func(i *Inventory) { i.Service = "" }, wantField: "Service"},
+		{name: "missing hostname", edit: func(i *Inventory) { i.Hostname = "" }, wantField: "Hostname"},
+		{name: "empty attribute key", edit: func(i *Inventory) { i.Attributes = Properties{"": 1} }, wantField: "PropertyKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := valid()
+			tt.edit(&inv)
+			err := inv.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.wantField {
+				t.Errorf("Validate = %v, want a %s ValidationError", err, tt.wantField)
+			}
+		})
+	}
+}
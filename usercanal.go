@@ -3,9 +3,20 @@ package usercanal
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/usercanal/sdk-go/internal/api"
+	"github.com/usercanal/sdk-go/internal/consent"
+	"github.com/usercanal/sdk-go/internal/credentials"
+	"github.com/usercanal/sdk-go/internal/encrypt"
+	"github.com/usercanal/sdk-go/internal/identity"
+	"github.com/usercanal/sdk-go/internal/inventory"
+	"github.com/usercanal/sdk-go/internal/logbridge"
+	"github.com/usercanal/sdk-go/internal/metrics"
+	"github.com/usercanal/sdk-go/internal/superprops"
+	"github.com/usercanal/sdk-go/internal/trace"
 	"github.com/usercanal/sdk-go/internal/version"
 	"github.com/usercanal/sdk-go/types"
 )
@@ -17,11 +28,57 @@ type Config struct {
 	FlushInterval time.Duration // Max time between sends
 	MaxRetries    int           // Retry attempts
 	Debug         bool          // Enable debug logging
+	ServiceName   string        // Service reported with spans; defaults to the executable name
+
+	// Credentials supplies the API key for each batch; when set, the apiKey
+	// argument to NewClient may be empty
+	Credentials CredentialProvider
+
+	// Encryption encrypts the listed property paths with AES-GCM before sending
+	Encryption *EncryptionConfig
+
+	// Redaction drops, masks, truncates or hashes PII in properties and log data
+	Redaction *RedactionConfig
+
+	// Consent drops or anonymizes events from users who have opted out
+	Consent *ConsentConfig
+
+	// MetricsInterval is the window over which metric instruments are aggregated
+	MetricsInterval time.Duration
+
+	// Inventory reports this service's runtime, build and dependencies at startup and on an interval
+	Inventory *InventoryConfig
+
+	// RuntimeMetrics samples goroutine, heap, GC and scheduler metrics on an interval
+	RuntimeMetrics *RuntimeMetricsConfig
+
+	// ErrorCapture tunes CaptureError and Recover: rate limits, stack depth and re-panicking
+	ErrorCapture *ErrorCaptureConfig
+
+	// LogFilter drops logs by level, sampling and rate limits before they are queued
+	LogFilter *LogFilterConfig
+
+	// LogDedupe collapses identical log entries repeated within a window
+	LogDedupe *LogDedupeConfig
+
+	// EventBatching and LogBatching tune each stream's queue; unset values use
+	// BatchSize and FlushInterval
+	EventBatching *BatchConfig
+	LogBatching   *BatchConfig
+
+	// Priority sends EMERGENCY, ALERT and CRITICAL logs, and chosen events, through a
+	// fast lane ahead of bulk traffic; it is on by default
+	Priority *PriorityConfig
+
+	// DefaultProperties are added to every event's properties and log entry's Data
+	// alike, e.g. env or app_version; per-call values take precedence. See Client.Register.
+	DefaultProperties Properties
 }
 
 // Client is a facade over the internal API client
 type Client struct {
 	internal *api.Client
+	scope    Properties // Added by a handle derived with With; never modified
 }
 
 func (c *Client) GetStats() Stats {
@@ -29,6 +86,7 @@ func (c *Client) GetStats() Stats {
 }
 
 // NewClient creates a new client with configuration
+// The API key must be 32 hex characters (16 bytes) unless Config.Credentials is set
 func NewClient(apiKey string, cfg ...Config) (*Client, error) {
 	var options []api.Option
 
@@ -40,6 +98,21 @@ func NewClient(apiKey string, cfg ...Config) (*Client, error) {
 			api.WithFlushInterval(c.FlushInterval),
 			api.WithMaxRetries(c.MaxRetries),
 			api.WithDebug(c.Debug),
+			api.WithServiceName(c.ServiceName),
+			api.WithCredentials(c.Credentials),
+			api.WithEncryption(c.Encryption),
+			api.WithRedaction(c.Redaction),
+			api.WithConsent(c.Consent),
+			api.WithMetricsInterval(c.MetricsInterval),
+			api.WithInventory(c.Inventory),
+			api.WithRuntimeMetrics(c.RuntimeMetrics),
+			api.WithErrorCapture(c.ErrorCapture),
+			api.WithLogFilter(c.LogFilter),
+			api.WithLogDedupe(c.LogDedupe),
+			api.WithPriority(c.Priority),
+			api.WithEventBatching(c.EventBatching),
+			api.WithLogBatching(c.LogBatching),
+			api.WithDefaultProperties(c.DefaultProperties),
 		)
 	}
 
@@ -51,13 +124,36 @@ func NewClient(apiKey string, cfg ...Config) (*Client, error) {
 	return &Client{internal: client}, nil
 }
 
+// StaticCredentials returns a provider for a fixed API key
+func StaticCredentials(apiKey string) CredentialProvider {
+	return credentials.NewStatic(apiKey)
+}
+
+// EnvCredentials returns a provider that reads the API key from an environment
+// variable on every batch (USERCANAL_API_KEY when name is empty)
+func EnvCredentials(name string) CredentialProvider {
+	return credentials.NewEnv(name)
+}
+
+// NewFileCredentials returns a provider that watches a key file and picks up
+// rotations on the given poll interval. Close it when the client is closed.
+func NewFileCredentials(path string, interval time.Duration) (*FileCredentials, error) {
+	return credentials.NewFile(path, interval)
+}
+
+// DecryptField decrypts a value produced by field-level encryption, as found in a
+// JSON-decoded payload. Intended for downstream consumers holding the keys.
+func DecryptField(value interface{}, keys map[string][]byte) (interface{}, error) {
+	return encrypt.Decrypt(value, keys)
+}
+
 // Event protocol
 // Simplified parameter approach for better developer experience
 func (c *Client) Event(ctx context.Context, userID string, eventName EventName, properties Properties) error {
 	event := Event{
 		UserId:     userID,
 		Name:       eventName,
-		Properties: properties,
+		Properties: c.scoped(properties),
 	}
 	return c.internal.Track(ctx, event)
 }
@@ -65,7 +161,7 @@ func (c *Client) Event(ctx context.Context, userID string, eventName EventName,
 func (c *Client) EventIdentify(ctx context.Context, userID string, traits Properties) error {
 	identity := Identity{
 		UserId:     userID,
-		Properties: traits,
+		Properties: c.scoped(traits),
 	}
 	return c.internal.Identify(ctx, identity)
 }
@@ -74,7 +170,7 @@ func (c *Client) EventGroup(ctx context.Context, userID string, groupID string,
 	group := GroupInfo{
 		UserId:     userID,
 		GroupId:    groupID,
-		Properties: properties,
+		Properties: c.scoped(properties),
 	}
 	return c.internal.Group(ctx, group)
 }
@@ -85,12 +181,82 @@ func (c *Client) EventRevenue(ctx context.Context, userID string, orderID string
 		OrderID:    orderID,
 		Amount:     amount,
 		Currency:   currency,
-		Properties: properties,
+		Properties: c.scoped(properties),
 	}
 	return c.internal.Revenue(ctx, revenue)
 }
 
+// EventAlias links a previous identifier, such as an anonymous ID, to userID
+func (c *Client) EventAlias(ctx context.Context, previousID string, userID string) error {
+	alias := Alias{
+		PreviousId: previousID,
+		UserId:     userID,
+		Properties: c.scope,
+	}
+	return c.internal.Alias(ctx, alias)
+}
+
+// Track starts building a track event. Set the user and any overrides, then Send:
+//
+//	client.Track(usercanal.OrderCompleted).
+//	    WithUserID(userID).
+//	    WithDeviceID(deviceID).
+//	    WithoutSession().
+//	    WithString("plan", "pro").
+//	    Send(ctx)
+func (c *Client) Track(eventName EventName) *EventBuilder {
+	return c.internal.NewTrack(eventName).WithProperties(c.scope)
+}
+
+// Identify starts building an identify event; properties set on it are the user's traits
+func (c *Client) Identify(userID string) *EventBuilder {
+	return c.internal.NewIdentify(userID).WithProperties(c.scope)
+}
+
+// Group starts building an event associating a user, set with WithUserID, with groupID
+func (c *Client) Group(groupID string) *EventBuilder {
+	return c.internal.NewGroup(groupID).WithProperties(c.scope)
+}
+
+// Revenue starts building a revenue event for a user set with WithUserID.
+// The type defaults to RevenueTypeOneTime.
+func (c *Client) Revenue(orderID string, amount float64, currency Currency) *EventBuilder {
+	return c.internal.NewRevenue(orderID, amount, currency).WithProperties(c.scope)
+}
+
+// Alias starts building an event linking previousID to userID
+func (c *Client) Alias(previousID string, userID string) *EventBuilder {
+	return c.internal.NewAlias(previousID, userID).WithProperties(c.scope)
+}
+
+// SetConsent records a user's consent decision and tracks it as a ConsentUpdated event.
+// Requires a consent store that supports updates, such as NewMemoryConsentStore.
+func (c *Client) SetConsent(ctx context.Context, userID string, category ConsentCategory, granted bool) error {
+	return c.internal.SetConsent(ctx, userID, category, granted)
+}
+
+// RequestDeletion asks the collector to erase all data for userID ("right to be forgotten").
+// Queued and future events for the user are dropped in this client, and the request is
+// sent immediately, retried up to MaxRetries times while ctx allows. The local block
+// list is bounded, expires after a day and is kept in memory only.
+func (c *Client) RequestDeletion(ctx context.Context, userID string) error {
+	return c.internal.RequestDeletion(ctx, userID)
+}
+
+// Suppress asks the collector to stop processing data for userID and drops the user's
+// queued and future events in this client
+func (c *Client) Suppress(ctx context.Context, userID string) error {
+	return c.internal.Suppress(ctx, userID)
+}
+
+// NewMemoryConsentStore creates an in-memory consent store.
+// defaultGranted applies to users and categories without a recorded decision.
+func NewMemoryConsentStore(defaultGranted bool) *MemoryConsentStore {
+	return consent.NewMemory(defaultGranted)
+}
+
 func (c *Client) EventAdvanced(ctx context.Context, event EventAdvanced) error {
+	event.Properties = c.scoped(event.Properties)
 	return c.internal.TrackAdvanced(ctx, event)
 }
 
@@ -113,6 +279,54 @@ func (c *Client) ResetSession() {
 	c.internal.ResetSession()
 }
 
+// Super-properties
+
+// Register adds properties to every event's properties and log entry's Data from
+// now on, for this client and all handles derived from it. Events and logs share
+// one registry, so there is no way to register a key for only one of them.
+// Per-call values take precedence; registering a key again replaces its value.
+func (c *Client) Register(props Properties) error {
+	return c.internal.Register(props)
+}
+
+// RegisterOnce registers only the properties whose keys are not registered yet
+func (c *Client) RegisterOnce(props Properties) error {
+	return c.internal.RegisterOnce(props)
+}
+
+// Unregister stops adding the given keys
+func (c *Client) Unregister(keys ...string) {
+	c.internal.Unregister(keys...)
+}
+
+// SuperProperties returns a copy of the registered properties
+func (c *Client) SuperProperties() Properties {
+	return c.internal.SuperProperties()
+}
+
+// With returns a handle that adds props to events, logs, loggers, slog handlers and log writers
+// created through it, over registered properties and under per-call values. The
+// handle shares this client's queues and connection; closing either closes both.
+//
+//	checkout := client.With(usercanal.Properties{"tenant": tenantID})
+//	checkout.Event(ctx, userID, usercanal.CheckoutStarted, nil)
+func (c *Client) With(props Properties) *Client {
+	// Copy so later changes to props do not leak into the handle
+	scope := make(Properties, len(c.scope)+len(props))
+	for k, v := range c.scope {
+		scope[k] = v
+	}
+	for k, v := range props {
+		scope[k] = v
+	}
+	return &Client{internal: c.internal, scope: scope}
+}
+
+// scoped returns props over the handle's scoped properties
+func (c *Client) scoped(props Properties) Properties {
+	return superprops.Merge(c.scope, props)
+}
+
 // Re-export types that users need
 type (
 	Properties           = types.Properties
@@ -120,6 +334,8 @@ type (
 	EventAdvanced        = types.EventAdvanced
 	Identity             = types.Identity
 	GroupInfo            = types.GroupInfo
+	Alias                = types.Alias
+	EventBuilder         = api.EventBuilder
 	Revenue              = types.Revenue
 	Product              = types.Product
 	Currency             = types.Currency
@@ -140,6 +356,73 @@ type (
 	Industry             = types.Industry
 )
 
+// Re-export batching types
+type (
+	BatchConfig      = types.BatchConfig
+	AdaptiveBatching = types.AdaptiveBatching
+	OverflowPolicy   = types.OverflowPolicy
+	StreamStats      = types.StreamStats
+)
+
+// Re-export overflow policies
+const (
+	OverflowDropOldest = types.OverflowDropOldest
+	OverflowDropNewest = types.OverflowDropNewest
+	OverflowBlock      = types.OverflowBlock
+)
+
+// ErrQueueFull is returned when a queue with OverflowDropNewest is full
+var ErrQueueFull = types.ErrQueueFull
+
+// Re-export credential types
+type (
+	CredentialProvider = types.CredentialProvider
+	CredentialsFunc    = credentials.Func
+	FileCredentials    = credentials.File
+	EncryptionConfig   = types.EncryptionConfig
+)
+
+// Re-export redaction types
+type (
+	RedactionConfig = types.RedactionConfig
+	RedactionRule   = types.RedactionRule
+	RedactionAction = types.RedactionAction
+)
+
+// Re-export consent types
+type (
+	ConsentConfig      = types.ConsentConfig
+	ConsentCategory    = types.ConsentCategory
+	ConsentMode        = types.ConsentMode
+	ConsentStore       = types.ConsentStore
+	ConsentFunc        = consent.Func
+	MemoryConsentStore = consent.Memory
+)
+
+// Re-export consent constants
+const (
+	ConsentAnalytics = types.ConsentAnalytics
+	ConsentMarketing = types.ConsentMarketing
+	ConsentLogs      = types.ConsentLogs
+
+	ConsentDrop      = types.ConsentDrop
+	ConsentAnonymize = types.ConsentAnonymize
+)
+
+// Re-export redaction constants
+const (
+	RedactDrop     = types.RedactDrop
+	RedactMask     = types.RedactMask
+	RedactTruncate = types.RedactTruncate
+	RedactHash     = types.RedactHash
+
+	RedactPatternEmail      = types.RedactPatternEmail
+	RedactPatternCreditCard = types.RedactPatternCreditCard
+	RedactPatternJWT        = types.RedactPatternJWT
+	RedactPatternIPv4       = types.RedactPatternIPv4
+	RedactPatternIPv6       = types.RedactPatternIPv6
+)
+
 // Re-export constants
 const (
 	// Authentication & User Management Events
@@ -200,6 +483,11 @@ const (
 	SupportTicketCreated  = types.SupportTicketCreated
 	SupportTicketResolved = types.SupportTicketResolved
 
+	// Privacy Events
+	ConsentUpdated       = types.ConsentUpdated
+	DeletionRequested    = types.DeletionRequested
+	SuppressionRequested = types.SuppressionRequested
+
 	// Authentication Methods
 	AuthMethodPassword = types.AuthMethodPassword
 	AuthMethodGoogle   = types.AuthMethodGoogle
@@ -446,54 +734,172 @@ const (
 
 // Logging protocol
 func (c *Client) Log(ctx context.Context, entry LogEntry) error {
+	entry.Data = c.scoped(entry.Data)
 	return c.internal.Log(ctx, entry)
 }
 
 func (c *Client) LogInfo(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogInfo(ctx, service, "", message, data)
+	return c.internal.LogInfo(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogError(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogError(ctx, service, "", message, data)
+	return c.internal.LogError(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogDebug(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogDebug(ctx, service, "", message, data)
+	return c.internal.LogDebug(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogWarning(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogWarning(ctx, service, "", message, data)
+	return c.internal.LogWarning(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogCritical(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogCritical(ctx, service, "", message, data)
+	return c.internal.LogCritical(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogAlert(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogAlert(ctx, service, "", message, data)
+	return c.internal.LogAlert(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogEmergency(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogEmergency(ctx, service, "", message, data)
+	return c.internal.LogEmergency(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogNotice(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogNotice(ctx, service, "", message, data)
+	return c.internal.LogNotice(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogTrace(ctx context.Context, service, message string, data map[string]interface{}) error {
-	return c.internal.LogTrace(ctx, service, "", message, data)
+	return c.internal.LogTrace(ctx, service, "", message, c.scoped(data))
 }
 
 func (c *Client) LogBatch(ctx context.Context, entries []LogEntry) error {
+	if len(c.scope) > 0 {
+		scoped := make([]LogEntry, len(entries))
+		for i, entry := range entries {
+			entry.Data = c.scoped(entry.Data)
+			scoped[i] = entry
+		}
+		entries = scoped
+	}
 	return c.internal.LogBatch(ctx, entries)
 }
 
+// Error tracking
+
+// CaptureError sends err as an error log entry with its unwrapped chain, concrete error
+// types, the calling stack and a fingerprint for grouping. Repeated captures of the same
+// fingerprint are rate limited.
+func (c *Client) CaptureError(ctx context.Context, err error, props Properties) error {
+	return c.internal.CaptureError(ctx, err, c.scoped(props))
+}
+
+// Recover captures a panic as a critical log entry. Use it directly with defer:
+//
+//	defer client.Recover(ctx, nil)
+//
+// The panic is swallowed unless ErrorCaptureConfig.Repanic is set.
+func (c *Client) Recover(ctx context.Context, props Properties) {
+	if r := recover(); r != nil {
+		c.internal.HandlePanic(ctx, r, c.scoped(props))
+	}
+}
+
+// SetLogLevel changes the least severe level sent for service while running.
+// An empty service sets the default for services without their own level.
+func (c *Client) SetLogLevel(service string, level LogLevel) error {
+	return c.internal.SetLogLevel(service, level)
+}
+
+// Logger returns a logger bound to service, with leveled methods taking key/value pairs.
+// With derives child loggers that add fields.
+//
+//	log := client.Logger("checkout", usercanal.WithLoggerLevel(usercanal.LogInfo))
+//	log.With("order_id", id).Error(ctx, "payment declined", "reason", reason)
+func (c *Client) Logger(service string, opts ...LoggerOption) *Logger {
+	if len(c.scope) > 0 {
+		// Scoped fields first so the caller's own fields replace them
+		opts = append([]LoggerOption{logbridge.WithFields(c.scope)}, opts...)
+	}
+	return c.internal.Logger(service, opts...)
+}
+
+// WithLoggerSource sets the source of a logger's entries, replacing the hostname default
+func WithLoggerSource(source string) LoggerOption {
+	return logbridge.WithSource(source)
+}
+
+// WithLoggerSessionID sets the session of a logger's entries
+func WithLoggerSessionID(sessionID []byte) LoggerOption {
+	return logbridge.WithSessionID(sessionID)
+}
+
+// WithLoggerLevel sets the least severe level a logger sends
+func WithLoggerLevel(level LogLevel) LoggerOption {
+	return logbridge.WithLevel(level)
+}
+
+// WithLoggerFields adds fields to every entry of a logger
+func WithLoggerFields(fields Properties) LoggerOption {
+	return logbridge.WithFields(fields)
+}
+
+// SlogHandler returns a log/slog handler backed by the log pipeline. Attributes and
+// groups become nested Data; trace and session IDs are taken from the record's context.
+//
+//	logger := slog.New(client.SlogHandler(usercanal.SlogOptions{Service: "api"}))
+func (c *Client) SlogHandler(opts SlogOptions) slog.Handler {
+	h := c.internal.SlogHandler(opts)
+	if len(c.scope) == 0 {
+		return h
+	}
+	attrs := make([]slog.Attr, 0, len(c.scope))
+	for k, v := range c.scope {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	return h.WithAttrs(attrs)
+}
+
+// LogWriter returns an io.Writer that sends each written line as a log entry, for
+// code that writes to *log.Logger or a plain io.Writer. Lines held for stack trace
+// continuation are sent by Flush and Close.
+//
+//	log.SetOutput(client.LogWriter(usercanal.LogWriterOptions{Service: "legacy"}))
+func (c *Client) LogWriter(opts LogWriterOptions) *LogWriter {
+	// The caller's own fields replace scoped ones
+	opts.Fields = superprops.Merge(c.scope, opts.Fields)
+	return c.internal.LogWriter(opts)
+}
+
+// ContextWithSessionID returns a copy of ctx whose logs carry sessionID
+func ContextWithSessionID(ctx context.Context, sessionID []byte) context.Context {
+	return identity.ContextWithSessionID(ctx, sessionID)
+}
+
+// Additional slog levels for syslog severities slog does not define
+const (
+	LevelTrace     = logbridge.LevelTrace
+	LevelNotice    = logbridge.LevelNotice
+	LevelCritical  = logbridge.LevelCritical
+	LevelAlert     = logbridge.LevelAlert
+	LevelEmergency = logbridge.LevelEmergency
+)
+
 // Re-export log types
 type (
-	LogEntry     = types.LogEntry
-	LogLevel     = types.LogLevel
-	LogEventType = types.LogEventType
+	ErrorCaptureConfig = types.ErrorCaptureConfig
+	LogFilterConfig    = types.LogFilterConfig
+	LogDedupeConfig    = types.LogDedupeConfig
+	PriorityConfig     = types.PriorityConfig
+	Logger             = logbridge.Logger
+	LoggerOption       = logbridge.LoggerOption
+	SlogOptions        = logbridge.HandlerOptions
+	LogWriter          = logbridge.Writer
+	LogWriterOptions   = logbridge.WriterOptions
+	LogEntry           = types.LogEntry
+	LogLevel           = types.LogLevel
+	LogEventType       = types.LogEventType
 )
 
 // Re-export log constants
@@ -514,6 +920,131 @@ const (
 	LogEnrich  = types.LogEnrich
 )
 
+// Metrics protocol
+func (c *Client) Metrics() *Metrics {
+	return c.internal.Metrics()
+}
+
+// Re-export metric types
+type (
+	Metrics   = metrics.Registry
+	Counter   = metrics.Counter
+	Gauge     = metrics.Gauge
+	Histogram = metrics.Histogram
+	Timer     = metrics.Timer
+	Tags      = types.Tags
+)
+
+// DefaultBuckets returns a copy of the histogram bucket bounds used when none are given
+func DefaultBuckets() []float64 {
+	return metrics.DefaultBuckets()
+}
+
+// Re-export runtime metrics types
+type (
+	RuntimeMetricsConfig = types.RuntimeMetricsConfig
+	RuntimeMetricsOutput = types.RuntimeMetricsOutput
+)
+
+// Re-export runtime metrics constants
+const (
+	RuntimeMetricsAsMetrics = types.RuntimeMetricsAsMetrics
+	RuntimeMetricsAsLogs    = types.RuntimeMetricsAsLogs
+)
+
+// DefaultRuntimeMetrics are the runtime/metrics names sampled when none are configured
+var DefaultRuntimeMetrics = types.DefaultRuntimeMetrics
+
+// Inventory protocol
+func (c *Client) ReportInventory(ctx context.Context, inv Inventory) error {
+	return c.internal.ReportInventory(ctx, inv)
+}
+
+// CollectInventory gathers runtime and build details of the current process,
+// ready to be extended with attributes and passed to ReportInventory
+func CollectInventory(service string, includeDependencies bool) Inventory {
+	return inventory.Collect(service, includeDependencies)
+}
+
+// Re-export inventory types
+type (
+	Inventory       = types.Inventory
+	InventoryKind   = types.InventoryKind
+	InventoryConfig = types.InventoryConfig
+	RuntimeInfo     = types.RuntimeInfo
+	BuildInfo       = types.BuildInfo
+	Dependency      = types.Dependency
+)
+
+// Re-export inventory constants
+const (
+	InventoryService = types.InventoryService
+	InventoryAsset   = types.InventoryAsset
+)
+
+// Tracing protocol
+
+// StartSpan begins a span as a child of the span in ctx, or of a remote parent extracted
+// with ExtractTraceContext. Logs and events sent with the returned context carry the
+// span's trace and span IDs. Call End on the span to send it.
+func (c *Client) StartSpan(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+	return c.internal.StartSpan(ctx, name, opts...)
+}
+
+// SpanFromContext returns the active span in ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	return trace.SpanFromContext(ctx)
+}
+
+// InjectTraceContext writes the W3C traceparent header for the active span in ctx
+func InjectTraceContext(ctx context.Context, header http.Header) {
+	trace.Inject(ctx, header)
+}
+
+// ExtractTraceContext reads the W3C traceparent header so the next span continues the caller's trace
+func ExtractTraceContext(ctx context.Context, header http.Header) context.Context {
+	return trace.Extract(ctx, header)
+}
+
+// WithSpanKind sets the role of a span; the default is SpanInternal
+func WithSpanKind(kind SpanKind) SpanOption {
+	return trace.WithSpanKind(kind)
+}
+
+// WithSpanAttributes sets initial span attributes
+func WithSpanAttributes(attrs Properties) SpanOption {
+	return trace.WithAttributes(attrs)
+}
+
+// WithSpanStartTime overrides the span start time
+func WithSpanStartTime(t time.Time) SpanOption {
+	return trace.WithStartTime(t)
+}
+
+// Re-export tracing types
+type (
+	Span        = trace.Span
+	SpanOption  = trace.StartOption
+	SpanContext = types.SpanContext
+	SpanKind    = types.SpanKind
+	SpanStatus  = types.SpanStatus
+	TraceID     = types.TraceID
+	SpanID      = types.SpanID
+)
+
+// Re-export tracing constants
+const (
+	SpanInternal = types.SpanInternal
+	SpanServer   = types.SpanServer
+	SpanClient   = types.SpanClient
+	SpanProducer = types.SpanProducer
+	SpanConsumer = types.SpanConsumer
+
+	SpanStatusUnset = types.SpanStatusUnset
+	SpanStatusOK    = types.SpanStatusOK
+	SpanStatusError = types.SpanStatusError
+)
+
 // Version returns detailed version information
 func Version() version.Info {
 	return version.Get()
@@ -0,0 +1,40 @@
+// sdk-go/usercanal_alloc_test.go
+
+// The race detector adds allocations of its own, so they are only counted without it.
+//go:build !race
+
+package usercanal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Queueing an event allocates its transport record and payload; property
+// encoding and batching add nothing
+func TestClientEventAllocs(t *testing.T) {
+	client, err := NewClient("000102030405060708090a0b0c0d0e0f", Config{
+		Endpoint:      discardServer(t),
+		BatchSize:     1000,
+		FlushInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)
+
+	props := Properties{
+		"feature_name": "export",
+		"duration_ms":  1500,
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := client.Event(ctx, "user_123", FeatureUsed, props); err != nil {
+			t.Fatalf("Event: %v", err)
+		}
+	})
+	if allocs > 2 {
+		t.Errorf("Event allocated %.1f times, want at most 2", allocs)
+	}
+}
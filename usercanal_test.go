@@ -0,0 +1,304 @@
+// sdk-go/usercanal_test.go
+package usercanal
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	schema_common "github.com/usercanal/sdk-go/internal/schema/common"
+	event_schema "github.com/usercanal/sdk-go/internal/schema/event"
+	log_schema "github.com/usercanal/sdk-go/internal/schema/log"
+)
+
+// discardServer accepts connections and reads everything sent on them
+func discardServer(tb testing.TB) string {
+	tb.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("listen: %v", err)
+	}
+	tb.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(io.Discard, conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func BenchmarkClientEvent(b *testing.B) {
+	client, err := NewClient("000102030405060708090a0b0c0d0e0f", Config{
+		Endpoint:      discardServer(b),
+		BatchSize:     1000,
+		FlushInterval: time.Minute,
+	})
+	if err != nil {
+		b.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)
+
+	props := Properties{
+		"feature_name": "export",
+		"duration_ms":  1500,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := client.Event(ctx, "user_123", FeatureUsed, props); err != nil {
+			b.Fatalf("Event: %v", err)
+		}
+	}
+}
+
+// frameServer accepts connections and passes each batch it reads, without the
+// length prefix, to the returned channel
+func frameServer(t *testing.T) (string, <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	frames := make(chan []byte, 64)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var prefix [4]byte
+				for {
+					if _, err := io.ReadFull(conn, prefix[:]); err != nil {
+						return
+					}
+					frame := make([]byte, binary.BigEndian.Uint32(prefix[:]))
+					if _, err := io.ReadFull(conn, frame); err != nil {
+						return
+					}
+					frames <- frame
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String(), frames
+}
+
+// nextPayload returns the payload of the single item in the next batch of the
+// given schema, skipping batches of other schemas
+func nextPayload(t *testing.T, frames <-chan []byte, schema schema_common.SchemaType) map[string]interface{} {
+	t.Helper()
+	for {
+		var frame []byte
+		select {
+		case frame = <-frames:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %v batch received", schema)
+		}
+
+		batch := schema_common.GetRootAsBatch(frame, 0)
+		if batch.SchemaType() != schema {
+			continue
+		}
+
+		var raw []byte
+		switch schema {
+		case schema_common.SchemaTypeEVENT:
+			data := event_schema.GetRootAsEventData(batch.DataBytes(), 0)
+			if n := data.EventsLength(); n != 1 {
+				t.Fatalf("batch holds %d events, want 1", n)
+			}
+			var e event_schema.Event
+			data.Events(&e, 0)
+			raw = e.PayloadBytes()
+		case schema_common.SchemaTypeLOG:
+			data := log_schema.GetRootAsLogData(batch.DataBytes(), 0)
+			if n := data.LogsLength(); n != 1 {
+				t.Fatalf("batch holds %d logs, want 1", n)
+			}
+			var l log_schema.LogEntry
+			data.Logs(&l, 0)
+			raw = l.PayloadBytes()
+		}
+
+		var out map[string]interface{}
+		if err := json.Unmarshal(raw, &out); err != nil {
+			t.Fatalf("decode payload %s: %v", raw, err)
+		}
+		return out
+	}
+}
+
+func TestWithPrecedence(t *testing.T) {
+	endpoint, frames := frameServer(t)
+	client, err := NewClient("000102030405060708090a0b0c0d0e0f", Config{
+		Endpoint:          endpoint,
+		BatchSize:         1000,
+		FlushInterval:     time.Hour,
+		DefaultProperties: Properties{"layer": "default", "default": true},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)
+
+	if err := client.Register(Properties{"layer": "registered", "registered": true}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	scope := Properties{"layer": "scoped", "scoped": true}
+	handle := client.With(scope)
+	scope["layer"] = "changed after With"
+	nested := handle.With(Properties{"nested": true})
+
+	write := func(c *Client, line string) error {
+		w := c.LogWriter(LogWriterOptions{Service: "svc"})
+		defer w.Close()
+		_, err := w.Write([]byte(line + "\n"))
+		return err
+	}
+
+	event, log := schema_common.SchemaTypeEVENT, schema_common.SchemaTypeLOG
+	tests := []struct {
+		name      string
+		schema    schema_common.SchemaType
+		send      func() error
+		wantLayer string
+		wantScope bool
+	}{
+		{
+			name:      "client event",
+			schema:    event,
+			send:      func() error { return client.Event(ctx, "user_1", FeatureUsed, nil) },
+			wantLayer: "registered",
+		},
+		{
+			name:      "scoped event",
+			schema:    event,
+			send:      func() error { return handle.Event(ctx, "user_1", FeatureUsed, nil) },
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+		{
+			name:      "per-call event",
+			schema:    event,
+			send:      func() error { return handle.Event(ctx, "user_1", FeatureUsed, Properties{"layer": "call"}) },
+			wantLayer: "call",
+			wantScope: true,
+		},
+		{
+			name:      "scoped log",
+			schema:    log,
+			send:      func() error { return handle.LogInfo(ctx, "svc", "hello", nil) },
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+		{
+			name:      "per-call log",
+			schema:    log,
+			send:      func() error { return handle.LogInfo(ctx, "svc", "hello", map[string]interface{}{"layer": "call"}) },
+			wantLayer: "call",
+			wantScope: true,
+		},
+		{
+			name:      "scoped logger",
+			schema:    log,
+			send:      func() error { return handle.Logger("svc").Info(ctx, "hello") },
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+		{
+			name:      "per-call logger",
+			schema:    log,
+			send:      func() error { return handle.Logger("svc").Info(ctx, "hello", "layer", "call") },
+			wantLayer: "call",
+			wantScope: true,
+		},
+		{
+			name:   "scoped slog handler",
+			schema: log,
+			send: func() error {
+				slog.New(handle.SlogHandler(SlogOptions{Service: "svc"})).InfoContext(ctx, "hello")
+				return nil
+			},
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+		{
+			name:   "per-call slog handler",
+			schema: log,
+			send: func() error {
+				slog.New(handle.SlogHandler(SlogOptions{Service: "svc"})).InfoContext(ctx, "hello", "layer", "call")
+				return nil
+			},
+			wantLayer: "call",
+			wantScope: true,
+		},
+		{
+			name:      "scoped log writer",
+			schema:    log,
+			send:      func() error { return write(handle, "hello") },
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+		{
+			name:      "per-call log writer",
+			schema:    log,
+			send:      func() error { return write(handle, `{"msg":"hello","layer":"call"}`) },
+			wantLayer: "call",
+			wantScope: true,
+		},
+		{
+			name:      "client log writer",
+			schema:    log,
+			send:      func() error { return write(client, "hello") },
+			wantLayer: "registered",
+		},
+		{
+			name:      "nested handle",
+			schema:    event,
+			send:      func() error { return nested.Event(ctx, "user_1", FeatureUsed, nil) },
+			wantLayer: "scoped",
+			wantScope: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.send(); err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if err := client.Flush(ctx); err != nil {
+				t.Fatalf("Flush: %v", err)
+			}
+
+			got := nextPayload(t, frames, tt.schema)
+			if got["layer"] != tt.wantLayer {
+				t.Errorf("layer = %v, want %s", got["layer"], tt.wantLayer)
+			}
+			if got["default"] != true || got["registered"] != true {
+				t.Errorf("payload %v is missing default or registered properties", got)
+			}
+			if scoped := got["scoped"] == true; scoped != tt.wantScope {
+				t.Errorf("scoped = %v, want %v", got["scoped"], tt.wantScope)
+			}
+			if _, ok := got["nested"]; ok != (tt.name == "nested handle") {
+				t.Errorf("nested = %v in %s", got["nested"], tt.name)
+			}
+		})
+	}
+}